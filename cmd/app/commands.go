@@ -0,0 +1,379 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/cawa87/garantex-test/internal/app"
+	"github.com/cawa87/garantex-test/internal/config"
+	"github.com/cawa87/garantex-test/internal/lib/logger/sl"
+	"github.com/cawa87/garantex-test/internal/service/auth"
+	"github.com/cawa87/garantex-test/internal/service/backfill"
+	"github.com/cawa87/garantex-test/internal/service/exchange"
+	"github.com/cawa87/garantex-test/internal/service/export"
+	"github.com/cawa87/garantex-test/internal/service/importer"
+)
+
+// runCommand dispatches a maintenance subcommand
+func runCommand(cfg *config.Config, name string, args []string) error {
+	switch name {
+	case "create-api-key":
+		return runCreateAPIKey(cfg, args)
+	case "dedupe-rates":
+		return runDedupeRates(cfg, args)
+	case "backfill":
+		return runBackfill(cfg, args)
+	case "export":
+		return runExport(cfg, args)
+	case "import":
+		return runImport(cfg, args)
+	default:
+		return fmt.Errorf("unknown command %q", name)
+	}
+}
+
+// runCreateAPIKey creates an API key directly in the database, e.g. to bootstrap the first admin key
+func runCreateAPIKey(cfg *config.Config, args []string) error {
+	fs := flag.NewFlagSet("create-api-key", flag.ExitOnError)
+	name := fs.String("name", "", "Key name")
+	scopes := fs.String("scopes", auth.ScopeRatesRead, "Comma-separated scopes (rates:read, history:read, admin)")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	if *name == "" {
+		return fmt.Errorf("-name is required")
+	}
+
+	scopeList := strings.Split(*scopes, ",")
+	for i, scope := range scopeList {
+		scopeList[i] = strings.TrimSpace(scope)
+		if !auth.ValidScope(scopeList[i]) {
+			return fmt.Errorf("unknown scope %q", scopeList[i])
+		}
+	}
+
+	logger, err := sl.New(cfg.Log.Level)
+	if err != nil {
+		return fmt.Errorf("failed to create logger: %w", err)
+	}
+
+	repo, err := app.OpenPostgres(cfg.Database, logger)
+	if err != nil {
+		return fmt.Errorf("failed to create repository: %w", err)
+	}
+	defer repo.Close()
+
+	key, plaintext, err := auth.GenerateAPIKey(*name, scopeList, "cli")
+	if err != nil {
+		return err
+	}
+
+	if err := repo.CreateAPIKey(context.Background(), key); err != nil {
+		return err
+	}
+
+	fmt.Printf("API key %s created with scopes %s\n", key.ID, strings.Join(key.Scopes, ","))
+	fmt.Printf("Key (shown only once): %s\n", plaintext)
+	return nil
+}
+
+// runDedupeRates removes duplicate samples from the rates history so migration 000009 can create
+// the unique sample index
+func runDedupeRates(cfg *config.Config, args []string) error {
+	fs := flag.NewFlagSet("dedupe-rates", flag.ExitOnError)
+	batchSize := fs.Int("batch-size", 10000, "Rows deleted per statement")
+	dryRun := fs.Bool("dry-run", false, "Only count duplicates")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	if *batchSize < 1 {
+		return fmt.Errorf("-batch-size must be positive")
+	}
+
+	logger, err := sl.New(cfg.Log.Level)
+	if err != nil {
+		return fmt.Errorf("failed to create logger: %w", err)
+	}
+
+	repo, err := app.OpenPostgres(cfg.Database, logger)
+	if err != nil {
+		return fmt.Errorf("failed to create repository: %w", err)
+	}
+	defer repo.Close()
+
+	ctx := context.Background()
+	if *dryRun {
+		count, err := repo.CountDuplicateRates(ctx)
+		if err != nil {
+			return err
+		}
+		fmt.Printf("%d duplicate rates found\n", count)
+		return nil
+	}
+
+	deleted, err := repo.DeleteDuplicateRates(ctx, *batchSize)
+	if err != nil {
+		return err
+	}
+	fmt.Printf("%d duplicate rates deleted\n", deleted)
+	return nil
+}
+
+// runBackfill fills a window of history from the exchange trades or candles. Rerunning a job with the
+// same name resumes it from its checkpoint
+func runBackfill(cfg *config.Config, args []string) error {
+	fs := flag.NewFlagSet("backfill", flag.ExitOnError)
+	name := fs.String("name", "", "Job name identifying the checkpoint (default market-mode-from)")
+	market := fs.String("market", exchange.DefaultMarket, "Market")
+	mode := fs.String("mode", string(backfill.ModeKLine), "History to read: trades or kline")
+	period := fs.Duration("period", time.Minute, "Candle period for kline mode, whole minutes")
+	from := fs.String("from", "", "Window start, RFC 3339")
+	to := fs.String("to", "", "Window end, RFC 3339 (default now)")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	job := backfill.Job{
+		Name:   *name,
+		Market: *market,
+		Mode:   backfill.Mode(*mode),
+		To:     time.Now().UTC(),
+	}
+	if job.Mode == backfill.ModeKLine {
+		job.Period = *period
+	}
+
+	var err error
+	if job.From, err = time.Parse(time.RFC3339, *from); err != nil {
+		return fmt.Errorf("-from: %w", err)
+	}
+	if *to != "" {
+		if job.To, err = time.Parse(time.RFC3339, *to); err != nil {
+			return fmt.Errorf("-to: %w", err)
+		}
+	}
+	if job.Name == "" {
+		job.Name = fmt.Sprintf("%s-%s-%s", job.Market, job.Mode, job.From.UTC().Format(time.RFC3339))
+	}
+	if err := job.Validate(); err != nil {
+		return err
+	}
+
+	logger, err := sl.New(cfg.Log.Level)
+	if err != nil {
+		return fmt.Errorf("failed to create logger: %w", err)
+	}
+
+	repo, err := app.OpenPostgres(cfg.Database, logger)
+	if err != nil {
+		return fmt.Errorf("failed to create repository: %w", err)
+	}
+	defer repo.Close()
+
+	// Interrupting keeps the checkpoint of the last stored page
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	if _, err := repo.EnsurePartitions(ctx, job.From, job.To); err != nil {
+		return err
+	}
+
+	backfiller := backfill.New(app.NewExchangeClient(cfg.Exchange, logger), repo, logger)
+
+	var report backfill.Report
+	acquired, err := repo.WithAdvisoryLock(ctx, job.LockKey(), func(ctx context.Context) (err error) {
+		report, err = backfiller.Run(ctx, job)
+		return err
+	})
+	if !acquired && err == nil {
+		return fmt.Errorf("backfill %q is already running", job.Name)
+	}
+	if err != nil {
+		if !report.Cursor.IsZero() {
+			fmt.Printf("Backfill %s stopped at %s; rerun to resume\n", job.Name, report.Cursor.Format(time.RFC3339))
+		}
+		return err
+	}
+
+	resumed := ""
+	if report.Resumed {
+		resumed = " (resumed)"
+	}
+	fmt.Printf("Backfill %s completed%s: %d fetched, %d rates inserted\n", job.Name, resumed, report.Fetched, report.Inserted)
+	return nil
+}
+
+// runExport writes the rate history of a market to a file or stdout, raw or as candles. Rows are streamed
+// from the store, so memory use does not grow with the size of the export
+func runExport(cfg *config.Config, args []string) (err error) {
+	fs := flag.NewFlagSet("export", flag.ExitOnError)
+	market := fs.String("market", exchange.DefaultMarket, "Market")
+	from := fs.String("from", "", "Window start, RFC 3339")
+	to := fs.String("to", "", "Window end, RFC 3339, exclusive (default now)")
+	interval := fs.Duration("interval", 0, "Candle interval, whole seconds (default raw rates)")
+	format := fs.String("format", string(export.FormatCSV), "Output format: csv, ndjson or parquet")
+	out := fs.String("out", "", "Output file (default stdout)")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	query := export.Query{
+		Market:   *market,
+		To:       time.Now().UTC(),
+		Interval: *interval,
+		Format:   export.Format(*format),
+	}
+	if query.From, err = time.Parse(time.RFC3339, *from); err != nil {
+		return fmt.Errorf("-from: %w", err)
+	}
+	if *to != "" {
+		if query.To, err = time.Parse(time.RFC3339, *to); err != nil {
+			return fmt.Errorf("-to: %w", err)
+		}
+	}
+	if err := query.Validate(); err != nil {
+		return err
+	}
+
+	logger, err := sl.New(cfg.Log.Level)
+	if err != nil {
+		return fmt.Errorf("failed to create logger: %w", err)
+	}
+
+	store, err := app.OpenStore(cfg.Database, logger)
+	if err != nil {
+		return fmt.Errorf("failed to create repository: %w", err)
+	}
+	defer store.Close()
+
+	file := os.Stdout
+	if *out != "" {
+		if file, err = os.Create(*out); err != nil {
+			return err
+		}
+		defer func() {
+			if closeErr := file.Close(); err == nil {
+				err = closeErr
+			}
+		}()
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	w := bufio.NewWriter(file)
+	rows, err := export.Write(ctx, store, w, query)
+	if err != nil {
+		return err
+	}
+	if err := w.Flush(); err != nil {
+		return err
+	}
+
+	if *out != "" {
+		fmt.Printf("Exported %d rows to %s\n", rows, *out)
+	}
+	return nil
+}
+
+// runImport validates rate history files and loads them into rates, one transaction per file. Files with
+// invalid rows are not loaded unless -skip-invalid is set; the invalid lines are listed either way
+func runImport(cfg *config.Config, args []string) error {
+	fs := flag.NewFlagSet("import", flag.ExitOnError)
+	format := fs.String("format", "", "File format: csv or ndjson (default from the file extension)")
+	columns := fs.String("columns", "", "Column mapping as field=column pairs, e.g. timestamp=Date,ask=Ask Price")
+	tz := fs.String("tz", "UTC", "Time zone of timestamps without an offset")
+	layout := fs.String("layout", "", "Go time layout of timestamps, or unix / unixms (default RFC 3339 and common layouts)")
+	market := fs.String("market", exchange.DefaultMarket, "Market of files without a market column")
+	source := fs.String("source", importer.Source, "Source tag of the imported rates")
+	skipInvalid := fs.Bool("skip-invalid", false, "Load the valid rows of files with invalid rows")
+	maxErrors := fs.Int("max-errors", importer.DefaultMaxErrors, "Invalid lines listed per file")
+	dryRun := fs.Bool("dry-run", false, "Validate and count new rows without storing them")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	if fs.NArg() == 0 {
+		return fmt.Errorf("no files to import")
+	}
+
+	opts := importer.Options{
+		Format:      importer.Format(*format),
+		Layout:      *layout,
+		Market:      *market,
+		Source:      *source,
+		SkipInvalid: *skipInvalid,
+		MaxErrors:   *maxErrors,
+		DryRun:      *dryRun,
+	}
+	var err error
+	if opts.Mapping, err = importer.ParseMapping(*columns); err != nil {
+		return fmt.Errorf("-columns: %w", err)
+	}
+	if opts.Location, err = time.LoadLocation(*tz); err != nil {
+		return fmt.Errorf("-tz: %w", err)
+	}
+
+	logger, err := sl.New(cfg.Log.Level)
+	if err != nil {
+		return fmt.Errorf("failed to create logger: %w", err)
+	}
+
+	repo, err := app.OpenPostgres(cfg.Database, logger)
+	if err != nil {
+		return fmt.Errorf("failed to create repository: %w", err)
+	}
+	defer repo.Close()
+
+	imp, err := importer.New(repo, opts, logger)
+	if err != nil {
+		return err
+	}
+
+	// Interrupting rolls back the file being loaded; files already loaded stay
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	failed := 0
+	for _, path := range fs.Args() {
+		report, err := imp.Import(ctx, path)
+		if report != nil {
+			printImportReport(report)
+		}
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "%s: %v\n", path, err)
+			failed++
+			if ctx.Err() != nil {
+				break
+			}
+		}
+	}
+	if failed > 0 {
+		return fmt.Errorf("%d of %d files failed", failed, fs.NArg())
+	}
+	return nil
+}
+
+// printImportReport prints the outcome of an import and its invalid lines
+func printImportReport(r *importer.Report) {
+	verb := "inserted"
+	if r.DryRun {
+		verb = "would be inserted"
+	}
+	fmt.Printf("%s: %d rows, %d invalid, %d %s, %d duplicates\n", r.File, r.Rows, r.Invalid, r.Inserted, verb, r.Duplicates)
+	for _, e := range r.Errors {
+		fmt.Printf("  %s:%d: %s\n", r.File, e.Line, e.Message)
+	}
+	if hidden := r.Invalid - int64(len(r.Errors)); hidden > 0 {
+		fmt.Printf("  ... and %d more invalid lines\n", hidden)
+	}
+}
@@ -17,11 +17,19 @@ func main() {
 	flag.Parse()
 
 	// Load configuration
-	cfg, err := config.Load()
+	cfg, err := config.Load(configFile)
 	if err != nil {
 		log.Fatalf("Failed to load configuration: %v", err)
 	}
 
+	// Run a maintenance command instead of the service if one was given
+	if flag.NArg() > 0 {
+		if err := runCommand(cfg, flag.Arg(0), flag.Args()[1:]); err != nil {
+			log.Fatalf("Command %s failed: %v", flag.Arg(0), err)
+		}
+		return
+	}
+
 	// Create and run application
 	application, err := app.New(cfg)
 	if err != nil {
@@ -4,10 +4,12 @@ import (
 	"context"
 	"fmt"
 	"log"
+	"os"
 	"time"
 
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
 
 	pb "github.com/cawa87/garantex-test/gen/go/rate_service.v1"
 )
@@ -23,6 +25,10 @@ func main() {
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
 
+	if apiKey := os.Getenv("RATE_SERVICE_API_KEY"); apiKey != "" {
+		ctx = metadata.AppendToOutgoingContext(ctx, "x-api-key", apiKey)
+	}
+
 	fmt.Println("=== Garantex Rate Service Client ===")
 
 	fmt.Println("\n1. Health Check:")
@@ -40,8 +46,8 @@ func main() {
 	if err != nil {
 		log.Fatalf("GetRates failed: %v", err)
 	}
-	fmt.Printf("Ask: $%.2f\n", ratesResp.Ask)
-	fmt.Printf("Bid: $%.2f\n", ratesResp.Bid)
+	fmt.Printf("Ask: $%s\n", ratesResp.AskPrice.GetValue())
+	fmt.Printf("Bid: $%s\n", ratesResp.BidPrice.GetValue())
 	fmt.Printf("Timestamp: %s\n", ratesResp.Timestamp.AsTime().Format(time.RFC3339))
 
 	fmt.Println("\n✅ Service is working correctly!")
@@ -7,8 +7,10 @@
 package rate_service
 
 import (
+	_ "github.com/envoyproxy/protoc-gen-validate/validate"
 	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
 	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	durationpb "google.golang.org/protobuf/types/known/durationpb"
 	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
 	reflect "reflect"
 	sync "sync"
@@ -22,6 +24,120 @@ const (
 	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
 )
 
+// LookupMode selects how GetRateAt resolves a rate between samples
+type LookupMode int32
+
+const (
+	// Same as LOOKUP_MODE_PREVIOUS
+	LookupMode_LOOKUP_MODE_UNSPECIFIED LookupMode = 0
+	// Latest sample at or before the requested time
+	LookupMode_LOOKUP_MODE_PREVIOUS LookupMode = 1
+	// Sample closest to the requested time; the earlier one on ties
+	LookupMode_LOOKUP_MODE_NEAREST LookupMode = 2
+	// Linear interpolation between the samples around the requested time
+	LookupMode_LOOKUP_MODE_INTERPOLATED LookupMode = 3
+)
+
+// Enum value maps for LookupMode.
+var (
+	LookupMode_name = map[int32]string{
+		0: "LOOKUP_MODE_UNSPECIFIED",
+		1: "LOOKUP_MODE_PREVIOUS",
+		2: "LOOKUP_MODE_NEAREST",
+		3: "LOOKUP_MODE_INTERPOLATED",
+	}
+	LookupMode_value = map[string]int32{
+		"LOOKUP_MODE_UNSPECIFIED":  0,
+		"LOOKUP_MODE_PREVIOUS":     1,
+		"LOOKUP_MODE_NEAREST":      2,
+		"LOOKUP_MODE_INTERPOLATED": 3,
+	}
+)
+
+func (x LookupMode) Enum() *LookupMode {
+	p := new(LookupMode)
+	*p = x
+	return p
+}
+
+func (x LookupMode) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (LookupMode) Descriptor() protoreflect.EnumDescriptor {
+	return file_proto_rate_service_v1_rate_service_proto_enumTypes[0].Descriptor()
+}
+
+func (LookupMode) Type() protoreflect.EnumType {
+	return &file_proto_rate_service_v1_rate_service_proto_enumTypes[0]
+}
+
+func (x LookupMode) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use LookupMode.Descriptor instead.
+func (LookupMode) EnumDescriptor() ([]byte, []int) {
+	return file_proto_rate_service_v1_rate_service_proto_rawDescGZIP(), []int{0}
+}
+
+// ExportFormat is the file format of an export
+type ExportFormat int32
+
+const (
+	// Same as EXPORT_FORMAT_CSV
+	ExportFormat_EXPORT_FORMAT_UNSPECIFIED ExportFormat = 0
+	// Comma-separated values with a header row
+	ExportFormat_EXPORT_FORMAT_CSV ExportFormat = 1
+	// One JSON object per line
+	ExportFormat_EXPORT_FORMAT_NDJSON ExportFormat = 2
+	// Apache Parquet file
+	ExportFormat_EXPORT_FORMAT_PARQUET ExportFormat = 3
+)
+
+// Enum value maps for ExportFormat.
+var (
+	ExportFormat_name = map[int32]string{
+		0: "EXPORT_FORMAT_UNSPECIFIED",
+		1: "EXPORT_FORMAT_CSV",
+		2: "EXPORT_FORMAT_NDJSON",
+		3: "EXPORT_FORMAT_PARQUET",
+	}
+	ExportFormat_value = map[string]int32{
+		"EXPORT_FORMAT_UNSPECIFIED": 0,
+		"EXPORT_FORMAT_CSV":         1,
+		"EXPORT_FORMAT_NDJSON":      2,
+		"EXPORT_FORMAT_PARQUET":     3,
+	}
+)
+
+func (x ExportFormat) Enum() *ExportFormat {
+	p := new(ExportFormat)
+	*p = x
+	return p
+}
+
+func (x ExportFormat) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (ExportFormat) Descriptor() protoreflect.EnumDescriptor {
+	return file_proto_rate_service_v1_rate_service_proto_enumTypes[1].Descriptor()
+}
+
+func (ExportFormat) Type() protoreflect.EnumType {
+	return &file_proto_rate_service_v1_rate_service_proto_enumTypes[1]
+}
+
+func (x ExportFormat) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use ExportFormat.Descriptor instead.
+func (ExportFormat) EnumDescriptor() ([]byte, []int) {
+	return file_proto_rate_service_v1_rate_service_proto_rawDescGZIP(), []int{1}
+}
+
 // GetRatesRequest is the request message for GetRates method
 type GetRatesRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
@@ -59,22 +175,80 @@ func (*GetRatesRequest) Descriptor() ([]byte, []int) {
 	return file_proto_rate_service_v1_rate_service_proto_rawDescGZIP(), []int{0}
 }
 
+// Decimal is an exact decimal number
+type Decimal struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Canonical decimal string, e.g. "64250.12345678"
+	Value         string `protobuf:"bytes,1,opt,name=value,proto3" json:"value,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Decimal) Reset() {
+	*x = Decimal{}
+	mi := &file_proto_rate_service_v1_rate_service_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Decimal) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Decimal) ProtoMessage() {}
+
+func (x *Decimal) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_rate_service_v1_rate_service_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Decimal.ProtoReflect.Descriptor instead.
+func (*Decimal) Descriptor() ([]byte, []int) {
+	return file_proto_rate_service_v1_rate_service_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *Decimal) GetValue() string {
+	if x != nil {
+		return x.Value
+	}
+	return ""
+}
+
 // GetRatesResponse is the response message for GetRates method
 type GetRatesResponse struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
-	// Ask price (selling price)
+	// Ask price (selling price); deprecated, use ask_price
+	//
+	// Deprecated: Marked as deprecated in proto/rate_service.v1/rate_service.proto.
 	Ask float64 `protobuf:"fixed64,1,opt,name=ask,proto3" json:"ask,omitempty"`
-	// Bid price (buying price)
+	// Bid price (buying price); deprecated, use bid_price
+	//
+	// Deprecated: Marked as deprecated in proto/rate_service.v1/rate_service.proto.
 	Bid float64 `protobuf:"fixed64,2,opt,name=bid,proto3" json:"bid,omitempty"`
-	// Timestamp when the rate was retrieved
-	Timestamp     *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	// Exchange timestamp of the quote; the receive time if the exchange sent none
+	Timestamp *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	// Exact ask price (selling price)
+	AskPrice *Decimal `protobuf:"bytes,4,opt,name=ask_price,json=askPrice,proto3" json:"ask_price,omitempty"`
+	// Exact bid price (buying price)
+	BidPrice *Decimal `protobuf:"bytes,5,opt,name=bid_price,json=bidPrice,proto3" json:"bid_price,omitempty"`
+	// Local time the exchange response was received
+	ReceivedAt *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=received_at,json=receivedAt,proto3" json:"received_at,omitempty"`
+	// Round trip of the exchange request
+	Latency       *durationpb.Duration `protobuf:"bytes,7,opt,name=latency,proto3" json:"latency,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *GetRatesResponse) Reset() {
 	*x = GetRatesResponse{}
-	mi := &file_proto_rate_service_v1_rate_service_proto_msgTypes[1]
+	mi := &file_proto_rate_service_v1_rate_service_proto_msgTypes[2]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -86,7 +260,7 @@ func (x *GetRatesResponse) String() string {
 func (*GetRatesResponse) ProtoMessage() {}
 
 func (x *GetRatesResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_rate_service_v1_rate_service_proto_msgTypes[1]
+	mi := &file_proto_rate_service_v1_rate_service_proto_msgTypes[2]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -99,9 +273,10 @@ func (x *GetRatesResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetRatesResponse.ProtoReflect.Descriptor instead.
 func (*GetRatesResponse) Descriptor() ([]byte, []int) {
-	return file_proto_rate_service_v1_rate_service_proto_rawDescGZIP(), []int{1}
+	return file_proto_rate_service_v1_rate_service_proto_rawDescGZIP(), []int{2}
 }
 
+// Deprecated: Marked as deprecated in proto/rate_service.v1/rate_service.proto.
 func (x *GetRatesResponse) GetAsk() float64 {
 	if x != nil {
 		return x.Ask
@@ -109,6 +284,7 @@ func (x *GetRatesResponse) GetAsk() float64 {
 	return 0
 }
 
+// Deprecated: Marked as deprecated in proto/rate_service.v1/rate_service.proto.
 func (x *GetRatesResponse) GetBid() float64 {
 	if x != nil {
 		return x.Bid
@@ -123,6 +299,34 @@ func (x *GetRatesResponse) GetTimestamp() *timestamppb.Timestamp {
 	return nil
 }
 
+func (x *GetRatesResponse) GetAskPrice() *Decimal {
+	if x != nil {
+		return x.AskPrice
+	}
+	return nil
+}
+
+func (x *GetRatesResponse) GetBidPrice() *Decimal {
+	if x != nil {
+		return x.BidPrice
+	}
+	return nil
+}
+
+func (x *GetRatesResponse) GetReceivedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ReceivedAt
+	}
+	return nil
+}
+
+func (x *GetRatesResponse) GetLatency() *durationpb.Duration {
+	if x != nil {
+		return x.Latency
+	}
+	return nil
+}
+
 // HealthCheckRequest is the request message for HealthCheck method
 type HealthCheckRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
@@ -132,7 +336,7 @@ type HealthCheckRequest struct {
 
 func (x *HealthCheckRequest) Reset() {
 	*x = HealthCheckRequest{}
-	mi := &file_proto_rate_service_v1_rate_service_proto_msgTypes[2]
+	mi := &file_proto_rate_service_v1_rate_service_proto_msgTypes[3]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -144,7 +348,7 @@ func (x *HealthCheckRequest) String() string {
 func (*HealthCheckRequest) ProtoMessage() {}
 
 func (x *HealthCheckRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_rate_service_v1_rate_service_proto_msgTypes[2]
+	mi := &file_proto_rate_service_v1_rate_service_proto_msgTypes[3]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -157,7 +361,7 @@ func (x *HealthCheckRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use HealthCheckRequest.ProtoReflect.Descriptor instead.
 func (*HealthCheckRequest) Descriptor() ([]byte, []int) {
-	return file_proto_rate_service_v1_rate_service_proto_rawDescGZIP(), []int{2}
+	return file_proto_rate_service_v1_rate_service_proto_rawDescGZIP(), []int{3}
 }
 
 // HealthCheckResponse is the response message for HealthCheck method
@@ -173,7 +377,7 @@ type HealthCheckResponse struct {
 
 func (x *HealthCheckResponse) Reset() {
 	*x = HealthCheckResponse{}
-	mi := &file_proto_rate_service_v1_rate_service_proto_msgTypes[3]
+	mi := &file_proto_rate_service_v1_rate_service_proto_msgTypes[4]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -185,7 +389,7 @@ func (x *HealthCheckResponse) String() string {
 func (*HealthCheckResponse) ProtoMessage() {}
 
 func (x *HealthCheckResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_rate_service_v1_rate_service_proto_msgTypes[3]
+	mi := &file_proto_rate_service_v1_rate_service_proto_msgTypes[4]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -198,7 +402,7 @@ func (x *HealthCheckResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use HealthCheckResponse.ProtoReflect.Descriptor instead.
 func (*HealthCheckResponse) Descriptor() ([]byte, []int) {
-	return file_proto_rate_service_v1_rate_service_proto_rawDescGZIP(), []int{3}
+	return file_proto_rate_service_v1_rate_service_proto_rawDescGZIP(), []int{4}
 }
 
 func (x *HealthCheckResponse) GetStatus() string {
@@ -215,26 +419,1659 @@ func (x *HealthCheckResponse) GetDetails() map[string]string {
 	return nil
 }
 
-var File_proto_rate_service_v1_rate_service_proto protoreflect.FileDescriptor
+// CreateAPIKeyRequest is the request message for CreateAPIKey method
+type CreateAPIKeyRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Human readable key name
+	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	// Granted scopes: rates:read, history:read, admin
+	Scopes        []string `protobuf:"bytes,2,rep,name=scopes,proto3" json:"scopes,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
 
-const file_proto_rate_service_v1_rate_service_proto_rawDesc = "" +
-	"\n" +
-	"(proto/rate_service.v1/rate_service.proto\x12\x0frate_service.v1\x1a\x1fgoogle/protobuf/timestamp.proto\"\x11\n" +
-	"\x0fGetRatesRequest\"p\n" +
-	"\x10GetRatesResponse\x12\x10\n" +
-	"\x03ask\x18\x01 \x01(\x01R\x03ask\x12\x10\n" +
-	"\x03bid\x18\x02 \x01(\x01R\x03bid\x128\n" +
-	"\ttimestamp\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\ttimestamp\"\x14\n" +
-	"\x12HealthCheckRequest\"\xb6\x01\n" +
-	"\x13HealthCheckResponse\x12\x16\n" +
-	"\x06status\x18\x01 \x01(\tR\x06status\x12K\n" +
-	"\adetails\x18\x02 \x03(\v21.rate_service.v1.HealthCheckResponse.DetailsEntryR\adetails\x1a:\n" +
-	"\fDetailsEntry\x12\x10\n" +
-	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
-	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x012\xb8\x01\n" +
+func (x *CreateAPIKeyRequest) Reset() {
+	*x = CreateAPIKeyRequest{}
+	mi := &file_proto_rate_service_v1_rate_service_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateAPIKeyRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateAPIKeyRequest) ProtoMessage() {}
+
+func (x *CreateAPIKeyRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_rate_service_v1_rate_service_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateAPIKeyRequest.ProtoReflect.Descriptor instead.
+func (*CreateAPIKeyRequest) Descriptor() ([]byte, []int) {
+	return file_proto_rate_service_v1_rate_service_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *CreateAPIKeyRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *CreateAPIKeyRequest) GetScopes() []string {
+	if x != nil {
+		return x.Scopes
+	}
+	return nil
+}
+
+// CreateAPIKeyResponse is the response message for CreateAPIKey method
+type CreateAPIKeyResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Key identifier
+	Id string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	// Plaintext key, returned only once; send it in the x-api-key metadata
+	Key string `protobuf:"bytes,2,opt,name=key,proto3" json:"key,omitempty"`
+	// Granted scopes
+	Scopes []string `protobuf:"bytes,3,rep,name=scopes,proto3" json:"scopes,omitempty"`
+	// Creation time
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateAPIKeyResponse) Reset() {
+	*x = CreateAPIKeyResponse{}
+	mi := &file_proto_rate_service_v1_rate_service_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateAPIKeyResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateAPIKeyResponse) ProtoMessage() {}
+
+func (x *CreateAPIKeyResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_rate_service_v1_rate_service_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateAPIKeyResponse.ProtoReflect.Descriptor instead.
+func (*CreateAPIKeyResponse) Descriptor() ([]byte, []int) {
+	return file_proto_rate_service_v1_rate_service_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *CreateAPIKeyResponse) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *CreateAPIKeyResponse) GetKey() string {
+	if x != nil {
+		return x.Key
+	}
+	return ""
+}
+
+func (x *CreateAPIKeyResponse) GetScopes() []string {
+	if x != nil {
+		return x.Scopes
+	}
+	return nil
+}
+
+func (x *CreateAPIKeyResponse) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+// RevokeAPIKeyRequest is the request message for RevokeAPIKey method
+type RevokeAPIKeyRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Key identifier
+	Id            string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RevokeAPIKeyRequest) Reset() {
+	*x = RevokeAPIKeyRequest{}
+	mi := &file_proto_rate_service_v1_rate_service_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RevokeAPIKeyRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RevokeAPIKeyRequest) ProtoMessage() {}
+
+func (x *RevokeAPIKeyRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_rate_service_v1_rate_service_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RevokeAPIKeyRequest.ProtoReflect.Descriptor instead.
+func (*RevokeAPIKeyRequest) Descriptor() ([]byte, []int) {
+	return file_proto_rate_service_v1_rate_service_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *RevokeAPIKeyRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+// RevokeAPIKeyResponse is the response message for RevokeAPIKey method
+type RevokeAPIKeyResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Revocation time
+	RevokedAt     *timestamppb.Timestamp `protobuf:"bytes,1,opt,name=revoked_at,json=revokedAt,proto3" json:"revoked_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RevokeAPIKeyResponse) Reset() {
+	*x = RevokeAPIKeyResponse{}
+	mi := &file_proto_rate_service_v1_rate_service_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RevokeAPIKeyResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RevokeAPIKeyResponse) ProtoMessage() {}
+
+func (x *RevokeAPIKeyResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_rate_service_v1_rate_service_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RevokeAPIKeyResponse.ProtoReflect.Descriptor instead.
+func (*RevokeAPIKeyResponse) Descriptor() ([]byte, []int) {
+	return file_proto_rate_service_v1_rate_service_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *RevokeAPIKeyResponse) GetRevokedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.RevokedAt
+	}
+	return nil
+}
+
+// GetOrderBookRequest is the request message for GetOrderBook method
+type GetOrderBookRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Market, e.g. "btcusdt"; defaults to the tracked market
+	Market string `protobuf:"bytes,1,opt,name=market,proto3" json:"market,omitempty"`
+	// Return the latest snapshot taken at or before this time; unset returns the latest snapshot
+	AsOf          *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=as_of,json=asOf,proto3" json:"as_of,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetOrderBookRequest) Reset() {
+	*x = GetOrderBookRequest{}
+	mi := &file_proto_rate_service_v1_rate_service_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetOrderBookRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetOrderBookRequest) ProtoMessage() {}
+
+func (x *GetOrderBookRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_rate_service_v1_rate_service_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetOrderBookRequest.ProtoReflect.Descriptor instead.
+func (*GetOrderBookRequest) Descriptor() ([]byte, []int) {
+	return file_proto_rate_service_v1_rate_service_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *GetOrderBookRequest) GetMarket() string {
+	if x != nil {
+		return x.Market
+	}
+	return ""
+}
+
+func (x *GetOrderBookRequest) GetAsOf() *timestamppb.Timestamp {
+	if x != nil {
+		return x.AsOf
+	}
+	return nil
+}
+
+// OrderBookLevel is a single aggregated price level
+type OrderBookLevel struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Level price
+	Price *Decimal `protobuf:"bytes,1,opt,name=price,proto3" json:"price,omitempty"`
+	// Volume available at the price
+	Volume        *Decimal `protobuf:"bytes,2,opt,name=volume,proto3" json:"volume,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *OrderBookLevel) Reset() {
+	*x = OrderBookLevel{}
+	mi := &file_proto_rate_service_v1_rate_service_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *OrderBookLevel) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OrderBookLevel) ProtoMessage() {}
+
+func (x *OrderBookLevel) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_rate_service_v1_rate_service_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use OrderBookLevel.ProtoReflect.Descriptor instead.
+func (*OrderBookLevel) Descriptor() ([]byte, []int) {
+	return file_proto_rate_service_v1_rate_service_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *OrderBookLevel) GetPrice() *Decimal {
+	if x != nil {
+		return x.Price
+	}
+	return nil
+}
+
+func (x *OrderBookLevel) GetVolume() *Decimal {
+	if x != nil {
+		return x.Volume
+	}
+	return nil
+}
+
+// GetOrderBookResponse is the response message for GetOrderBook method
+type GetOrderBookResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Market of the snapshot
+	Market string `protobuf:"bytes,1,opt,name=market,proto3" json:"market,omitempty"`
+	// Time the snapshot was taken
+	Timestamp *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	// Asks sorted by ascending price
+	Asks []*OrderBookLevel `protobuf:"bytes,3,rep,name=asks,proto3" json:"asks,omitempty"`
+	// Bids sorted by descending price
+	Bids []*OrderBookLevel `protobuf:"bytes,4,rep,name=bids,proto3" json:"bids,omitempty"`
+	// ID of the rate derived from the snapshot; 0 once the rate has been pruned
+	RateId        int64 `protobuf:"varint,5,opt,name=rate_id,json=rateId,proto3" json:"rate_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetOrderBookResponse) Reset() {
+	*x = GetOrderBookResponse{}
+	mi := &file_proto_rate_service_v1_rate_service_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetOrderBookResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetOrderBookResponse) ProtoMessage() {}
+
+func (x *GetOrderBookResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_rate_service_v1_rate_service_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetOrderBookResponse.ProtoReflect.Descriptor instead.
+func (*GetOrderBookResponse) Descriptor() ([]byte, []int) {
+	return file_proto_rate_service_v1_rate_service_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *GetOrderBookResponse) GetMarket() string {
+	if x != nil {
+		return x.Market
+	}
+	return ""
+}
+
+func (x *GetOrderBookResponse) GetTimestamp() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Timestamp
+	}
+	return nil
+}
+
+func (x *GetOrderBookResponse) GetAsks() []*OrderBookLevel {
+	if x != nil {
+		return x.Asks
+	}
+	return nil
+}
+
+func (x *GetOrderBookResponse) GetBids() []*OrderBookLevel {
+	if x != nil {
+		return x.Bids
+	}
+	return nil
+}
+
+func (x *GetOrderBookResponse) GetRateId() int64 {
+	if x != nil {
+		return x.RateId
+	}
+	return 0
+}
+
+// GetRateAtRequest is the request message for GetRateAt method
+type GetRateAtRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Market, e.g. "btcusdt"; defaults to the tracked market
+	Market string `protobuf:"bytes,1,opt,name=market,proto3" json:"market,omitempty"`
+	// Instant to look the rate up at
+	Timestamp *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	// Lookup mode; defaults to previous
+	Mode          LookupMode `protobuf:"varint,3,opt,name=mode,proto3,enum=rate_service.v1.LookupMode" json:"mode,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetRateAtRequest) Reset() {
+	*x = GetRateAtRequest{}
+	mi := &file_proto_rate_service_v1_rate_service_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetRateAtRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetRateAtRequest) ProtoMessage() {}
+
+func (x *GetRateAtRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_rate_service_v1_rate_service_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetRateAtRequest.ProtoReflect.Descriptor instead.
+func (*GetRateAtRequest) Descriptor() ([]byte, []int) {
+	return file_proto_rate_service_v1_rate_service_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *GetRateAtRequest) GetMarket() string {
+	if x != nil {
+		return x.Market
+	}
+	return ""
+}
+
+func (x *GetRateAtRequest) GetTimestamp() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Timestamp
+	}
+	return nil
+}
+
+func (x *GetRateAtRequest) GetMode() LookupMode {
+	if x != nil {
+		return x.Mode
+	}
+	return LookupMode_LOOKUP_MODE_UNSPECIFIED
+}
+
+// RateSample is a stored rate observation
+type RateSample struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Rate ID
+	Id int64 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	// Exact ask price
+	AskPrice *Decimal `protobuf:"bytes,2,opt,name=ask_price,json=askPrice,proto3" json:"ask_price,omitempty"`
+	// Exact bid price
+	BidPrice *Decimal `protobuf:"bytes,3,opt,name=bid_price,json=bidPrice,proto3" json:"bid_price,omitempty"`
+	// Exchange timestamp of the sample
+	Timestamp     *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RateSample) Reset() {
+	*x = RateSample{}
+	mi := &file_proto_rate_service_v1_rate_service_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RateSample) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RateSample) ProtoMessage() {}
+
+func (x *RateSample) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_rate_service_v1_rate_service_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RateSample.ProtoReflect.Descriptor instead.
+func (*RateSample) Descriptor() ([]byte, []int) {
+	return file_proto_rate_service_v1_rate_service_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *RateSample) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *RateSample) GetAskPrice() *Decimal {
+	if x != nil {
+		return x.AskPrice
+	}
+	return nil
+}
+
+func (x *RateSample) GetBidPrice() *Decimal {
+	if x != nil {
+		return x.BidPrice
+	}
+	return nil
+}
+
+func (x *RateSample) GetTimestamp() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Timestamp
+	}
+	return nil
+}
+
+// GetRateAtResponse is the response message for GetRateAt method
+type GetRateAtResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Market of the rate
+	Market string `protobuf:"bytes,1,opt,name=market,proto3" json:"market,omitempty"`
+	// Requested instant
+	Timestamp *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	// Ask price at the requested instant
+	AskPrice *Decimal `protobuf:"bytes,3,opt,name=ask_price,json=askPrice,proto3" json:"ask_price,omitempty"`
+	// Bid price at the requested instant
+	BidPrice *Decimal `protobuf:"bytes,4,opt,name=bid_price,json=bidPrice,proto3" json:"bid_price,omitempty"`
+	// Sample used, or both bracketing samples when interpolated
+	Samples []*RateSample `protobuf:"bytes,5,rep,name=samples,proto3" json:"samples,omitempty"`
+	// Distance between the requested instant and the furthest sample used
+	Distance *durationpb.Duration `protobuf:"bytes,6,opt,name=distance,proto3" json:"distance,omitempty"`
+	// Mode the rate was resolved with
+	Mode          LookupMode `protobuf:"varint,7,opt,name=mode,proto3,enum=rate_service.v1.LookupMode" json:"mode,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetRateAtResponse) Reset() {
+	*x = GetRateAtResponse{}
+	mi := &file_proto_rate_service_v1_rate_service_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetRateAtResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetRateAtResponse) ProtoMessage() {}
+
+func (x *GetRateAtResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_rate_service_v1_rate_service_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetRateAtResponse.ProtoReflect.Descriptor instead.
+func (*GetRateAtResponse) Descriptor() ([]byte, []int) {
+	return file_proto_rate_service_v1_rate_service_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *GetRateAtResponse) GetMarket() string {
+	if x != nil {
+		return x.Market
+	}
+	return ""
+}
+
+func (x *GetRateAtResponse) GetTimestamp() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Timestamp
+	}
+	return nil
+}
+
+func (x *GetRateAtResponse) GetAskPrice() *Decimal {
+	if x != nil {
+		return x.AskPrice
+	}
+	return nil
+}
+
+func (x *GetRateAtResponse) GetBidPrice() *Decimal {
+	if x != nil {
+		return x.BidPrice
+	}
+	return nil
+}
+
+func (x *GetRateAtResponse) GetSamples() []*RateSample {
+	if x != nil {
+		return x.Samples
+	}
+	return nil
+}
+
+func (x *GetRateAtResponse) GetDistance() *durationpb.Duration {
+	if x != nil {
+		return x.Distance
+	}
+	return nil
+}
+
+func (x *GetRateAtResponse) GetMode() LookupMode {
+	if x != nil {
+		return x.Mode
+	}
+	return LookupMode_LOOKUP_MODE_UNSPECIFIED
+}
+
+// Fixing is a reference rate computed over a daily window
+type Fixing struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Fixing window name, e.g. "msk-noon"
+	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	// Market of the fixing
+	Market string `protobuf:"bytes,2,opt,name=market,proto3" json:"market,omitempty"`
+	// Calendar date of the window start in the window time zone, YYYY-MM-DD
+	Date string `protobuf:"bytes,3,opt,name=date,proto3" json:"date,omitempty"`
+	// Method: twap or vwap
+	Method string `protobuf:"bytes,4,opt,name=method,proto3" json:"method,omitempty"`
+	// Human readable description of the computation
+	Methodology string `protobuf:"bytes,5,opt,name=methodology,proto3" json:"methodology,omitempty"`
+	// Window start (inclusive)
+	WindowStart *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=window_start,json=windowStart,proto3" json:"window_start,omitempty"`
+	// Window end (exclusive)
+	WindowEnd *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=window_end,json=windowEnd,proto3" json:"window_end,omitempty"`
+	// Fixed ask price
+	AskPrice *Decimal `protobuf:"bytes,8,opt,name=ask_price,json=askPrice,proto3" json:"ask_price,omitempty"`
+	// Fixed bid price
+	BidPrice *Decimal `protobuf:"bytes,9,opt,name=bid_price,json=bidPrice,proto3" json:"bid_price,omitempty"`
+	// Mid of the fixed ask and bid
+	MidPrice *Decimal `protobuf:"bytes,10,opt,name=mid_price,json=midPrice,proto3" json:"mid_price,omitempty"`
+	// Number of ticks or order book snapshots in the window
+	SampleCount int32 `protobuf:"varint,11,opt,name=sample_count,json=sampleCount,proto3" json:"sample_count,omitempty"`
+	// Time the fixing was computed
+	ComputedAt    *timestamppb.Timestamp `protobuf:"bytes,12,opt,name=computed_at,json=computedAt,proto3" json:"computed_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Fixing) Reset() {
+	*x = Fixing{}
+	mi := &file_proto_rate_service_v1_rate_service_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Fixing) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Fixing) ProtoMessage() {}
+
+func (x *Fixing) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_rate_service_v1_rate_service_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Fixing.ProtoReflect.Descriptor instead.
+func (*Fixing) Descriptor() ([]byte, []int) {
+	return file_proto_rate_service_v1_rate_service_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *Fixing) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Fixing) GetMarket() string {
+	if x != nil {
+		return x.Market
+	}
+	return ""
+}
+
+func (x *Fixing) GetDate() string {
+	if x != nil {
+		return x.Date
+	}
+	return ""
+}
+
+func (x *Fixing) GetMethod() string {
+	if x != nil {
+		return x.Method
+	}
+	return ""
+}
+
+func (x *Fixing) GetMethodology() string {
+	if x != nil {
+		return x.Methodology
+	}
+	return ""
+}
+
+func (x *Fixing) GetWindowStart() *timestamppb.Timestamp {
+	if x != nil {
+		return x.WindowStart
+	}
+	return nil
+}
+
+func (x *Fixing) GetWindowEnd() *timestamppb.Timestamp {
+	if x != nil {
+		return x.WindowEnd
+	}
+	return nil
+}
+
+func (x *Fixing) GetAskPrice() *Decimal {
+	if x != nil {
+		return x.AskPrice
+	}
+	return nil
+}
+
+func (x *Fixing) GetBidPrice() *Decimal {
+	if x != nil {
+		return x.BidPrice
+	}
+	return nil
+}
+
+func (x *Fixing) GetMidPrice() *Decimal {
+	if x != nil {
+		return x.MidPrice
+	}
+	return nil
+}
+
+func (x *Fixing) GetSampleCount() int32 {
+	if x != nil {
+		return x.SampleCount
+	}
+	return 0
+}
+
+func (x *Fixing) GetComputedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ComputedAt
+	}
+	return nil
+}
+
+// GetFixingRequest is the request message for GetFixing method
+type GetFixingRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Fixing window name
+	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	// Calendar date, YYYY-MM-DD
+	Date          string `protobuf:"bytes,2,opt,name=date,proto3" json:"date,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetFixingRequest) Reset() {
+	*x = GetFixingRequest{}
+	mi := &file_proto_rate_service_v1_rate_service_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetFixingRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetFixingRequest) ProtoMessage() {}
+
+func (x *GetFixingRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_rate_service_v1_rate_service_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetFixingRequest.ProtoReflect.Descriptor instead.
+func (*GetFixingRequest) Descriptor() ([]byte, []int) {
+	return file_proto_rate_service_v1_rate_service_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *GetFixingRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *GetFixingRequest) GetDate() string {
+	if x != nil {
+		return x.Date
+	}
+	return ""
+}
+
+// ListFixingsRequest is the request message for ListFixings method
+type ListFixingsRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Only fixings of this window
+	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	// Only fixings of this market
+	Market string `protobuf:"bytes,2,opt,name=market,proto3" json:"market,omitempty"`
+	// Earliest date, YYYY-MM-DD
+	FromDate string `protobuf:"bytes,3,opt,name=from_date,json=fromDate,proto3" json:"from_date,omitempty"`
+	// Latest date, YYYY-MM-DD
+	ToDate string `protobuf:"bytes,4,opt,name=to_date,json=toDate,proto3" json:"to_date,omitempty"`
+	// Maximum number of fixings; defaults to 100
+	Limit         uint32 `protobuf:"varint,5,opt,name=limit,proto3" json:"limit,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListFixingsRequest) Reset() {
+	*x = ListFixingsRequest{}
+	mi := &file_proto_rate_service_v1_rate_service_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListFixingsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListFixingsRequest) ProtoMessage() {}
+
+func (x *ListFixingsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_rate_service_v1_rate_service_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListFixingsRequest.ProtoReflect.Descriptor instead.
+func (*ListFixingsRequest) Descriptor() ([]byte, []int) {
+	return file_proto_rate_service_v1_rate_service_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *ListFixingsRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *ListFixingsRequest) GetMarket() string {
+	if x != nil {
+		return x.Market
+	}
+	return ""
+}
+
+func (x *ListFixingsRequest) GetFromDate() string {
+	if x != nil {
+		return x.FromDate
+	}
+	return ""
+}
+
+func (x *ListFixingsRequest) GetToDate() string {
+	if x != nil {
+		return x.ToDate
+	}
+	return ""
+}
+
+func (x *ListFixingsRequest) GetLimit() uint32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+// ListFixingsResponse is the response message for ListFixings method
+type ListFixingsResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Fixings, newest date first
+	Fixings       []*Fixing `protobuf:"bytes,1,rep,name=fixings,proto3" json:"fixings,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListFixingsResponse) Reset() {
+	*x = ListFixingsResponse{}
+	mi := &file_proto_rate_service_v1_rate_service_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListFixingsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListFixingsResponse) ProtoMessage() {}
+
+func (x *ListFixingsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_rate_service_v1_rate_service_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListFixingsResponse.ProtoReflect.Descriptor instead.
+func (*ListFixingsResponse) Descriptor() ([]byte, []int) {
+	return file_proto_rate_service_v1_rate_service_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *ListFixingsResponse) GetFixings() []*Fixing {
+	if x != nil {
+		return x.Fixings
+	}
+	return nil
+}
+
+// GetDataQualityRequest is the request message for GetDataQuality method
+type GetDataQualityRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Market, e.g. "btcusdt"; defaults to the tracked market
+	Market string `protobuf:"bytes,1,opt,name=market,proto3" json:"market,omitempty"`
+	// Window start
+	From *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=from,proto3" json:"from,omitempty"`
+	// Window end
+	To *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=to,proto3" json:"to,omitempty"`
+	// Only issues of this kind: gap, frozen, crossed or jump; empty for all
+	Kind string `protobuf:"bytes,4,opt,name=kind,proto3" json:"kind,omitempty"`
+	// Maximum number of issues; defaults to 100
+	Limit         uint32 `protobuf:"varint,5,opt,name=limit,proto3" json:"limit,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetDataQualityRequest) Reset() {
+	*x = GetDataQualityRequest{}
+	mi := &file_proto_rate_service_v1_rate_service_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetDataQualityRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetDataQualityRequest) ProtoMessage() {}
+
+func (x *GetDataQualityRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_rate_service_v1_rate_service_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetDataQualityRequest.ProtoReflect.Descriptor instead.
+func (*GetDataQualityRequest) Descriptor() ([]byte, []int) {
+	return file_proto_rate_service_v1_rate_service_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *GetDataQualityRequest) GetMarket() string {
+	if x != nil {
+		return x.Market
+	}
+	return ""
+}
+
+func (x *GetDataQualityRequest) GetFrom() *timestamppb.Timestamp {
+	if x != nil {
+		return x.From
+	}
+	return nil
+}
+
+func (x *GetDataQualityRequest) GetTo() *timestamppb.Timestamp {
+	if x != nil {
+		return x.To
+	}
+	return nil
+}
+
+func (x *GetDataQualityRequest) GetKind() string {
+	if x != nil {
+		return x.Kind
+	}
+	return ""
+}
+
+func (x *GetDataQualityRequest) GetLimit() uint32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+// DataQualityIssue is a stretch of rate history that failed a data quality check
+type DataQualityIssue struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Market of the issue
+	Market string `protobuf:"bytes,1,opt,name=market,proto3" json:"market,omitempty"`
+	// Kind: gap, frozen, crossed or jump
+	Kind string `protobuf:"bytes,2,opt,name=kind,proto3" json:"kind,omitempty"`
+	// First sample involved
+	Start *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=start,proto3" json:"start,omitempty"`
+	// Last sample involved; the scan time for a gap that was still open
+	End *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=end,proto3" json:"end,omitempty"`
+	// Number of samples involved
+	Samples int32 `protobuf:"varint,5,opt,name=samples,proto3" json:"samples,omitempty"`
+	// Human readable description
+	Detail string `protobuf:"bytes,6,opt,name=detail,proto3" json:"detail,omitempty"`
+	// When the issue was last detected
+	DetectedAt    *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=detected_at,json=detectedAt,proto3" json:"detected_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DataQualityIssue) Reset() {
+	*x = DataQualityIssue{}
+	mi := &file_proto_rate_service_v1_rate_service_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DataQualityIssue) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DataQualityIssue) ProtoMessage() {}
+
+func (x *DataQualityIssue) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_rate_service_v1_rate_service_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DataQualityIssue.ProtoReflect.Descriptor instead.
+func (*DataQualityIssue) Descriptor() ([]byte, []int) {
+	return file_proto_rate_service_v1_rate_service_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *DataQualityIssue) GetMarket() string {
+	if x != nil {
+		return x.Market
+	}
+	return ""
+}
+
+func (x *DataQualityIssue) GetKind() string {
+	if x != nil {
+		return x.Kind
+	}
+	return ""
+}
+
+func (x *DataQualityIssue) GetStart() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Start
+	}
+	return nil
+}
+
+func (x *DataQualityIssue) GetEnd() *timestamppb.Timestamp {
+	if x != nil {
+		return x.End
+	}
+	return nil
+}
+
+func (x *DataQualityIssue) GetSamples() int32 {
+	if x != nil {
+		return x.Samples
+	}
+	return 0
+}
+
+func (x *DataQualityIssue) GetDetail() string {
+	if x != nil {
+		return x.Detail
+	}
+	return ""
+}
+
+func (x *DataQualityIssue) GetDetectedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.DetectedAt
+	}
+	return nil
+}
+
+// GetDataQualityResponse is the response message for GetDataQuality method
+type GetDataQualityResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Market of the report
+	Market string `protobuf:"bytes,1,opt,name=market,proto3" json:"market,omitempty"`
+	// Issues overlapping the window, oldest first
+	Issues []*DataQualityIssue `protobuf:"bytes,2,rep,name=issues,proto3" json:"issues,omitempty"`
+	// Time in the window lost to gaps
+	Missing *durationpb.Duration `protobuf:"bytes,3,opt,name=missing,proto3" json:"missing,omitempty"`
+	// Share of the window not lost to gaps, between 0 and 1
+	Completeness  float64 `protobuf:"fixed64,4,opt,name=completeness,proto3" json:"completeness,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetDataQualityResponse) Reset() {
+	*x = GetDataQualityResponse{}
+	mi := &file_proto_rate_service_v1_rate_service_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetDataQualityResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetDataQualityResponse) ProtoMessage() {}
+
+func (x *GetDataQualityResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_rate_service_v1_rate_service_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetDataQualityResponse.ProtoReflect.Descriptor instead.
+func (*GetDataQualityResponse) Descriptor() ([]byte, []int) {
+	return file_proto_rate_service_v1_rate_service_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *GetDataQualityResponse) GetMarket() string {
+	if x != nil {
+		return x.Market
+	}
+	return ""
+}
+
+func (x *GetDataQualityResponse) GetIssues() []*DataQualityIssue {
+	if x != nil {
+		return x.Issues
+	}
+	return nil
+}
+
+func (x *GetDataQualityResponse) GetMissing() *durationpb.Duration {
+	if x != nil {
+		return x.Missing
+	}
+	return nil
+}
+
+func (x *GetDataQualityResponse) GetCompleteness() float64 {
+	if x != nil {
+		return x.Completeness
+	}
+	return 0
+}
+
+// ExportRatesRequest is the request message for ExportRates method
+type ExportRatesRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Market, e.g. "btcusdt"; defaults to the tracked market
+	Market string `protobuf:"bytes,1,opt,name=market,proto3" json:"market,omitempty"`
+	// Window start, inclusive
+	From *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=from,proto3" json:"from,omitempty"`
+	// Window end, exclusive
+	To *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=to,proto3" json:"to,omitempty"`
+	// Candle interval, a whole number of seconds; unset for raw rates
+	Interval *durationpb.Duration `protobuf:"bytes,4,opt,name=interval,proto3" json:"interval,omitempty"`
+	// File format
+	Format        ExportFormat `protobuf:"varint,5,opt,name=format,proto3,enum=rate_service.v1.ExportFormat" json:"format,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ExportRatesRequest) Reset() {
+	*x = ExportRatesRequest{}
+	mi := &file_proto_rate_service_v1_rate_service_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ExportRatesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExportRatesRequest) ProtoMessage() {}
+
+func (x *ExportRatesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_rate_service_v1_rate_service_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExportRatesRequest.ProtoReflect.Descriptor instead.
+func (*ExportRatesRequest) Descriptor() ([]byte, []int) {
+	return file_proto_rate_service_v1_rate_service_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *ExportRatesRequest) GetMarket() string {
+	if x != nil {
+		return x.Market
+	}
+	return ""
+}
+
+func (x *ExportRatesRequest) GetFrom() *timestamppb.Timestamp {
+	if x != nil {
+		return x.From
+	}
+	return nil
+}
+
+func (x *ExportRatesRequest) GetTo() *timestamppb.Timestamp {
+	if x != nil {
+		return x.To
+	}
+	return nil
+}
+
+func (x *ExportRatesRequest) GetInterval() *durationpb.Duration {
+	if x != nil {
+		return x.Interval
+	}
+	return nil
+}
+
+func (x *ExportRatesRequest) GetFormat() ExportFormat {
+	if x != nil {
+		return x.Format
+	}
+	return ExportFormat_EXPORT_FORMAT_UNSPECIFIED
+}
+
+// ExportChunk is the next part of an export file; the file is the concatenation of the chunks
+type ExportChunk struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Data          []byte                 `protobuf:"bytes,1,opt,name=data,proto3" json:"data,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ExportChunk) Reset() {
+	*x = ExportChunk{}
+	mi := &file_proto_rate_service_v1_rate_service_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ExportChunk) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExportChunk) ProtoMessage() {}
+
+func (x *ExportChunk) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_rate_service_v1_rate_service_proto_msgTypes[23]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExportChunk.ProtoReflect.Descriptor instead.
+func (*ExportChunk) Descriptor() ([]byte, []int) {
+	return file_proto_rate_service_v1_rate_service_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *ExportChunk) GetData() []byte {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+// StreamRatesRequest is the request message for StreamRates method
+type StreamRatesRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Market, e.g. "btcusdt"; defaults to the tracked market
+	Market        string `protobuf:"bytes,1,opt,name=market,proto3" json:"market,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *StreamRatesRequest) Reset() {
+	*x = StreamRatesRequest{}
+	mi := &file_proto_rate_service_v1_rate_service_proto_msgTypes[24]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StreamRatesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StreamRatesRequest) ProtoMessage() {}
+
+func (x *StreamRatesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_rate_service_v1_rate_service_proto_msgTypes[24]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StreamRatesRequest.ProtoReflect.Descriptor instead.
+func (*StreamRatesRequest) Descriptor() ([]byte, []int) {
+	return file_proto_rate_service_v1_rate_service_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *StreamRatesRequest) GetMarket() string {
+	if x != nil {
+		return x.Market
+	}
+	return ""
+}
+
+// RateUpdate is a newly saved rate
+type RateUpdate struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Rate ID
+	Id int64 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	// Market of the rate
+	Market string `protobuf:"bytes,2,opt,name=market,proto3" json:"market,omitempty"`
+	// Source of the rate, e.g. "garantex"
+	Source string `protobuf:"bytes,3,opt,name=source,proto3" json:"source,omitempty"`
+	// Exact ask price
+	AskPrice *Decimal `protobuf:"bytes,4,opt,name=ask_price,json=askPrice,proto3" json:"ask_price,omitempty"`
+	// Exact bid price
+	BidPrice *Decimal `protobuf:"bytes,5,opt,name=bid_price,json=bidPrice,proto3" json:"bid_price,omitempty"`
+	// Exchange timestamp of the rate
+	Timestamp     *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RateUpdate) Reset() {
+	*x = RateUpdate{}
+	mi := &file_proto_rate_service_v1_rate_service_proto_msgTypes[25]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RateUpdate) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RateUpdate) ProtoMessage() {}
+
+func (x *RateUpdate) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_rate_service_v1_rate_service_proto_msgTypes[25]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RateUpdate.ProtoReflect.Descriptor instead.
+func (*RateUpdate) Descriptor() ([]byte, []int) {
+	return file_proto_rate_service_v1_rate_service_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *RateUpdate) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *RateUpdate) GetMarket() string {
+	if x != nil {
+		return x.Market
+	}
+	return ""
+}
+
+func (x *RateUpdate) GetSource() string {
+	if x != nil {
+		return x.Source
+	}
+	return ""
+}
+
+func (x *RateUpdate) GetAskPrice() *Decimal {
+	if x != nil {
+		return x.AskPrice
+	}
+	return nil
+}
+
+func (x *RateUpdate) GetBidPrice() *Decimal {
+	if x != nil {
+		return x.BidPrice
+	}
+	return nil
+}
+
+func (x *RateUpdate) GetTimestamp() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Timestamp
+	}
+	return nil
+}
+
+var File_proto_rate_service_v1_rate_service_proto protoreflect.FileDescriptor
+
+const file_proto_rate_service_v1_rate_service_proto_rawDesc = "" +
+	"\n" +
+	"(proto/rate_service.v1/rate_service.proto\x12\x0frate_service.v1\x1a\x1egoogle/protobuf/duration.proto\x1a\x1fgoogle/protobuf/timestamp.proto\x1a\x17validate/validate.proto\"\x11\n" +
+	"\x0fGetRatesRequest\"\x1f\n" +
+	"\aDecimal\x12\x14\n" +
+	"\x05value\x18\x01 \x01(\tR\x05value\"\xd8\x02\n" +
+	"\x10GetRatesResponse\x12\x14\n" +
+	"\x03ask\x18\x01 \x01(\x01B\x02\x18\x01R\x03ask\x12\x14\n" +
+	"\x03bid\x18\x02 \x01(\x01B\x02\x18\x01R\x03bid\x128\n" +
+	"\ttimestamp\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\ttimestamp\x125\n" +
+	"\task_price\x18\x04 \x01(\v2\x18.rate_service.v1.DecimalR\baskPrice\x125\n" +
+	"\tbid_price\x18\x05 \x01(\v2\x18.rate_service.v1.DecimalR\bbidPrice\x12;\n" +
+	"\vreceived_at\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"receivedAt\x123\n" +
+	"\alatency\x18\a \x01(\v2\x19.google.protobuf.DurationR\alatency\"\x14\n" +
+	"\x12HealthCheckRequest\"\xb6\x01\n" +
+	"\x13HealthCheckResponse\x12\x16\n" +
+	"\x06status\x18\x01 \x01(\tR\x06status\x12K\n" +
+	"\adetails\x18\x02 \x03(\v21.rate_service.v1.HealthCheckResponse.DetailsEntryR\adetails\x1a:\n" +
+	"\fDetailsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"}\n" +
+	"\x13CreateAPIKeyRequest\x12\x1d\n" +
+	"\x04name\x18\x01 \x01(\tB\t\xfaB\x06r\x04\x10\x01\x18@R\x04name\x12G\n" +
+	"\x06scopes\x18\x02 \x03(\tB/\xfaB,\x92\x01)\b\x01\x18\x01\"#r!R\n" +
+	"rates:readR\fhistory:readR\x05adminR\x06scopes\"\x8b\x01\n" +
+	"\x14CreateAPIKeyResponse\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x10\n" +
+	"\x03key\x18\x02 \x01(\tR\x03key\x12\x16\n" +
+	"\x06scopes\x18\x03 \x03(\tR\x06scopes\x129\n" +
+	"\n" +
+	"created_at\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\"<\n" +
+	"\x13RevokeAPIKeyRequest\x12%\n" +
+	"\x02id\x18\x01 \x01(\tB\x15\xfaB\x12r\x102\x0e^[0-9a-f]{12}$R\x02id\"Q\n" +
+	"\x14RevokeAPIKeyResponse\x129\n" +
+	"\n" +
+	"revoked_at\x18\x01 \x01(\v2\x1a.google.protobuf.TimestampR\trevokedAt\"t\n" +
+	"\x13GetOrderBookRequest\x12,\n" +
+	"\x06market\x18\x01 \x01(\tB\x14\xfaB\x11r\x0f\x18\x142\v^[a-z0-9]*$R\x06market\x12/\n" +
+	"\x05as_of\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\x04asOf\"r\n" +
+	"\x0eOrderBookLevel\x12.\n" +
+	"\x05price\x18\x01 \x01(\v2\x18.rate_service.v1.DecimalR\x05price\x120\n" +
+	"\x06volume\x18\x02 \x01(\v2\x18.rate_service.v1.DecimalR\x06volume\"\xeb\x01\n" +
+	"\x14GetOrderBookResponse\x12\x16\n" +
+	"\x06market\x18\x01 \x01(\tR\x06market\x128\n" +
+	"\ttimestamp\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\ttimestamp\x123\n" +
+	"\x04asks\x18\x03 \x03(\v2\x1f.rate_service.v1.OrderBookLevelR\x04asks\x123\n" +
+	"\x04bids\x18\x04 \x03(\v2\x1f.rate_service.v1.OrderBookLevelR\x04bids\x12\x17\n" +
+	"\arate_id\x18\x05 \x01(\x03R\x06rateId\"\xbf\x01\n" +
+	"\x10GetRateAtRequest\x12,\n" +
+	"\x06market\x18\x01 \x01(\tB\x14\xfaB\x11r\x0f\x18\x142\v^[a-z0-9]*$R\x06market\x12B\n" +
+	"\ttimestamp\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampB\b\xfaB\x05\xb2\x01\x02\b\x01R\ttimestamp\x129\n" +
+	"\x04mode\x18\x03 \x01(\x0e2\x1b.rate_service.v1.LookupModeB\b\xfaB\x05\x82\x01\x02\x10\x01R\x04mode\"\xc4\x01\n" +
+	"\n" +
+	"RateSample\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\x125\n" +
+	"\task_price\x18\x02 \x01(\v2\x18.rate_service.v1.DecimalR\baskPrice\x125\n" +
+	"\tbid_price\x18\x03 \x01(\v2\x18.rate_service.v1.DecimalR\bbidPrice\x128\n" +
+	"\ttimestamp\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\ttimestamp\"\xf2\x02\n" +
+	"\x11GetRateAtResponse\x12\x16\n" +
+	"\x06market\x18\x01 \x01(\tR\x06market\x128\n" +
+	"\ttimestamp\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\ttimestamp\x125\n" +
+	"\task_price\x18\x03 \x01(\v2\x18.rate_service.v1.DecimalR\baskPrice\x125\n" +
+	"\tbid_price\x18\x04 \x01(\v2\x18.rate_service.v1.DecimalR\bbidPrice\x125\n" +
+	"\asamples\x18\x05 \x03(\v2\x1b.rate_service.v1.RateSampleR\asamples\x125\n" +
+	"\bdistance\x18\x06 \x01(\v2\x19.google.protobuf.DurationR\bdistance\x12/\n" +
+	"\x04mode\x18\a \x01(\x0e2\x1b.rate_service.v1.LookupModeR\x04mode\"\x81\x04\n" +
+	"\x06Fixing\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x16\n" +
+	"\x06market\x18\x02 \x01(\tR\x06market\x12\x12\n" +
+	"\x04date\x18\x03 \x01(\tR\x04date\x12\x16\n" +
+	"\x06method\x18\x04 \x01(\tR\x06method\x12 \n" +
+	"\vmethodology\x18\x05 \x01(\tR\vmethodology\x12=\n" +
+	"\fwindow_start\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\vwindowStart\x129\n" +
+	"\n" +
+	"window_end\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\twindowEnd\x125\n" +
+	"\task_price\x18\b \x01(\v2\x18.rate_service.v1.DecimalR\baskPrice\x125\n" +
+	"\tbid_price\x18\t \x01(\v2\x18.rate_service.v1.DecimalR\bbidPrice\x125\n" +
+	"\tmid_price\x18\n" +
+	" \x01(\v2\x18.rate_service.v1.DecimalR\bmidPrice\x12!\n" +
+	"\fsample_count\x18\v \x01(\x05R\vsampleCount\x12;\n" +
+	"\vcomputed_at\x18\f \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"computedAt\"j\n" +
+	"\x10GetFixingRequest\x12\x1d\n" +
+	"\x04name\x18\x01 \x01(\tB\t\xfaB\x06r\x04\x10\x01\x18@R\x04name\x127\n" +
+	"\x04date\x18\x02 \x01(\tB#\xfaB r\x1e2\x1c^[0-9]{4}-[0-9]{2}-[0-9]{2}$R\x04date\"\x85\x02\n" +
+	"\x12ListFixingsRequest\x12\x1b\n" +
+	"\x04name\x18\x01 \x01(\tB\a\xfaB\x04r\x02\x18@R\x04name\x12,\n" +
+	"\x06market\x18\x02 \x01(\tB\x14\xfaB\x11r\x0f\x18\x142\v^[a-z0-9]*$R\x06market\x12C\n" +
+	"\tfrom_date\x18\x03 \x01(\tB&\xfaB#r!2\x1f^([0-9]{4}-[0-9]{2}-[0-9]{2})?$R\bfromDate\x12?\n" +
+	"\ato_date\x18\x04 \x01(\tB&\xfaB#r!2\x1f^([0-9]{4}-[0-9]{2}-[0-9]{2})?$R\x06toDate\x12\x1e\n" +
+	"\x05limit\x18\x05 \x01(\rB\b\xfaB\x05*\x03\x18\xe8\aR\x05limit\"H\n" +
+	"\x13ListFixingsResponse\x121\n" +
+	"\afixings\x18\x01 \x03(\v2\x17.rate_service.v1.FixingR\afixings\"\x8e\x02\n" +
+	"\x15GetDataQualityRequest\x12,\n" +
+	"\x06market\x18\x01 \x01(\tB\x14\xfaB\x11r\x0f\x18\x142\v^[a-z0-9]*$R\x06market\x128\n" +
+	"\x04from\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampB\b\xfaB\x05\xb2\x01\x02\b\x01R\x04from\x124\n" +
+	"\x02to\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampB\b\xfaB\x05\xb2\x01\x02\b\x01R\x02to\x127\n" +
+	"\x04kind\x18\x04 \x01(\tB#\xfaB r\x1eR\x00R\x03gapR\x06frozenR\acrossedR\x04jumpR\x04kind\x12\x1e\n" +
+	"\x05limit\x18\x05 \x01(\rB\b\xfaB\x05*\x03\x18\xe8\aR\x05limit\"\x8d\x02\n" +
+	"\x10DataQualityIssue\x12\x16\n" +
+	"\x06market\x18\x01 \x01(\tR\x06market\x12\x12\n" +
+	"\x04kind\x18\x02 \x01(\tR\x04kind\x120\n" +
+	"\x05start\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\x05start\x12,\n" +
+	"\x03end\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\x03end\x12\x18\n" +
+	"\asamples\x18\x05 \x01(\x05R\asamples\x12\x16\n" +
+	"\x06detail\x18\x06 \x01(\tR\x06detail\x12;\n" +
+	"\vdetected_at\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"detectedAt\"\xc4\x01\n" +
+	"\x16GetDataQualityResponse\x12\x16\n" +
+	"\x06market\x18\x01 \x01(\tR\x06market\x129\n" +
+	"\x06issues\x18\x02 \x03(\v2!.rate_service.v1.DataQualityIssueR\x06issues\x123\n" +
+	"\amissing\x18\x03 \x01(\v2\x19.google.protobuf.DurationR\amissing\x12\"\n" +
+	"\fcompleteness\x18\x04 \x01(\x01R\fcompleteness\"\xaa\x02\n" +
+	"\x12ExportRatesRequest\x12,\n" +
+	"\x06market\x18\x01 \x01(\tB\x14\xfaB\x11r\x0f\x18\x142\v^[a-z0-9]*$R\x06market\x128\n" +
+	"\x04from\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampB\b\xfaB\x05\xb2\x01\x02\b\x01R\x04from\x124\n" +
+	"\x02to\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampB\b\xfaB\x05\xb2\x01\x02\b\x01R\x02to\x125\n" +
+	"\binterval\x18\x04 \x01(\v2\x19.google.protobuf.DurationR\binterval\x12?\n" +
+	"\x06format\x18\x05 \x01(\x0e2\x1d.rate_service.v1.ExportFormatB\b\xfaB\x05\x82\x01\x02\x10\x01R\x06format\"!\n" +
+	"\vExportChunk\x12\x12\n" +
+	"\x04data\x18\x01 \x01(\fR\x04data\"B\n" +
+	"\x12StreamRatesRequest\x12,\n" +
+	"\x06market\x18\x01 \x01(\tB\x14\xfaB\x11r\x0f\x18\x142\v^[a-z0-9]*$R\x06market\"\xf4\x01\n" +
+	"\n" +
+	"RateUpdate\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\x12\x16\n" +
+	"\x06market\x18\x02 \x01(\tR\x06market\x12\x16\n" +
+	"\x06source\x18\x03 \x01(\tR\x06source\x125\n" +
+	"\task_price\x18\x04 \x01(\v2\x18.rate_service.v1.DecimalR\baskPrice\x125\n" +
+	"\tbid_price\x18\x05 \x01(\v2\x18.rate_service.v1.DecimalR\bbidPrice\x128\n" +
+	"\ttimestamp\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\ttimestamp*z\n" +
+	"\n" +
+	"LookupMode\x12\x1b\n" +
+	"\x17LOOKUP_MODE_UNSPECIFIED\x10\x00\x12\x18\n" +
+	"\x14LOOKUP_MODE_PREVIOUS\x10\x01\x12\x17\n" +
+	"\x13LOOKUP_MODE_NEAREST\x10\x02\x12\x1c\n" +
+	"\x18LOOKUP_MODE_INTERPOLATED\x10\x03*y\n" +
+	"\fExportFormat\x12\x1d\n" +
+	"\x19EXPORT_FORMAT_UNSPECIFIED\x10\x00\x12\x15\n" +
+	"\x11EXPORT_FORMAT_CSV\x10\x01\x12\x18\n" +
+	"\x14EXPORT_FORMAT_NDJSON\x10\x02\x12\x19\n" +
+	"\x15EXPORT_FORMAT_PARQUET\x10\x032\xd0\a\n" +
 	"\vRateService\x12O\n" +
 	"\bGetRates\x12 .rate_service.v1.GetRatesRequest\x1a!.rate_service.v1.GetRatesResponse\x12X\n" +
-	"\vHealthCheck\x12#.rate_service.v1.HealthCheckRequest\x1a$.rate_service.v1.HealthCheckResponseBEZCgithub.com/cawa87/garantex-test/gen/go/rate_service.v1;rate_serviceb\x06proto3"
+	"\vHealthCheck\x12#.rate_service.v1.HealthCheckRequest\x1a$.rate_service.v1.HealthCheckResponse\x12[\n" +
+	"\fCreateAPIKey\x12$.rate_service.v1.CreateAPIKeyRequest\x1a%.rate_service.v1.CreateAPIKeyResponse\x12[\n" +
+	"\fRevokeAPIKey\x12$.rate_service.v1.RevokeAPIKeyRequest\x1a%.rate_service.v1.RevokeAPIKeyResponse\x12[\n" +
+	"\fGetOrderBook\x12$.rate_service.v1.GetOrderBookRequest\x1a%.rate_service.v1.GetOrderBookResponse\x12R\n" +
+	"\tGetRateAt\x12!.rate_service.v1.GetRateAtRequest\x1a\".rate_service.v1.GetRateAtResponse\x12G\n" +
+	"\tGetFixing\x12!.rate_service.v1.GetFixingRequest\x1a\x17.rate_service.v1.Fixing\x12X\n" +
+	"\vListFixings\x12#.rate_service.v1.ListFixingsRequest\x1a$.rate_service.v1.ListFixingsResponse\x12a\n" +
+	"\x0eGetDataQuality\x12&.rate_service.v1.GetDataQualityRequest\x1a'.rate_service.v1.GetDataQualityResponse\x12R\n" +
+	"\vExportRates\x12#.rate_service.v1.ExportRatesRequest\x1a\x1c.rate_service.v1.ExportChunk0\x01\x12Q\n" +
+	"\vStreamRates\x12#.rate_service.v1.StreamRatesRequest\x1a\x1b.rate_service.v1.RateUpdate0\x01BEZCgithub.com/cawa87/garantex-test/gen/go/rate_service.v1;rate_serviceb\x06proto3"
 
 var (
 	file_proto_rate_service_v1_rate_service_proto_rawDescOnce sync.Once
@@ -248,27 +2085,115 @@ func file_proto_rate_service_v1_rate_service_proto_rawDescGZIP() []byte {
 	return file_proto_rate_service_v1_rate_service_proto_rawDescData
 }
 
-var file_proto_rate_service_v1_rate_service_proto_msgTypes = make([]protoimpl.MessageInfo, 5)
+var file_proto_rate_service_v1_rate_service_proto_enumTypes = make([]protoimpl.EnumInfo, 2)
+var file_proto_rate_service_v1_rate_service_proto_msgTypes = make([]protoimpl.MessageInfo, 27)
 var file_proto_rate_service_v1_rate_service_proto_goTypes = []any{
-	(*GetRatesRequest)(nil),       // 0: rate_service.v1.GetRatesRequest
-	(*GetRatesResponse)(nil),      // 1: rate_service.v1.GetRatesResponse
-	(*HealthCheckRequest)(nil),    // 2: rate_service.v1.HealthCheckRequest
-	(*HealthCheckResponse)(nil),   // 3: rate_service.v1.HealthCheckResponse
-	nil,                           // 4: rate_service.v1.HealthCheckResponse.DetailsEntry
-	(*timestamppb.Timestamp)(nil), // 5: google.protobuf.Timestamp
+	(LookupMode)(0),                // 0: rate_service.v1.LookupMode
+	(ExportFormat)(0),              // 1: rate_service.v1.ExportFormat
+	(*GetRatesRequest)(nil),        // 2: rate_service.v1.GetRatesRequest
+	(*Decimal)(nil),                // 3: rate_service.v1.Decimal
+	(*GetRatesResponse)(nil),       // 4: rate_service.v1.GetRatesResponse
+	(*HealthCheckRequest)(nil),     // 5: rate_service.v1.HealthCheckRequest
+	(*HealthCheckResponse)(nil),    // 6: rate_service.v1.HealthCheckResponse
+	(*CreateAPIKeyRequest)(nil),    // 7: rate_service.v1.CreateAPIKeyRequest
+	(*CreateAPIKeyResponse)(nil),   // 8: rate_service.v1.CreateAPIKeyResponse
+	(*RevokeAPIKeyRequest)(nil),    // 9: rate_service.v1.RevokeAPIKeyRequest
+	(*RevokeAPIKeyResponse)(nil),   // 10: rate_service.v1.RevokeAPIKeyResponse
+	(*GetOrderBookRequest)(nil),    // 11: rate_service.v1.GetOrderBookRequest
+	(*OrderBookLevel)(nil),         // 12: rate_service.v1.OrderBookLevel
+	(*GetOrderBookResponse)(nil),   // 13: rate_service.v1.GetOrderBookResponse
+	(*GetRateAtRequest)(nil),       // 14: rate_service.v1.GetRateAtRequest
+	(*RateSample)(nil),             // 15: rate_service.v1.RateSample
+	(*GetRateAtResponse)(nil),      // 16: rate_service.v1.GetRateAtResponse
+	(*Fixing)(nil),                 // 17: rate_service.v1.Fixing
+	(*GetFixingRequest)(nil),       // 18: rate_service.v1.GetFixingRequest
+	(*ListFixingsRequest)(nil),     // 19: rate_service.v1.ListFixingsRequest
+	(*ListFixingsResponse)(nil),    // 20: rate_service.v1.ListFixingsResponse
+	(*GetDataQualityRequest)(nil),  // 21: rate_service.v1.GetDataQualityRequest
+	(*DataQualityIssue)(nil),       // 22: rate_service.v1.DataQualityIssue
+	(*GetDataQualityResponse)(nil), // 23: rate_service.v1.GetDataQualityResponse
+	(*ExportRatesRequest)(nil),     // 24: rate_service.v1.ExportRatesRequest
+	(*ExportChunk)(nil),            // 25: rate_service.v1.ExportChunk
+	(*StreamRatesRequest)(nil),     // 26: rate_service.v1.StreamRatesRequest
+	(*RateUpdate)(nil),             // 27: rate_service.v1.RateUpdate
+	nil,                            // 28: rate_service.v1.HealthCheckResponse.DetailsEntry
+	(*timestamppb.Timestamp)(nil),  // 29: google.protobuf.Timestamp
+	(*durationpb.Duration)(nil),    // 30: google.protobuf.Duration
 }
 var file_proto_rate_service_v1_rate_service_proto_depIdxs = []int32{
-	5, // 0: rate_service.v1.GetRatesResponse.timestamp:type_name -> google.protobuf.Timestamp
-	4, // 1: rate_service.v1.HealthCheckResponse.details:type_name -> rate_service.v1.HealthCheckResponse.DetailsEntry
-	0, // 2: rate_service.v1.RateService.GetRates:input_type -> rate_service.v1.GetRatesRequest
-	2, // 3: rate_service.v1.RateService.HealthCheck:input_type -> rate_service.v1.HealthCheckRequest
-	1, // 4: rate_service.v1.RateService.GetRates:output_type -> rate_service.v1.GetRatesResponse
-	3, // 5: rate_service.v1.RateService.HealthCheck:output_type -> rate_service.v1.HealthCheckResponse
-	4, // [4:6] is the sub-list for method output_type
-	2, // [2:4] is the sub-list for method input_type
-	2, // [2:2] is the sub-list for extension type_name
-	2, // [2:2] is the sub-list for extension extendee
-	0, // [0:2] is the sub-list for field type_name
+	29, // 0: rate_service.v1.GetRatesResponse.timestamp:type_name -> google.protobuf.Timestamp
+	3,  // 1: rate_service.v1.GetRatesResponse.ask_price:type_name -> rate_service.v1.Decimal
+	3,  // 2: rate_service.v1.GetRatesResponse.bid_price:type_name -> rate_service.v1.Decimal
+	29, // 3: rate_service.v1.GetRatesResponse.received_at:type_name -> google.protobuf.Timestamp
+	30, // 4: rate_service.v1.GetRatesResponse.latency:type_name -> google.protobuf.Duration
+	28, // 5: rate_service.v1.HealthCheckResponse.details:type_name -> rate_service.v1.HealthCheckResponse.DetailsEntry
+	29, // 6: rate_service.v1.CreateAPIKeyResponse.created_at:type_name -> google.protobuf.Timestamp
+	29, // 7: rate_service.v1.RevokeAPIKeyResponse.revoked_at:type_name -> google.protobuf.Timestamp
+	29, // 8: rate_service.v1.GetOrderBookRequest.as_of:type_name -> google.protobuf.Timestamp
+	3,  // 9: rate_service.v1.OrderBookLevel.price:type_name -> rate_service.v1.Decimal
+	3,  // 10: rate_service.v1.OrderBookLevel.volume:type_name -> rate_service.v1.Decimal
+	29, // 11: rate_service.v1.GetOrderBookResponse.timestamp:type_name -> google.protobuf.Timestamp
+	12, // 12: rate_service.v1.GetOrderBookResponse.asks:type_name -> rate_service.v1.OrderBookLevel
+	12, // 13: rate_service.v1.GetOrderBookResponse.bids:type_name -> rate_service.v1.OrderBookLevel
+	29, // 14: rate_service.v1.GetRateAtRequest.timestamp:type_name -> google.protobuf.Timestamp
+	0,  // 15: rate_service.v1.GetRateAtRequest.mode:type_name -> rate_service.v1.LookupMode
+	3,  // 16: rate_service.v1.RateSample.ask_price:type_name -> rate_service.v1.Decimal
+	3,  // 17: rate_service.v1.RateSample.bid_price:type_name -> rate_service.v1.Decimal
+	29, // 18: rate_service.v1.RateSample.timestamp:type_name -> google.protobuf.Timestamp
+	29, // 19: rate_service.v1.GetRateAtResponse.timestamp:type_name -> google.protobuf.Timestamp
+	3,  // 20: rate_service.v1.GetRateAtResponse.ask_price:type_name -> rate_service.v1.Decimal
+	3,  // 21: rate_service.v1.GetRateAtResponse.bid_price:type_name -> rate_service.v1.Decimal
+	15, // 22: rate_service.v1.GetRateAtResponse.samples:type_name -> rate_service.v1.RateSample
+	30, // 23: rate_service.v1.GetRateAtResponse.distance:type_name -> google.protobuf.Duration
+	0,  // 24: rate_service.v1.GetRateAtResponse.mode:type_name -> rate_service.v1.LookupMode
+	29, // 25: rate_service.v1.Fixing.window_start:type_name -> google.protobuf.Timestamp
+	29, // 26: rate_service.v1.Fixing.window_end:type_name -> google.protobuf.Timestamp
+	3,  // 27: rate_service.v1.Fixing.ask_price:type_name -> rate_service.v1.Decimal
+	3,  // 28: rate_service.v1.Fixing.bid_price:type_name -> rate_service.v1.Decimal
+	3,  // 29: rate_service.v1.Fixing.mid_price:type_name -> rate_service.v1.Decimal
+	29, // 30: rate_service.v1.Fixing.computed_at:type_name -> google.protobuf.Timestamp
+	17, // 31: rate_service.v1.ListFixingsResponse.fixings:type_name -> rate_service.v1.Fixing
+	29, // 32: rate_service.v1.GetDataQualityRequest.from:type_name -> google.protobuf.Timestamp
+	29, // 33: rate_service.v1.GetDataQualityRequest.to:type_name -> google.protobuf.Timestamp
+	29, // 34: rate_service.v1.DataQualityIssue.start:type_name -> google.protobuf.Timestamp
+	29, // 35: rate_service.v1.DataQualityIssue.end:type_name -> google.protobuf.Timestamp
+	29, // 36: rate_service.v1.DataQualityIssue.detected_at:type_name -> google.protobuf.Timestamp
+	22, // 37: rate_service.v1.GetDataQualityResponse.issues:type_name -> rate_service.v1.DataQualityIssue
+	30, // 38: rate_service.v1.GetDataQualityResponse.missing:type_name -> google.protobuf.Duration
+	29, // 39: rate_service.v1.ExportRatesRequest.from:type_name -> google.protobuf.Timestamp
+	29, // 40: rate_service.v1.ExportRatesRequest.to:type_name -> google.protobuf.Timestamp
+	30, // 41: rate_service.v1.ExportRatesRequest.interval:type_name -> google.protobuf.Duration
+	1,  // 42: rate_service.v1.ExportRatesRequest.format:type_name -> rate_service.v1.ExportFormat
+	3,  // 43: rate_service.v1.RateUpdate.ask_price:type_name -> rate_service.v1.Decimal
+	3,  // 44: rate_service.v1.RateUpdate.bid_price:type_name -> rate_service.v1.Decimal
+	29, // 45: rate_service.v1.RateUpdate.timestamp:type_name -> google.protobuf.Timestamp
+	2,  // 46: rate_service.v1.RateService.GetRates:input_type -> rate_service.v1.GetRatesRequest
+	5,  // 47: rate_service.v1.RateService.HealthCheck:input_type -> rate_service.v1.HealthCheckRequest
+	7,  // 48: rate_service.v1.RateService.CreateAPIKey:input_type -> rate_service.v1.CreateAPIKeyRequest
+	9,  // 49: rate_service.v1.RateService.RevokeAPIKey:input_type -> rate_service.v1.RevokeAPIKeyRequest
+	11, // 50: rate_service.v1.RateService.GetOrderBook:input_type -> rate_service.v1.GetOrderBookRequest
+	14, // 51: rate_service.v1.RateService.GetRateAt:input_type -> rate_service.v1.GetRateAtRequest
+	18, // 52: rate_service.v1.RateService.GetFixing:input_type -> rate_service.v1.GetFixingRequest
+	19, // 53: rate_service.v1.RateService.ListFixings:input_type -> rate_service.v1.ListFixingsRequest
+	21, // 54: rate_service.v1.RateService.GetDataQuality:input_type -> rate_service.v1.GetDataQualityRequest
+	24, // 55: rate_service.v1.RateService.ExportRates:input_type -> rate_service.v1.ExportRatesRequest
+	26, // 56: rate_service.v1.RateService.StreamRates:input_type -> rate_service.v1.StreamRatesRequest
+	4,  // 57: rate_service.v1.RateService.GetRates:output_type -> rate_service.v1.GetRatesResponse
+	6,  // 58: rate_service.v1.RateService.HealthCheck:output_type -> rate_service.v1.HealthCheckResponse
+	8,  // 59: rate_service.v1.RateService.CreateAPIKey:output_type -> rate_service.v1.CreateAPIKeyResponse
+	10, // 60: rate_service.v1.RateService.RevokeAPIKey:output_type -> rate_service.v1.RevokeAPIKeyResponse
+	13, // 61: rate_service.v1.RateService.GetOrderBook:output_type -> rate_service.v1.GetOrderBookResponse
+	16, // 62: rate_service.v1.RateService.GetRateAt:output_type -> rate_service.v1.GetRateAtResponse
+	17, // 63: rate_service.v1.RateService.GetFixing:output_type -> rate_service.v1.Fixing
+	20, // 64: rate_service.v1.RateService.ListFixings:output_type -> rate_service.v1.ListFixingsResponse
+	23, // 65: rate_service.v1.RateService.GetDataQuality:output_type -> rate_service.v1.GetDataQualityResponse
+	25, // 66: rate_service.v1.RateService.ExportRates:output_type -> rate_service.v1.ExportChunk
+	27, // 67: rate_service.v1.RateService.StreamRates:output_type -> rate_service.v1.RateUpdate
+	57, // [57:68] is the sub-list for method output_type
+	46, // [46:57] is the sub-list for method input_type
+	46, // [46:46] is the sub-list for extension type_name
+	46, // [46:46] is the sub-list for extension extendee
+	0,  // [0:46] is the sub-list for field type_name
 }
 
 func init() { file_proto_rate_service_v1_rate_service_proto_init() }
@@ -281,13 +2206,14 @@ func file_proto_rate_service_v1_rate_service_proto_init() {
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_proto_rate_service_v1_rate_service_proto_rawDesc), len(file_proto_rate_service_v1_rate_service_proto_rawDesc)),
-			NumEnums:      0,
-			NumMessages:   5,
+			NumEnums:      2,
+			NumMessages:   27,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
 		GoTypes:           file_proto_rate_service_v1_rate_service_proto_goTypes,
 		DependencyIndexes: file_proto_rate_service_v1_rate_service_proto_depIdxs,
+		EnumInfos:         file_proto_rate_service_v1_rate_service_proto_enumTypes,
 		MessageInfos:      file_proto_rate_service_v1_rate_service_proto_msgTypes,
 	}.Build()
 	File_proto_rate_service_v1_rate_service_proto = out.File
@@ -0,0 +1,4208 @@
+// Code generated by protoc-gen-validate. DO NOT EDIT.
+// source: proto/rate_service.v1/rate_service.proto
+
+package rate_service
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"net"
+	"net/mail"
+	"net/url"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"google.golang.org/protobuf/types/known/anypb"
+)
+
+// ensure the imports are used
+var (
+	_ = bytes.MinRead
+	_ = errors.New("")
+	_ = fmt.Print
+	_ = utf8.UTFMax
+	_ = (*regexp.Regexp)(nil)
+	_ = (*strings.Reader)(nil)
+	_ = net.IPv4len
+	_ = time.Duration(0)
+	_ = (*url.URL)(nil)
+	_ = (*mail.Address)(nil)
+	_ = anypb.Any{}
+	_ = sort.Sort
+)
+
+// Validate checks the field values on GetRatesRequest with the rules defined
+// in the proto definition for this message. If any rules are violated, the
+// first error encountered is returned, or nil if there are no violations.
+func (m *GetRatesRequest) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on GetRatesRequest with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// GetRatesRequestMultiError, or nil if none found.
+func (m *GetRatesRequest) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *GetRatesRequest) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if len(errors) > 0 {
+		return GetRatesRequestMultiError(errors)
+	}
+
+	return nil
+}
+
+// GetRatesRequestMultiError is an error wrapping multiple validation errors
+// returned by GetRatesRequest.ValidateAll() if the designated constraints
+// aren't met.
+type GetRatesRequestMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m GetRatesRequestMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m GetRatesRequestMultiError) AllErrors() []error { return m }
+
+// GetRatesRequestValidationError is the validation error returned by
+// GetRatesRequest.Validate if the designated constraints aren't met.
+type GetRatesRequestValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e GetRatesRequestValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e GetRatesRequestValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e GetRatesRequestValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e GetRatesRequestValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e GetRatesRequestValidationError) ErrorName() string { return "GetRatesRequestValidationError" }
+
+// Error satisfies the builtin error interface
+func (e GetRatesRequestValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sGetRatesRequest.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = GetRatesRequestValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = GetRatesRequestValidationError{}
+
+// Validate checks the field values on Decimal with the rules defined in the
+// proto definition for this message. If any rules are violated, the first
+// error encountered is returned, or nil if there are no violations.
+func (m *Decimal) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on Decimal with the rules defined in the
+// proto definition for this message. If any rules are violated, the result is
+// a list of violation errors wrapped in DecimalMultiError, or nil if none found.
+func (m *Decimal) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *Decimal) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	// no validation rules for Value
+
+	if len(errors) > 0 {
+		return DecimalMultiError(errors)
+	}
+
+	return nil
+}
+
+// DecimalMultiError is an error wrapping multiple validation errors returned
+// by Decimal.ValidateAll() if the designated constraints aren't met.
+type DecimalMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m DecimalMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m DecimalMultiError) AllErrors() []error { return m }
+
+// DecimalValidationError is the validation error returned by Decimal.Validate
+// if the designated constraints aren't met.
+type DecimalValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e DecimalValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e DecimalValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e DecimalValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e DecimalValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e DecimalValidationError) ErrorName() string { return "DecimalValidationError" }
+
+// Error satisfies the builtin error interface
+func (e DecimalValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sDecimal.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = DecimalValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = DecimalValidationError{}
+
+// Validate checks the field values on GetRatesResponse with the rules defined
+// in the proto definition for this message. If any rules are violated, the
+// first error encountered is returned, or nil if there are no violations.
+func (m *GetRatesResponse) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on GetRatesResponse with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// GetRatesResponseMultiError, or nil if none found.
+func (m *GetRatesResponse) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *GetRatesResponse) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	// no validation rules for Ask
+
+	// no validation rules for Bid
+
+	if all {
+		switch v := interface{}(m.GetTimestamp()).(type) {
+		case interface{ ValidateAll() error }:
+			if err := v.ValidateAll(); err != nil {
+				errors = append(errors, GetRatesResponseValidationError{
+					field:  "Timestamp",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		case interface{ Validate() error }:
+			if err := v.Validate(); err != nil {
+				errors = append(errors, GetRatesResponseValidationError{
+					field:  "Timestamp",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		}
+	} else if v, ok := interface{}(m.GetTimestamp()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return GetRatesResponseValidationError{
+				field:  "Timestamp",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	if all {
+		switch v := interface{}(m.GetAskPrice()).(type) {
+		case interface{ ValidateAll() error }:
+			if err := v.ValidateAll(); err != nil {
+				errors = append(errors, GetRatesResponseValidationError{
+					field:  "AskPrice",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		case interface{ Validate() error }:
+			if err := v.Validate(); err != nil {
+				errors = append(errors, GetRatesResponseValidationError{
+					field:  "AskPrice",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		}
+	} else if v, ok := interface{}(m.GetAskPrice()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return GetRatesResponseValidationError{
+				field:  "AskPrice",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	if all {
+		switch v := interface{}(m.GetBidPrice()).(type) {
+		case interface{ ValidateAll() error }:
+			if err := v.ValidateAll(); err != nil {
+				errors = append(errors, GetRatesResponseValidationError{
+					field:  "BidPrice",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		case interface{ Validate() error }:
+			if err := v.Validate(); err != nil {
+				errors = append(errors, GetRatesResponseValidationError{
+					field:  "BidPrice",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		}
+	} else if v, ok := interface{}(m.GetBidPrice()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return GetRatesResponseValidationError{
+				field:  "BidPrice",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	if all {
+		switch v := interface{}(m.GetReceivedAt()).(type) {
+		case interface{ ValidateAll() error }:
+			if err := v.ValidateAll(); err != nil {
+				errors = append(errors, GetRatesResponseValidationError{
+					field:  "ReceivedAt",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		case interface{ Validate() error }:
+			if err := v.Validate(); err != nil {
+				errors = append(errors, GetRatesResponseValidationError{
+					field:  "ReceivedAt",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		}
+	} else if v, ok := interface{}(m.GetReceivedAt()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return GetRatesResponseValidationError{
+				field:  "ReceivedAt",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	if all {
+		switch v := interface{}(m.GetLatency()).(type) {
+		case interface{ ValidateAll() error }:
+			if err := v.ValidateAll(); err != nil {
+				errors = append(errors, GetRatesResponseValidationError{
+					field:  "Latency",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		case interface{ Validate() error }:
+			if err := v.Validate(); err != nil {
+				errors = append(errors, GetRatesResponseValidationError{
+					field:  "Latency",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		}
+	} else if v, ok := interface{}(m.GetLatency()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return GetRatesResponseValidationError{
+				field:  "Latency",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	if len(errors) > 0 {
+		return GetRatesResponseMultiError(errors)
+	}
+
+	return nil
+}
+
+// GetRatesResponseMultiError is an error wrapping multiple validation errors
+// returned by GetRatesResponse.ValidateAll() if the designated constraints
+// aren't met.
+type GetRatesResponseMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m GetRatesResponseMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m GetRatesResponseMultiError) AllErrors() []error { return m }
+
+// GetRatesResponseValidationError is the validation error returned by
+// GetRatesResponse.Validate if the designated constraints aren't met.
+type GetRatesResponseValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e GetRatesResponseValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e GetRatesResponseValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e GetRatesResponseValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e GetRatesResponseValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e GetRatesResponseValidationError) ErrorName() string { return "GetRatesResponseValidationError" }
+
+// Error satisfies the builtin error interface
+func (e GetRatesResponseValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sGetRatesResponse.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = GetRatesResponseValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = GetRatesResponseValidationError{}
+
+// Validate checks the field values on HealthCheckRequest with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *HealthCheckRequest) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on HealthCheckRequest with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// HealthCheckRequestMultiError, or nil if none found.
+func (m *HealthCheckRequest) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *HealthCheckRequest) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if len(errors) > 0 {
+		return HealthCheckRequestMultiError(errors)
+	}
+
+	return nil
+}
+
+// HealthCheckRequestMultiError is an error wrapping multiple validation errors
+// returned by HealthCheckRequest.ValidateAll() if the designated constraints
+// aren't met.
+type HealthCheckRequestMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m HealthCheckRequestMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m HealthCheckRequestMultiError) AllErrors() []error { return m }
+
+// HealthCheckRequestValidationError is the validation error returned by
+// HealthCheckRequest.Validate if the designated constraints aren't met.
+type HealthCheckRequestValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e HealthCheckRequestValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e HealthCheckRequestValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e HealthCheckRequestValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e HealthCheckRequestValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e HealthCheckRequestValidationError) ErrorName() string {
+	return "HealthCheckRequestValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e HealthCheckRequestValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sHealthCheckRequest.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = HealthCheckRequestValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = HealthCheckRequestValidationError{}
+
+// Validate checks the field values on HealthCheckResponse with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *HealthCheckResponse) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on HealthCheckResponse with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// HealthCheckResponseMultiError, or nil if none found.
+func (m *HealthCheckResponse) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *HealthCheckResponse) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	// no validation rules for Status
+
+	// no validation rules for Details
+
+	if len(errors) > 0 {
+		return HealthCheckResponseMultiError(errors)
+	}
+
+	return nil
+}
+
+// HealthCheckResponseMultiError is an error wrapping multiple validation
+// errors returned by HealthCheckResponse.ValidateAll() if the designated
+// constraints aren't met.
+type HealthCheckResponseMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m HealthCheckResponseMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m HealthCheckResponseMultiError) AllErrors() []error { return m }
+
+// HealthCheckResponseValidationError is the validation error returned by
+// HealthCheckResponse.Validate if the designated constraints aren't met.
+type HealthCheckResponseValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e HealthCheckResponseValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e HealthCheckResponseValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e HealthCheckResponseValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e HealthCheckResponseValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e HealthCheckResponseValidationError) ErrorName() string {
+	return "HealthCheckResponseValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e HealthCheckResponseValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sHealthCheckResponse.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = HealthCheckResponseValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = HealthCheckResponseValidationError{}
+
+// Validate checks the field values on CreateAPIKeyRequest with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *CreateAPIKeyRequest) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on CreateAPIKeyRequest with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// CreateAPIKeyRequestMultiError, or nil if none found.
+func (m *CreateAPIKeyRequest) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *CreateAPIKeyRequest) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if l := utf8.RuneCountInString(m.GetName()); l < 1 || l > 64 {
+		err := CreateAPIKeyRequestValidationError{
+			field:  "Name",
+			reason: "value length must be between 1 and 64 runes, inclusive",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if len(m.GetScopes()) < 1 {
+		err := CreateAPIKeyRequestValidationError{
+			field:  "Scopes",
+			reason: "value must contain at least 1 item(s)",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	_CreateAPIKeyRequest_Scopes_Unique := make(map[string]struct{}, len(m.GetScopes()))
+
+	for idx, item := range m.GetScopes() {
+		_, _ = idx, item
+
+		if _, exists := _CreateAPIKeyRequest_Scopes_Unique[item]; exists {
+			err := CreateAPIKeyRequestValidationError{
+				field:  fmt.Sprintf("Scopes[%v]", idx),
+				reason: "repeated value must contain unique items",
+			}
+			if !all {
+				return err
+			}
+			errors = append(errors, err)
+		} else {
+			_CreateAPIKeyRequest_Scopes_Unique[item] = struct{}{}
+		}
+
+		if _, ok := _CreateAPIKeyRequest_Scopes_InLookup[item]; !ok {
+			err := CreateAPIKeyRequestValidationError{
+				field:  fmt.Sprintf("Scopes[%v]", idx),
+				reason: "value must be in list [rates:read history:read admin]",
+			}
+			if !all {
+				return err
+			}
+			errors = append(errors, err)
+		}
+
+	}
+
+	if len(errors) > 0 {
+		return CreateAPIKeyRequestMultiError(errors)
+	}
+
+	return nil
+}
+
+// CreateAPIKeyRequestMultiError is an error wrapping multiple validation
+// errors returned by CreateAPIKeyRequest.ValidateAll() if the designated
+// constraints aren't met.
+type CreateAPIKeyRequestMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m CreateAPIKeyRequestMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m CreateAPIKeyRequestMultiError) AllErrors() []error { return m }
+
+// CreateAPIKeyRequestValidationError is the validation error returned by
+// CreateAPIKeyRequest.Validate if the designated constraints aren't met.
+type CreateAPIKeyRequestValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e CreateAPIKeyRequestValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e CreateAPIKeyRequestValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e CreateAPIKeyRequestValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e CreateAPIKeyRequestValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e CreateAPIKeyRequestValidationError) ErrorName() string {
+	return "CreateAPIKeyRequestValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e CreateAPIKeyRequestValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sCreateAPIKeyRequest.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = CreateAPIKeyRequestValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = CreateAPIKeyRequestValidationError{}
+
+var _CreateAPIKeyRequest_Scopes_InLookup = map[string]struct{}{
+	"rates:read":   {},
+	"history:read": {},
+	"admin":        {},
+}
+
+// Validate checks the field values on CreateAPIKeyResponse with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *CreateAPIKeyResponse) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on CreateAPIKeyResponse with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// CreateAPIKeyResponseMultiError, or nil if none found.
+func (m *CreateAPIKeyResponse) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *CreateAPIKeyResponse) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	// no validation rules for Id
+
+	// no validation rules for Key
+
+	if all {
+		switch v := interface{}(m.GetCreatedAt()).(type) {
+		case interface{ ValidateAll() error }:
+			if err := v.ValidateAll(); err != nil {
+				errors = append(errors, CreateAPIKeyResponseValidationError{
+					field:  "CreatedAt",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		case interface{ Validate() error }:
+			if err := v.Validate(); err != nil {
+				errors = append(errors, CreateAPIKeyResponseValidationError{
+					field:  "CreatedAt",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		}
+	} else if v, ok := interface{}(m.GetCreatedAt()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return CreateAPIKeyResponseValidationError{
+				field:  "CreatedAt",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	if len(errors) > 0 {
+		return CreateAPIKeyResponseMultiError(errors)
+	}
+
+	return nil
+}
+
+// CreateAPIKeyResponseMultiError is an error wrapping multiple validation
+// errors returned by CreateAPIKeyResponse.ValidateAll() if the designated
+// constraints aren't met.
+type CreateAPIKeyResponseMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m CreateAPIKeyResponseMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m CreateAPIKeyResponseMultiError) AllErrors() []error { return m }
+
+// CreateAPIKeyResponseValidationError is the validation error returned by
+// CreateAPIKeyResponse.Validate if the designated constraints aren't met.
+type CreateAPIKeyResponseValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e CreateAPIKeyResponseValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e CreateAPIKeyResponseValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e CreateAPIKeyResponseValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e CreateAPIKeyResponseValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e CreateAPIKeyResponseValidationError) ErrorName() string {
+	return "CreateAPIKeyResponseValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e CreateAPIKeyResponseValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sCreateAPIKeyResponse.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = CreateAPIKeyResponseValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = CreateAPIKeyResponseValidationError{}
+
+// Validate checks the field values on RevokeAPIKeyRequest with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *RevokeAPIKeyRequest) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on RevokeAPIKeyRequest with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// RevokeAPIKeyRequestMultiError, or nil if none found.
+func (m *RevokeAPIKeyRequest) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *RevokeAPIKeyRequest) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if !_RevokeAPIKeyRequest_Id_Pattern.MatchString(m.GetId()) {
+		err := RevokeAPIKeyRequestValidationError{
+			field:  "Id",
+			reason: "value does not match regex pattern \"^[0-9a-f]{12}$\"",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if len(errors) > 0 {
+		return RevokeAPIKeyRequestMultiError(errors)
+	}
+
+	return nil
+}
+
+// RevokeAPIKeyRequestMultiError is an error wrapping multiple validation
+// errors returned by RevokeAPIKeyRequest.ValidateAll() if the designated
+// constraints aren't met.
+type RevokeAPIKeyRequestMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m RevokeAPIKeyRequestMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m RevokeAPIKeyRequestMultiError) AllErrors() []error { return m }
+
+// RevokeAPIKeyRequestValidationError is the validation error returned by
+// RevokeAPIKeyRequest.Validate if the designated constraints aren't met.
+type RevokeAPIKeyRequestValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e RevokeAPIKeyRequestValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e RevokeAPIKeyRequestValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e RevokeAPIKeyRequestValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e RevokeAPIKeyRequestValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e RevokeAPIKeyRequestValidationError) ErrorName() string {
+	return "RevokeAPIKeyRequestValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e RevokeAPIKeyRequestValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sRevokeAPIKeyRequest.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = RevokeAPIKeyRequestValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = RevokeAPIKeyRequestValidationError{}
+
+var _RevokeAPIKeyRequest_Id_Pattern = regexp.MustCompile("^[0-9a-f]{12}$")
+
+// Validate checks the field values on RevokeAPIKeyResponse with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *RevokeAPIKeyResponse) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on RevokeAPIKeyResponse with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// RevokeAPIKeyResponseMultiError, or nil if none found.
+func (m *RevokeAPIKeyResponse) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *RevokeAPIKeyResponse) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if all {
+		switch v := interface{}(m.GetRevokedAt()).(type) {
+		case interface{ ValidateAll() error }:
+			if err := v.ValidateAll(); err != nil {
+				errors = append(errors, RevokeAPIKeyResponseValidationError{
+					field:  "RevokedAt",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		case interface{ Validate() error }:
+			if err := v.Validate(); err != nil {
+				errors = append(errors, RevokeAPIKeyResponseValidationError{
+					field:  "RevokedAt",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		}
+	} else if v, ok := interface{}(m.GetRevokedAt()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return RevokeAPIKeyResponseValidationError{
+				field:  "RevokedAt",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	if len(errors) > 0 {
+		return RevokeAPIKeyResponseMultiError(errors)
+	}
+
+	return nil
+}
+
+// RevokeAPIKeyResponseMultiError is an error wrapping multiple validation
+// errors returned by RevokeAPIKeyResponse.ValidateAll() if the designated
+// constraints aren't met.
+type RevokeAPIKeyResponseMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m RevokeAPIKeyResponseMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m RevokeAPIKeyResponseMultiError) AllErrors() []error { return m }
+
+// RevokeAPIKeyResponseValidationError is the validation error returned by
+// RevokeAPIKeyResponse.Validate if the designated constraints aren't met.
+type RevokeAPIKeyResponseValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e RevokeAPIKeyResponseValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e RevokeAPIKeyResponseValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e RevokeAPIKeyResponseValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e RevokeAPIKeyResponseValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e RevokeAPIKeyResponseValidationError) ErrorName() string {
+	return "RevokeAPIKeyResponseValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e RevokeAPIKeyResponseValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sRevokeAPIKeyResponse.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = RevokeAPIKeyResponseValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = RevokeAPIKeyResponseValidationError{}
+
+// Validate checks the field values on GetOrderBookRequest with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *GetOrderBookRequest) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on GetOrderBookRequest with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// GetOrderBookRequestMultiError, or nil if none found.
+func (m *GetOrderBookRequest) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *GetOrderBookRequest) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if utf8.RuneCountInString(m.GetMarket()) > 20 {
+		err := GetOrderBookRequestValidationError{
+			field:  "Market",
+			reason: "value length must be at most 20 runes",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if !_GetOrderBookRequest_Market_Pattern.MatchString(m.GetMarket()) {
+		err := GetOrderBookRequestValidationError{
+			field:  "Market",
+			reason: "value does not match regex pattern \"^[a-z0-9]*$\"",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if all {
+		switch v := interface{}(m.GetAsOf()).(type) {
+		case interface{ ValidateAll() error }:
+			if err := v.ValidateAll(); err != nil {
+				errors = append(errors, GetOrderBookRequestValidationError{
+					field:  "AsOf",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		case interface{ Validate() error }:
+			if err := v.Validate(); err != nil {
+				errors = append(errors, GetOrderBookRequestValidationError{
+					field:  "AsOf",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		}
+	} else if v, ok := interface{}(m.GetAsOf()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return GetOrderBookRequestValidationError{
+				field:  "AsOf",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	if len(errors) > 0 {
+		return GetOrderBookRequestMultiError(errors)
+	}
+
+	return nil
+}
+
+// GetOrderBookRequestMultiError is an error wrapping multiple validation
+// errors returned by GetOrderBookRequest.ValidateAll() if the designated
+// constraints aren't met.
+type GetOrderBookRequestMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m GetOrderBookRequestMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m GetOrderBookRequestMultiError) AllErrors() []error { return m }
+
+// GetOrderBookRequestValidationError is the validation error returned by
+// GetOrderBookRequest.Validate if the designated constraints aren't met.
+type GetOrderBookRequestValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e GetOrderBookRequestValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e GetOrderBookRequestValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e GetOrderBookRequestValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e GetOrderBookRequestValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e GetOrderBookRequestValidationError) ErrorName() string {
+	return "GetOrderBookRequestValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e GetOrderBookRequestValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sGetOrderBookRequest.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = GetOrderBookRequestValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = GetOrderBookRequestValidationError{}
+
+var _GetOrderBookRequest_Market_Pattern = regexp.MustCompile("^[a-z0-9]*$")
+
+// Validate checks the field values on OrderBookLevel with the rules defined in
+// the proto definition for this message. If any rules are violated, the first
+// error encountered is returned, or nil if there are no violations.
+func (m *OrderBookLevel) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on OrderBookLevel with the rules defined
+// in the proto definition for this message. If any rules are violated, the
+// result is a list of violation errors wrapped in OrderBookLevelMultiError,
+// or nil if none found.
+func (m *OrderBookLevel) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *OrderBookLevel) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if all {
+		switch v := interface{}(m.GetPrice()).(type) {
+		case interface{ ValidateAll() error }:
+			if err := v.ValidateAll(); err != nil {
+				errors = append(errors, OrderBookLevelValidationError{
+					field:  "Price",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		case interface{ Validate() error }:
+			if err := v.Validate(); err != nil {
+				errors = append(errors, OrderBookLevelValidationError{
+					field:  "Price",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		}
+	} else if v, ok := interface{}(m.GetPrice()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return OrderBookLevelValidationError{
+				field:  "Price",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	if all {
+		switch v := interface{}(m.GetVolume()).(type) {
+		case interface{ ValidateAll() error }:
+			if err := v.ValidateAll(); err != nil {
+				errors = append(errors, OrderBookLevelValidationError{
+					field:  "Volume",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		case interface{ Validate() error }:
+			if err := v.Validate(); err != nil {
+				errors = append(errors, OrderBookLevelValidationError{
+					field:  "Volume",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		}
+	} else if v, ok := interface{}(m.GetVolume()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return OrderBookLevelValidationError{
+				field:  "Volume",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	if len(errors) > 0 {
+		return OrderBookLevelMultiError(errors)
+	}
+
+	return nil
+}
+
+// OrderBookLevelMultiError is an error wrapping multiple validation errors
+// returned by OrderBookLevel.ValidateAll() if the designated constraints
+// aren't met.
+type OrderBookLevelMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m OrderBookLevelMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m OrderBookLevelMultiError) AllErrors() []error { return m }
+
+// OrderBookLevelValidationError is the validation error returned by
+// OrderBookLevel.Validate if the designated constraints aren't met.
+type OrderBookLevelValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e OrderBookLevelValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e OrderBookLevelValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e OrderBookLevelValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e OrderBookLevelValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e OrderBookLevelValidationError) ErrorName() string { return "OrderBookLevelValidationError" }
+
+// Error satisfies the builtin error interface
+func (e OrderBookLevelValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sOrderBookLevel.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = OrderBookLevelValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = OrderBookLevelValidationError{}
+
+// Validate checks the field values on GetOrderBookResponse with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *GetOrderBookResponse) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on GetOrderBookResponse with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// GetOrderBookResponseMultiError, or nil if none found.
+func (m *GetOrderBookResponse) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *GetOrderBookResponse) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	// no validation rules for Market
+
+	if all {
+		switch v := interface{}(m.GetTimestamp()).(type) {
+		case interface{ ValidateAll() error }:
+			if err := v.ValidateAll(); err != nil {
+				errors = append(errors, GetOrderBookResponseValidationError{
+					field:  "Timestamp",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		case interface{ Validate() error }:
+			if err := v.Validate(); err != nil {
+				errors = append(errors, GetOrderBookResponseValidationError{
+					field:  "Timestamp",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		}
+	} else if v, ok := interface{}(m.GetTimestamp()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return GetOrderBookResponseValidationError{
+				field:  "Timestamp",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	for idx, item := range m.GetAsks() {
+		_, _ = idx, item
+
+		if all {
+			switch v := interface{}(item).(type) {
+			case interface{ ValidateAll() error }:
+				if err := v.ValidateAll(); err != nil {
+					errors = append(errors, GetOrderBookResponseValidationError{
+						field:  fmt.Sprintf("Asks[%v]", idx),
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			case interface{ Validate() error }:
+				if err := v.Validate(); err != nil {
+					errors = append(errors, GetOrderBookResponseValidationError{
+						field:  fmt.Sprintf("Asks[%v]", idx),
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			}
+		} else if v, ok := interface{}(item).(interface{ Validate() error }); ok {
+			if err := v.Validate(); err != nil {
+				return GetOrderBookResponseValidationError{
+					field:  fmt.Sprintf("Asks[%v]", idx),
+					reason: "embedded message failed validation",
+					cause:  err,
+				}
+			}
+		}
+
+	}
+
+	for idx, item := range m.GetBids() {
+		_, _ = idx, item
+
+		if all {
+			switch v := interface{}(item).(type) {
+			case interface{ ValidateAll() error }:
+				if err := v.ValidateAll(); err != nil {
+					errors = append(errors, GetOrderBookResponseValidationError{
+						field:  fmt.Sprintf("Bids[%v]", idx),
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			case interface{ Validate() error }:
+				if err := v.Validate(); err != nil {
+					errors = append(errors, GetOrderBookResponseValidationError{
+						field:  fmt.Sprintf("Bids[%v]", idx),
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			}
+		} else if v, ok := interface{}(item).(interface{ Validate() error }); ok {
+			if err := v.Validate(); err != nil {
+				return GetOrderBookResponseValidationError{
+					field:  fmt.Sprintf("Bids[%v]", idx),
+					reason: "embedded message failed validation",
+					cause:  err,
+				}
+			}
+		}
+
+	}
+
+	// no validation rules for RateId
+
+	if len(errors) > 0 {
+		return GetOrderBookResponseMultiError(errors)
+	}
+
+	return nil
+}
+
+// GetOrderBookResponseMultiError is an error wrapping multiple validation
+// errors returned by GetOrderBookResponse.ValidateAll() if the designated
+// constraints aren't met.
+type GetOrderBookResponseMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m GetOrderBookResponseMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m GetOrderBookResponseMultiError) AllErrors() []error { return m }
+
+// GetOrderBookResponseValidationError is the validation error returned by
+// GetOrderBookResponse.Validate if the designated constraints aren't met.
+type GetOrderBookResponseValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e GetOrderBookResponseValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e GetOrderBookResponseValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e GetOrderBookResponseValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e GetOrderBookResponseValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e GetOrderBookResponseValidationError) ErrorName() string {
+	return "GetOrderBookResponseValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e GetOrderBookResponseValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sGetOrderBookResponse.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = GetOrderBookResponseValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = GetOrderBookResponseValidationError{}
+
+// Validate checks the field values on GetRateAtRequest with the rules defined
+// in the proto definition for this message. If any rules are violated, the
+// first error encountered is returned, or nil if there are no violations.
+func (m *GetRateAtRequest) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on GetRateAtRequest with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// GetRateAtRequestMultiError, or nil if none found.
+func (m *GetRateAtRequest) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *GetRateAtRequest) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if utf8.RuneCountInString(m.GetMarket()) > 20 {
+		err := GetRateAtRequestValidationError{
+			field:  "Market",
+			reason: "value length must be at most 20 runes",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if !_GetRateAtRequest_Market_Pattern.MatchString(m.GetMarket()) {
+		err := GetRateAtRequestValidationError{
+			field:  "Market",
+			reason: "value does not match regex pattern \"^[a-z0-9]*$\"",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if m.GetTimestamp() == nil {
+		err := GetRateAtRequestValidationError{
+			field:  "Timestamp",
+			reason: "value is required",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if _, ok := LookupMode_name[int32(m.GetMode())]; !ok {
+		err := GetRateAtRequestValidationError{
+			field:  "Mode",
+			reason: "value must be one of the defined enum values",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if len(errors) > 0 {
+		return GetRateAtRequestMultiError(errors)
+	}
+
+	return nil
+}
+
+// GetRateAtRequestMultiError is an error wrapping multiple validation errors
+// returned by GetRateAtRequest.ValidateAll() if the designated constraints
+// aren't met.
+type GetRateAtRequestMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m GetRateAtRequestMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m GetRateAtRequestMultiError) AllErrors() []error { return m }
+
+// GetRateAtRequestValidationError is the validation error returned by
+// GetRateAtRequest.Validate if the designated constraints aren't met.
+type GetRateAtRequestValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e GetRateAtRequestValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e GetRateAtRequestValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e GetRateAtRequestValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e GetRateAtRequestValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e GetRateAtRequestValidationError) ErrorName() string { return "GetRateAtRequestValidationError" }
+
+// Error satisfies the builtin error interface
+func (e GetRateAtRequestValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sGetRateAtRequest.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = GetRateAtRequestValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = GetRateAtRequestValidationError{}
+
+var _GetRateAtRequest_Market_Pattern = regexp.MustCompile("^[a-z0-9]*$")
+
+// Validate checks the field values on RateSample with the rules defined in the
+// proto definition for this message. If any rules are violated, the first
+// error encountered is returned, or nil if there are no violations.
+func (m *RateSample) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on RateSample with the rules defined in
+// the proto definition for this message. If any rules are violated, the
+// result is a list of violation errors wrapped in RateSampleMultiError, or
+// nil if none found.
+func (m *RateSample) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *RateSample) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	// no validation rules for Id
+
+	if all {
+		switch v := interface{}(m.GetAskPrice()).(type) {
+		case interface{ ValidateAll() error }:
+			if err := v.ValidateAll(); err != nil {
+				errors = append(errors, RateSampleValidationError{
+					field:  "AskPrice",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		case interface{ Validate() error }:
+			if err := v.Validate(); err != nil {
+				errors = append(errors, RateSampleValidationError{
+					field:  "AskPrice",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		}
+	} else if v, ok := interface{}(m.GetAskPrice()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return RateSampleValidationError{
+				field:  "AskPrice",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	if all {
+		switch v := interface{}(m.GetBidPrice()).(type) {
+		case interface{ ValidateAll() error }:
+			if err := v.ValidateAll(); err != nil {
+				errors = append(errors, RateSampleValidationError{
+					field:  "BidPrice",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		case interface{ Validate() error }:
+			if err := v.Validate(); err != nil {
+				errors = append(errors, RateSampleValidationError{
+					field:  "BidPrice",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		}
+	} else if v, ok := interface{}(m.GetBidPrice()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return RateSampleValidationError{
+				field:  "BidPrice",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	if all {
+		switch v := interface{}(m.GetTimestamp()).(type) {
+		case interface{ ValidateAll() error }:
+			if err := v.ValidateAll(); err != nil {
+				errors = append(errors, RateSampleValidationError{
+					field:  "Timestamp",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		case interface{ Validate() error }:
+			if err := v.Validate(); err != nil {
+				errors = append(errors, RateSampleValidationError{
+					field:  "Timestamp",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		}
+	} else if v, ok := interface{}(m.GetTimestamp()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return RateSampleValidationError{
+				field:  "Timestamp",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	if len(errors) > 0 {
+		return RateSampleMultiError(errors)
+	}
+
+	return nil
+}
+
+// RateSampleMultiError is an error wrapping multiple validation errors
+// returned by RateSample.ValidateAll() if the designated constraints aren't met.
+type RateSampleMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m RateSampleMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m RateSampleMultiError) AllErrors() []error { return m }
+
+// RateSampleValidationError is the validation error returned by
+// RateSample.Validate if the designated constraints aren't met.
+type RateSampleValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e RateSampleValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e RateSampleValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e RateSampleValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e RateSampleValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e RateSampleValidationError) ErrorName() string { return "RateSampleValidationError" }
+
+// Error satisfies the builtin error interface
+func (e RateSampleValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sRateSample.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = RateSampleValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = RateSampleValidationError{}
+
+// Validate checks the field values on GetRateAtResponse with the rules defined
+// in the proto definition for this message. If any rules are violated, the
+// first error encountered is returned, or nil if there are no violations.
+func (m *GetRateAtResponse) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on GetRateAtResponse with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// GetRateAtResponseMultiError, or nil if none found.
+func (m *GetRateAtResponse) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *GetRateAtResponse) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	// no validation rules for Market
+
+	if all {
+		switch v := interface{}(m.GetTimestamp()).(type) {
+		case interface{ ValidateAll() error }:
+			if err := v.ValidateAll(); err != nil {
+				errors = append(errors, GetRateAtResponseValidationError{
+					field:  "Timestamp",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		case interface{ Validate() error }:
+			if err := v.Validate(); err != nil {
+				errors = append(errors, GetRateAtResponseValidationError{
+					field:  "Timestamp",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		}
+	} else if v, ok := interface{}(m.GetTimestamp()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return GetRateAtResponseValidationError{
+				field:  "Timestamp",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	if all {
+		switch v := interface{}(m.GetAskPrice()).(type) {
+		case interface{ ValidateAll() error }:
+			if err := v.ValidateAll(); err != nil {
+				errors = append(errors, GetRateAtResponseValidationError{
+					field:  "AskPrice",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		case interface{ Validate() error }:
+			if err := v.Validate(); err != nil {
+				errors = append(errors, GetRateAtResponseValidationError{
+					field:  "AskPrice",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		}
+	} else if v, ok := interface{}(m.GetAskPrice()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return GetRateAtResponseValidationError{
+				field:  "AskPrice",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	if all {
+		switch v := interface{}(m.GetBidPrice()).(type) {
+		case interface{ ValidateAll() error }:
+			if err := v.ValidateAll(); err != nil {
+				errors = append(errors, GetRateAtResponseValidationError{
+					field:  "BidPrice",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		case interface{ Validate() error }:
+			if err := v.Validate(); err != nil {
+				errors = append(errors, GetRateAtResponseValidationError{
+					field:  "BidPrice",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		}
+	} else if v, ok := interface{}(m.GetBidPrice()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return GetRateAtResponseValidationError{
+				field:  "BidPrice",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	for idx, item := range m.GetSamples() {
+		_, _ = idx, item
+
+		if all {
+			switch v := interface{}(item).(type) {
+			case interface{ ValidateAll() error }:
+				if err := v.ValidateAll(); err != nil {
+					errors = append(errors, GetRateAtResponseValidationError{
+						field:  fmt.Sprintf("Samples[%v]", idx),
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			case interface{ Validate() error }:
+				if err := v.Validate(); err != nil {
+					errors = append(errors, GetRateAtResponseValidationError{
+						field:  fmt.Sprintf("Samples[%v]", idx),
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			}
+		} else if v, ok := interface{}(item).(interface{ Validate() error }); ok {
+			if err := v.Validate(); err != nil {
+				return GetRateAtResponseValidationError{
+					field:  fmt.Sprintf("Samples[%v]", idx),
+					reason: "embedded message failed validation",
+					cause:  err,
+				}
+			}
+		}
+
+	}
+
+	if all {
+		switch v := interface{}(m.GetDistance()).(type) {
+		case interface{ ValidateAll() error }:
+			if err := v.ValidateAll(); err != nil {
+				errors = append(errors, GetRateAtResponseValidationError{
+					field:  "Distance",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		case interface{ Validate() error }:
+			if err := v.Validate(); err != nil {
+				errors = append(errors, GetRateAtResponseValidationError{
+					field:  "Distance",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		}
+	} else if v, ok := interface{}(m.GetDistance()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return GetRateAtResponseValidationError{
+				field:  "Distance",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	// no validation rules for Mode
+
+	if len(errors) > 0 {
+		return GetRateAtResponseMultiError(errors)
+	}
+
+	return nil
+}
+
+// GetRateAtResponseMultiError is an error wrapping multiple validation errors
+// returned by GetRateAtResponse.ValidateAll() if the designated constraints
+// aren't met.
+type GetRateAtResponseMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m GetRateAtResponseMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m GetRateAtResponseMultiError) AllErrors() []error { return m }
+
+// GetRateAtResponseValidationError is the validation error returned by
+// GetRateAtResponse.Validate if the designated constraints aren't met.
+type GetRateAtResponseValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e GetRateAtResponseValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e GetRateAtResponseValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e GetRateAtResponseValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e GetRateAtResponseValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e GetRateAtResponseValidationError) ErrorName() string {
+	return "GetRateAtResponseValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e GetRateAtResponseValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sGetRateAtResponse.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = GetRateAtResponseValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = GetRateAtResponseValidationError{}
+
+// Validate checks the field values on Fixing with the rules defined in the
+// proto definition for this message. If any rules are violated, the first
+// error encountered is returned, or nil if there are no violations.
+func (m *Fixing) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on Fixing with the rules defined in the
+// proto definition for this message. If any rules are violated, the result is
+// a list of violation errors wrapped in FixingMultiError, or nil if none found.
+func (m *Fixing) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *Fixing) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	// no validation rules for Name
+
+	// no validation rules for Market
+
+	// no validation rules for Date
+
+	// no validation rules for Method
+
+	// no validation rules for Methodology
+
+	if all {
+		switch v := interface{}(m.GetWindowStart()).(type) {
+		case interface{ ValidateAll() error }:
+			if err := v.ValidateAll(); err != nil {
+				errors = append(errors, FixingValidationError{
+					field:  "WindowStart",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		case interface{ Validate() error }:
+			if err := v.Validate(); err != nil {
+				errors = append(errors, FixingValidationError{
+					field:  "WindowStart",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		}
+	} else if v, ok := interface{}(m.GetWindowStart()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return FixingValidationError{
+				field:  "WindowStart",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	if all {
+		switch v := interface{}(m.GetWindowEnd()).(type) {
+		case interface{ ValidateAll() error }:
+			if err := v.ValidateAll(); err != nil {
+				errors = append(errors, FixingValidationError{
+					field:  "WindowEnd",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		case interface{ Validate() error }:
+			if err := v.Validate(); err != nil {
+				errors = append(errors, FixingValidationError{
+					field:  "WindowEnd",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		}
+	} else if v, ok := interface{}(m.GetWindowEnd()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return FixingValidationError{
+				field:  "WindowEnd",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	if all {
+		switch v := interface{}(m.GetAskPrice()).(type) {
+		case interface{ ValidateAll() error }:
+			if err := v.ValidateAll(); err != nil {
+				errors = append(errors, FixingValidationError{
+					field:  "AskPrice",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		case interface{ Validate() error }:
+			if err := v.Validate(); err != nil {
+				errors = append(errors, FixingValidationError{
+					field:  "AskPrice",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		}
+	} else if v, ok := interface{}(m.GetAskPrice()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return FixingValidationError{
+				field:  "AskPrice",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	if all {
+		switch v := interface{}(m.GetBidPrice()).(type) {
+		case interface{ ValidateAll() error }:
+			if err := v.ValidateAll(); err != nil {
+				errors = append(errors, FixingValidationError{
+					field:  "BidPrice",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		case interface{ Validate() error }:
+			if err := v.Validate(); err != nil {
+				errors = append(errors, FixingValidationError{
+					field:  "BidPrice",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		}
+	} else if v, ok := interface{}(m.GetBidPrice()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return FixingValidationError{
+				field:  "BidPrice",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	if all {
+		switch v := interface{}(m.GetMidPrice()).(type) {
+		case interface{ ValidateAll() error }:
+			if err := v.ValidateAll(); err != nil {
+				errors = append(errors, FixingValidationError{
+					field:  "MidPrice",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		case interface{ Validate() error }:
+			if err := v.Validate(); err != nil {
+				errors = append(errors, FixingValidationError{
+					field:  "MidPrice",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		}
+	} else if v, ok := interface{}(m.GetMidPrice()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return FixingValidationError{
+				field:  "MidPrice",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	// no validation rules for SampleCount
+
+	if all {
+		switch v := interface{}(m.GetComputedAt()).(type) {
+		case interface{ ValidateAll() error }:
+			if err := v.ValidateAll(); err != nil {
+				errors = append(errors, FixingValidationError{
+					field:  "ComputedAt",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		case interface{ Validate() error }:
+			if err := v.Validate(); err != nil {
+				errors = append(errors, FixingValidationError{
+					field:  "ComputedAt",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		}
+	} else if v, ok := interface{}(m.GetComputedAt()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return FixingValidationError{
+				field:  "ComputedAt",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	if len(errors) > 0 {
+		return FixingMultiError(errors)
+	}
+
+	return nil
+}
+
+// FixingMultiError is an error wrapping multiple validation errors returned by
+// Fixing.ValidateAll() if the designated constraints aren't met.
+type FixingMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m FixingMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m FixingMultiError) AllErrors() []error { return m }
+
+// FixingValidationError is the validation error returned by Fixing.Validate if
+// the designated constraints aren't met.
+type FixingValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e FixingValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e FixingValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e FixingValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e FixingValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e FixingValidationError) ErrorName() string { return "FixingValidationError" }
+
+// Error satisfies the builtin error interface
+func (e FixingValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sFixing.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = FixingValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = FixingValidationError{}
+
+// Validate checks the field values on GetFixingRequest with the rules defined
+// in the proto definition for this message. If any rules are violated, the
+// first error encountered is returned, or nil if there are no violations.
+func (m *GetFixingRequest) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on GetFixingRequest with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// GetFixingRequestMultiError, or nil if none found.
+func (m *GetFixingRequest) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *GetFixingRequest) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if l := utf8.RuneCountInString(m.GetName()); l < 1 || l > 64 {
+		err := GetFixingRequestValidationError{
+			field:  "Name",
+			reason: "value length must be between 1 and 64 runes, inclusive",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if !_GetFixingRequest_Date_Pattern.MatchString(m.GetDate()) {
+		err := GetFixingRequestValidationError{
+			field:  "Date",
+			reason: "value does not match regex pattern \"^[0-9]{4}-[0-9]{2}-[0-9]{2}$\"",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if len(errors) > 0 {
+		return GetFixingRequestMultiError(errors)
+	}
+
+	return nil
+}
+
+// GetFixingRequestMultiError is an error wrapping multiple validation errors
+// returned by GetFixingRequest.ValidateAll() if the designated constraints
+// aren't met.
+type GetFixingRequestMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m GetFixingRequestMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m GetFixingRequestMultiError) AllErrors() []error { return m }
+
+// GetFixingRequestValidationError is the validation error returned by
+// GetFixingRequest.Validate if the designated constraints aren't met.
+type GetFixingRequestValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e GetFixingRequestValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e GetFixingRequestValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e GetFixingRequestValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e GetFixingRequestValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e GetFixingRequestValidationError) ErrorName() string { return "GetFixingRequestValidationError" }
+
+// Error satisfies the builtin error interface
+func (e GetFixingRequestValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sGetFixingRequest.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = GetFixingRequestValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = GetFixingRequestValidationError{}
+
+var _GetFixingRequest_Date_Pattern = regexp.MustCompile("^[0-9]{4}-[0-9]{2}-[0-9]{2}$")
+
+// Validate checks the field values on ListFixingsRequest with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *ListFixingsRequest) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on ListFixingsRequest with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// ListFixingsRequestMultiError, or nil if none found.
+func (m *ListFixingsRequest) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *ListFixingsRequest) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if utf8.RuneCountInString(m.GetName()) > 64 {
+		err := ListFixingsRequestValidationError{
+			field:  "Name",
+			reason: "value length must be at most 64 runes",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if utf8.RuneCountInString(m.GetMarket()) > 20 {
+		err := ListFixingsRequestValidationError{
+			field:  "Market",
+			reason: "value length must be at most 20 runes",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if !_ListFixingsRequest_Market_Pattern.MatchString(m.GetMarket()) {
+		err := ListFixingsRequestValidationError{
+			field:  "Market",
+			reason: "value does not match regex pattern \"^[a-z0-9]*$\"",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if !_ListFixingsRequest_FromDate_Pattern.MatchString(m.GetFromDate()) {
+		err := ListFixingsRequestValidationError{
+			field:  "FromDate",
+			reason: "value does not match regex pattern \"^([0-9]{4}-[0-9]{2}-[0-9]{2})?$\"",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if !_ListFixingsRequest_ToDate_Pattern.MatchString(m.GetToDate()) {
+		err := ListFixingsRequestValidationError{
+			field:  "ToDate",
+			reason: "value does not match regex pattern \"^([0-9]{4}-[0-9]{2}-[0-9]{2})?$\"",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if m.GetLimit() > 1000 {
+		err := ListFixingsRequestValidationError{
+			field:  "Limit",
+			reason: "value must be less than or equal to 1000",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if len(errors) > 0 {
+		return ListFixingsRequestMultiError(errors)
+	}
+
+	return nil
+}
+
+// ListFixingsRequestMultiError is an error wrapping multiple validation errors
+// returned by ListFixingsRequest.ValidateAll() if the designated constraints
+// aren't met.
+type ListFixingsRequestMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m ListFixingsRequestMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m ListFixingsRequestMultiError) AllErrors() []error { return m }
+
+// ListFixingsRequestValidationError is the validation error returned by
+// ListFixingsRequest.Validate if the designated constraints aren't met.
+type ListFixingsRequestValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e ListFixingsRequestValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e ListFixingsRequestValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e ListFixingsRequestValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e ListFixingsRequestValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e ListFixingsRequestValidationError) ErrorName() string {
+	return "ListFixingsRequestValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e ListFixingsRequestValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sListFixingsRequest.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = ListFixingsRequestValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = ListFixingsRequestValidationError{}
+
+var _ListFixingsRequest_Market_Pattern = regexp.MustCompile("^[a-z0-9]*$")
+
+var _ListFixingsRequest_FromDate_Pattern = regexp.MustCompile("^([0-9]{4}-[0-9]{2}-[0-9]{2})?$")
+
+var _ListFixingsRequest_ToDate_Pattern = regexp.MustCompile("^([0-9]{4}-[0-9]{2}-[0-9]{2})?$")
+
+// Validate checks the field values on ListFixingsResponse with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *ListFixingsResponse) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on ListFixingsResponse with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// ListFixingsResponseMultiError, or nil if none found.
+func (m *ListFixingsResponse) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *ListFixingsResponse) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	for idx, item := range m.GetFixings() {
+		_, _ = idx, item
+
+		if all {
+			switch v := interface{}(item).(type) {
+			case interface{ ValidateAll() error }:
+				if err := v.ValidateAll(); err != nil {
+					errors = append(errors, ListFixingsResponseValidationError{
+						field:  fmt.Sprintf("Fixings[%v]", idx),
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			case interface{ Validate() error }:
+				if err := v.Validate(); err != nil {
+					errors = append(errors, ListFixingsResponseValidationError{
+						field:  fmt.Sprintf("Fixings[%v]", idx),
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			}
+		} else if v, ok := interface{}(item).(interface{ Validate() error }); ok {
+			if err := v.Validate(); err != nil {
+				return ListFixingsResponseValidationError{
+					field:  fmt.Sprintf("Fixings[%v]", idx),
+					reason: "embedded message failed validation",
+					cause:  err,
+				}
+			}
+		}
+
+	}
+
+	if len(errors) > 0 {
+		return ListFixingsResponseMultiError(errors)
+	}
+
+	return nil
+}
+
+// ListFixingsResponseMultiError is an error wrapping multiple validation
+// errors returned by ListFixingsResponse.ValidateAll() if the designated
+// constraints aren't met.
+type ListFixingsResponseMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m ListFixingsResponseMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m ListFixingsResponseMultiError) AllErrors() []error { return m }
+
+// ListFixingsResponseValidationError is the validation error returned by
+// ListFixingsResponse.Validate if the designated constraints aren't met.
+type ListFixingsResponseValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e ListFixingsResponseValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e ListFixingsResponseValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e ListFixingsResponseValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e ListFixingsResponseValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e ListFixingsResponseValidationError) ErrorName() string {
+	return "ListFixingsResponseValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e ListFixingsResponseValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sListFixingsResponse.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = ListFixingsResponseValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = ListFixingsResponseValidationError{}
+
+// Validate checks the field values on GetDataQualityRequest with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *GetDataQualityRequest) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on GetDataQualityRequest with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// GetDataQualityRequestMultiError, or nil if none found.
+func (m *GetDataQualityRequest) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *GetDataQualityRequest) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if utf8.RuneCountInString(m.GetMarket()) > 20 {
+		err := GetDataQualityRequestValidationError{
+			field:  "Market",
+			reason: "value length must be at most 20 runes",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if !_GetDataQualityRequest_Market_Pattern.MatchString(m.GetMarket()) {
+		err := GetDataQualityRequestValidationError{
+			field:  "Market",
+			reason: "value does not match regex pattern \"^[a-z0-9]*$\"",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if m.GetFrom() == nil {
+		err := GetDataQualityRequestValidationError{
+			field:  "From",
+			reason: "value is required",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if m.GetTo() == nil {
+		err := GetDataQualityRequestValidationError{
+			field:  "To",
+			reason: "value is required",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if _, ok := _GetDataQualityRequest_Kind_InLookup[m.GetKind()]; !ok {
+		err := GetDataQualityRequestValidationError{
+			field:  "Kind",
+			reason: "value must be in list [ gap frozen crossed jump]",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if m.GetLimit() > 1000 {
+		err := GetDataQualityRequestValidationError{
+			field:  "Limit",
+			reason: "value must be less than or equal to 1000",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if len(errors) > 0 {
+		return GetDataQualityRequestMultiError(errors)
+	}
+
+	return nil
+}
+
+// GetDataQualityRequestMultiError is an error wrapping multiple validation
+// errors returned by GetDataQualityRequest.ValidateAll() if the designated
+// constraints aren't met.
+type GetDataQualityRequestMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m GetDataQualityRequestMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m GetDataQualityRequestMultiError) AllErrors() []error { return m }
+
+// GetDataQualityRequestValidationError is the validation error returned by
+// GetDataQualityRequest.Validate if the designated constraints aren't met.
+type GetDataQualityRequestValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e GetDataQualityRequestValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e GetDataQualityRequestValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e GetDataQualityRequestValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e GetDataQualityRequestValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e GetDataQualityRequestValidationError) ErrorName() string {
+	return "GetDataQualityRequestValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e GetDataQualityRequestValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sGetDataQualityRequest.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = GetDataQualityRequestValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = GetDataQualityRequestValidationError{}
+
+var _GetDataQualityRequest_Market_Pattern = regexp.MustCompile("^[a-z0-9]*$")
+
+var _GetDataQualityRequest_Kind_InLookup = map[string]struct{}{
+	"":        {},
+	"gap":     {},
+	"frozen":  {},
+	"crossed": {},
+	"jump":    {},
+}
+
+// Validate checks the field values on DataQualityIssue with the rules defined
+// in the proto definition for this message. If any rules are violated, the
+// first error encountered is returned, or nil if there are no violations.
+func (m *DataQualityIssue) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on DataQualityIssue with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// DataQualityIssueMultiError, or nil if none found.
+func (m *DataQualityIssue) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *DataQualityIssue) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	// no validation rules for Market
+
+	// no validation rules for Kind
+
+	if all {
+		switch v := interface{}(m.GetStart()).(type) {
+		case interface{ ValidateAll() error }:
+			if err := v.ValidateAll(); err != nil {
+				errors = append(errors, DataQualityIssueValidationError{
+					field:  "Start",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		case interface{ Validate() error }:
+			if err := v.Validate(); err != nil {
+				errors = append(errors, DataQualityIssueValidationError{
+					field:  "Start",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		}
+	} else if v, ok := interface{}(m.GetStart()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return DataQualityIssueValidationError{
+				field:  "Start",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	if all {
+		switch v := interface{}(m.GetEnd()).(type) {
+		case interface{ ValidateAll() error }:
+			if err := v.ValidateAll(); err != nil {
+				errors = append(errors, DataQualityIssueValidationError{
+					field:  "End",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		case interface{ Validate() error }:
+			if err := v.Validate(); err != nil {
+				errors = append(errors, DataQualityIssueValidationError{
+					field:  "End",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		}
+	} else if v, ok := interface{}(m.GetEnd()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return DataQualityIssueValidationError{
+				field:  "End",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	// no validation rules for Samples
+
+	// no validation rules for Detail
+
+	if all {
+		switch v := interface{}(m.GetDetectedAt()).(type) {
+		case interface{ ValidateAll() error }:
+			if err := v.ValidateAll(); err != nil {
+				errors = append(errors, DataQualityIssueValidationError{
+					field:  "DetectedAt",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		case interface{ Validate() error }:
+			if err := v.Validate(); err != nil {
+				errors = append(errors, DataQualityIssueValidationError{
+					field:  "DetectedAt",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		}
+	} else if v, ok := interface{}(m.GetDetectedAt()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return DataQualityIssueValidationError{
+				field:  "DetectedAt",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	if len(errors) > 0 {
+		return DataQualityIssueMultiError(errors)
+	}
+
+	return nil
+}
+
+// DataQualityIssueMultiError is an error wrapping multiple validation errors
+// returned by DataQualityIssue.ValidateAll() if the designated constraints
+// aren't met.
+type DataQualityIssueMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m DataQualityIssueMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m DataQualityIssueMultiError) AllErrors() []error { return m }
+
+// DataQualityIssueValidationError is the validation error returned by
+// DataQualityIssue.Validate if the designated constraints aren't met.
+type DataQualityIssueValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e DataQualityIssueValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e DataQualityIssueValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e DataQualityIssueValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e DataQualityIssueValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e DataQualityIssueValidationError) ErrorName() string { return "DataQualityIssueValidationError" }
+
+// Error satisfies the builtin error interface
+func (e DataQualityIssueValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sDataQualityIssue.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = DataQualityIssueValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = DataQualityIssueValidationError{}
+
+// Validate checks the field values on GetDataQualityResponse with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *GetDataQualityResponse) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on GetDataQualityResponse with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// GetDataQualityResponseMultiError, or nil if none found.
+func (m *GetDataQualityResponse) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *GetDataQualityResponse) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	// no validation rules for Market
+
+	for idx, item := range m.GetIssues() {
+		_, _ = idx, item
+
+		if all {
+			switch v := interface{}(item).(type) {
+			case interface{ ValidateAll() error }:
+				if err := v.ValidateAll(); err != nil {
+					errors = append(errors, GetDataQualityResponseValidationError{
+						field:  fmt.Sprintf("Issues[%v]", idx),
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			case interface{ Validate() error }:
+				if err := v.Validate(); err != nil {
+					errors = append(errors, GetDataQualityResponseValidationError{
+						field:  fmt.Sprintf("Issues[%v]", idx),
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			}
+		} else if v, ok := interface{}(item).(interface{ Validate() error }); ok {
+			if err := v.Validate(); err != nil {
+				return GetDataQualityResponseValidationError{
+					field:  fmt.Sprintf("Issues[%v]", idx),
+					reason: "embedded message failed validation",
+					cause:  err,
+				}
+			}
+		}
+
+	}
+
+	if all {
+		switch v := interface{}(m.GetMissing()).(type) {
+		case interface{ ValidateAll() error }:
+			if err := v.ValidateAll(); err != nil {
+				errors = append(errors, GetDataQualityResponseValidationError{
+					field:  "Missing",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		case interface{ Validate() error }:
+			if err := v.Validate(); err != nil {
+				errors = append(errors, GetDataQualityResponseValidationError{
+					field:  "Missing",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		}
+	} else if v, ok := interface{}(m.GetMissing()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return GetDataQualityResponseValidationError{
+				field:  "Missing",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	// no validation rules for Completeness
+
+	if len(errors) > 0 {
+		return GetDataQualityResponseMultiError(errors)
+	}
+
+	return nil
+}
+
+// GetDataQualityResponseMultiError is an error wrapping multiple validation
+// errors returned by GetDataQualityResponse.ValidateAll() if the designated
+// constraints aren't met.
+type GetDataQualityResponseMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m GetDataQualityResponseMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m GetDataQualityResponseMultiError) AllErrors() []error { return m }
+
+// GetDataQualityResponseValidationError is the validation error returned by
+// GetDataQualityResponse.Validate if the designated constraints aren't met.
+type GetDataQualityResponseValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e GetDataQualityResponseValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e GetDataQualityResponseValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e GetDataQualityResponseValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e GetDataQualityResponseValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e GetDataQualityResponseValidationError) ErrorName() string {
+	return "GetDataQualityResponseValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e GetDataQualityResponseValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sGetDataQualityResponse.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = GetDataQualityResponseValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = GetDataQualityResponseValidationError{}
+
+// Validate checks the field values on ExportRatesRequest with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *ExportRatesRequest) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on ExportRatesRequest with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// ExportRatesRequestMultiError, or nil if none found.
+func (m *ExportRatesRequest) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *ExportRatesRequest) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if utf8.RuneCountInString(m.GetMarket()) > 20 {
+		err := ExportRatesRequestValidationError{
+			field:  "Market",
+			reason: "value length must be at most 20 runes",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if !_ExportRatesRequest_Market_Pattern.MatchString(m.GetMarket()) {
+		err := ExportRatesRequestValidationError{
+			field:  "Market",
+			reason: "value does not match regex pattern \"^[a-z0-9]*$\"",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if m.GetFrom() == nil {
+		err := ExportRatesRequestValidationError{
+			field:  "From",
+			reason: "value is required",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if m.GetTo() == nil {
+		err := ExportRatesRequestValidationError{
+			field:  "To",
+			reason: "value is required",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if all {
+		switch v := interface{}(m.GetInterval()).(type) {
+		case interface{ ValidateAll() error }:
+			if err := v.ValidateAll(); err != nil {
+				errors = append(errors, ExportRatesRequestValidationError{
+					field:  "Interval",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		case interface{ Validate() error }:
+			if err := v.Validate(); err != nil {
+				errors = append(errors, ExportRatesRequestValidationError{
+					field:  "Interval",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		}
+	} else if v, ok := interface{}(m.GetInterval()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return ExportRatesRequestValidationError{
+				field:  "Interval",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	if _, ok := ExportFormat_name[int32(m.GetFormat())]; !ok {
+		err := ExportRatesRequestValidationError{
+			field:  "Format",
+			reason: "value must be one of the defined enum values",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if len(errors) > 0 {
+		return ExportRatesRequestMultiError(errors)
+	}
+
+	return nil
+}
+
+// ExportRatesRequestMultiError is an error wrapping multiple validation errors
+// returned by ExportRatesRequest.ValidateAll() if the designated constraints
+// aren't met.
+type ExportRatesRequestMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m ExportRatesRequestMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m ExportRatesRequestMultiError) AllErrors() []error { return m }
+
+// ExportRatesRequestValidationError is the validation error returned by
+// ExportRatesRequest.Validate if the designated constraints aren't met.
+type ExportRatesRequestValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e ExportRatesRequestValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e ExportRatesRequestValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e ExportRatesRequestValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e ExportRatesRequestValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e ExportRatesRequestValidationError) ErrorName() string {
+	return "ExportRatesRequestValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e ExportRatesRequestValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sExportRatesRequest.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = ExportRatesRequestValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = ExportRatesRequestValidationError{}
+
+var _ExportRatesRequest_Market_Pattern = regexp.MustCompile("^[a-z0-9]*$")
+
+// Validate checks the field values on ExportChunk with the rules defined in
+// the proto definition for this message. If any rules are violated, the first
+// error encountered is returned, or nil if there are no violations.
+func (m *ExportChunk) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on ExportChunk with the rules defined in
+// the proto definition for this message. If any rules are violated, the
+// result is a list of violation errors wrapped in ExportChunkMultiError, or
+// nil if none found.
+func (m *ExportChunk) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *ExportChunk) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	// no validation rules for Data
+
+	if len(errors) > 0 {
+		return ExportChunkMultiError(errors)
+	}
+
+	return nil
+}
+
+// ExportChunkMultiError is an error wrapping multiple validation errors
+// returned by ExportChunk.ValidateAll() if the designated constraints aren't met.
+type ExportChunkMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m ExportChunkMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m ExportChunkMultiError) AllErrors() []error { return m }
+
+// ExportChunkValidationError is the validation error returned by
+// ExportChunk.Validate if the designated constraints aren't met.
+type ExportChunkValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e ExportChunkValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e ExportChunkValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e ExportChunkValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e ExportChunkValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e ExportChunkValidationError) ErrorName() string { return "ExportChunkValidationError" }
+
+// Error satisfies the builtin error interface
+func (e ExportChunkValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sExportChunk.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = ExportChunkValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = ExportChunkValidationError{}
+
+// Validate checks the field values on StreamRatesRequest with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *StreamRatesRequest) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on StreamRatesRequest with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// StreamRatesRequestMultiError, or nil if none found.
+func (m *StreamRatesRequest) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *StreamRatesRequest) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if utf8.RuneCountInString(m.GetMarket()) > 20 {
+		err := StreamRatesRequestValidationError{
+			field:  "Market",
+			reason: "value length must be at most 20 runes",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if !_StreamRatesRequest_Market_Pattern.MatchString(m.GetMarket()) {
+		err := StreamRatesRequestValidationError{
+			field:  "Market",
+			reason: "value does not match regex pattern \"^[a-z0-9]*$\"",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if len(errors) > 0 {
+		return StreamRatesRequestMultiError(errors)
+	}
+
+	return nil
+}
+
+// StreamRatesRequestMultiError is an error wrapping multiple validation errors
+// returned by StreamRatesRequest.ValidateAll() if the designated constraints
+// aren't met.
+type StreamRatesRequestMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m StreamRatesRequestMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m StreamRatesRequestMultiError) AllErrors() []error { return m }
+
+// StreamRatesRequestValidationError is the validation error returned by
+// StreamRatesRequest.Validate if the designated constraints aren't met.
+type StreamRatesRequestValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e StreamRatesRequestValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e StreamRatesRequestValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e StreamRatesRequestValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e StreamRatesRequestValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e StreamRatesRequestValidationError) ErrorName() string {
+	return "StreamRatesRequestValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e StreamRatesRequestValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sStreamRatesRequest.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = StreamRatesRequestValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = StreamRatesRequestValidationError{}
+
+var _StreamRatesRequest_Market_Pattern = regexp.MustCompile("^[a-z0-9]*$")
+
+// Validate checks the field values on RateUpdate with the rules defined in the
+// proto definition for this message. If any rules are violated, the first
+// error encountered is returned, or nil if there are no violations.
+func (m *RateUpdate) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on RateUpdate with the rules defined in
+// the proto definition for this message. If any rules are violated, the
+// result is a list of violation errors wrapped in RateUpdateMultiError, or
+// nil if none found.
+func (m *RateUpdate) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *RateUpdate) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	// no validation rules for Id
+
+	// no validation rules for Market
+
+	// no validation rules for Source
+
+	if all {
+		switch v := interface{}(m.GetAskPrice()).(type) {
+		case interface{ ValidateAll() error }:
+			if err := v.ValidateAll(); err != nil {
+				errors = append(errors, RateUpdateValidationError{
+					field:  "AskPrice",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		case interface{ Validate() error }:
+			if err := v.Validate(); err != nil {
+				errors = append(errors, RateUpdateValidationError{
+					field:  "AskPrice",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		}
+	} else if v, ok := interface{}(m.GetAskPrice()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return RateUpdateValidationError{
+				field:  "AskPrice",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	if all {
+		switch v := interface{}(m.GetBidPrice()).(type) {
+		case interface{ ValidateAll() error }:
+			if err := v.ValidateAll(); err != nil {
+				errors = append(errors, RateUpdateValidationError{
+					field:  "BidPrice",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		case interface{ Validate() error }:
+			if err := v.Validate(); err != nil {
+				errors = append(errors, RateUpdateValidationError{
+					field:  "BidPrice",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		}
+	} else if v, ok := interface{}(m.GetBidPrice()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return RateUpdateValidationError{
+				field:  "BidPrice",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	if all {
+		switch v := interface{}(m.GetTimestamp()).(type) {
+		case interface{ ValidateAll() error }:
+			if err := v.ValidateAll(); err != nil {
+				errors = append(errors, RateUpdateValidationError{
+					field:  "Timestamp",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		case interface{ Validate() error }:
+			if err := v.Validate(); err != nil {
+				errors = append(errors, RateUpdateValidationError{
+					field:  "Timestamp",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		}
+	} else if v, ok := interface{}(m.GetTimestamp()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return RateUpdateValidationError{
+				field:  "Timestamp",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	if len(errors) > 0 {
+		return RateUpdateMultiError(errors)
+	}
+
+	return nil
+}
+
+// RateUpdateMultiError is an error wrapping multiple validation errors
+// returned by RateUpdate.ValidateAll() if the designated constraints aren't met.
+type RateUpdateMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m RateUpdateMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m RateUpdateMultiError) AllErrors() []error { return m }
+
+// RateUpdateValidationError is the validation error returned by
+// RateUpdate.Validate if the designated constraints aren't met.
+type RateUpdateValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e RateUpdateValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e RateUpdateValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e RateUpdateValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e RateUpdateValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e RateUpdateValidationError) ErrorName() string { return "RateUpdateValidationError" }
+
+// Error satisfies the builtin error interface
+func (e RateUpdateValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sRateUpdate.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = RateUpdateValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = RateUpdateValidationError{}
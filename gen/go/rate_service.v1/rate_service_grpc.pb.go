@@ -20,8 +20,17 @@ import (
 const _ = grpc.SupportPackageIsVersion7
 
 const (
-	RateService_GetRates_FullMethodName    = "/rate_service.v1.RateService/GetRates"
-	RateService_HealthCheck_FullMethodName = "/rate_service.v1.RateService/HealthCheck"
+	RateService_GetRates_FullMethodName       = "/rate_service.v1.RateService/GetRates"
+	RateService_HealthCheck_FullMethodName    = "/rate_service.v1.RateService/HealthCheck"
+	RateService_CreateAPIKey_FullMethodName   = "/rate_service.v1.RateService/CreateAPIKey"
+	RateService_RevokeAPIKey_FullMethodName   = "/rate_service.v1.RateService/RevokeAPIKey"
+	RateService_GetOrderBook_FullMethodName   = "/rate_service.v1.RateService/GetOrderBook"
+	RateService_GetRateAt_FullMethodName      = "/rate_service.v1.RateService/GetRateAt"
+	RateService_GetFixing_FullMethodName      = "/rate_service.v1.RateService/GetFixing"
+	RateService_ListFixings_FullMethodName    = "/rate_service.v1.RateService/ListFixings"
+	RateService_GetDataQuality_FullMethodName = "/rate_service.v1.RateService/GetDataQuality"
+	RateService_ExportRates_FullMethodName    = "/rate_service.v1.RateService/ExportRates"
+	RateService_StreamRates_FullMethodName    = "/rate_service.v1.RateService/StreamRates"
 )
 
 // RateServiceClient is the client API for RateService service.
@@ -34,6 +43,24 @@ type RateServiceClient interface {
 	GetRates(ctx context.Context, in *GetRatesRequest, opts ...grpc.CallOption) (*GetRatesResponse, error)
 	// HealthCheck checks the service health status
 	HealthCheck(ctx context.Context, in *HealthCheckRequest, opts ...grpc.CallOption) (*HealthCheckResponse, error)
+	// CreateAPIKey creates a new API key; requires the admin scope
+	CreateAPIKey(ctx context.Context, in *CreateAPIKeyRequest, opts ...grpc.CallOption) (*CreateAPIKeyResponse, error)
+	// RevokeAPIKey revokes an existing API key; requires the admin scope
+	RevokeAPIKey(ctx context.Context, in *RevokeAPIKeyRequest, opts ...grpc.CallOption) (*RevokeAPIKeyResponse, error)
+	// GetOrderBook returns the latest stored order book snapshot, or the latest one taken at or before as_of
+	GetOrderBook(ctx context.Context, in *GetOrderBookRequest, opts ...grpc.CallOption) (*GetOrderBookResponse, error)
+	// GetRateAt returns the stored rate of a market at an instant
+	GetRateAt(ctx context.Context, in *GetRateAtRequest, opts ...grpc.CallOption) (*GetRateAtResponse, error)
+	// GetFixing returns the reference rate of a fixing window on a date
+	GetFixing(ctx context.Context, in *GetFixingRequest, opts ...grpc.CallOption) (*Fixing, error)
+	// ListFixings lists computed fixings, newest date first
+	ListFixings(ctx context.Context, in *ListFixingsRequest, opts ...grpc.CallOption) (*ListFixingsResponse, error)
+	// GetDataQuality returns the data quality issues of a market over a window and how complete its history is
+	GetDataQuality(ctx context.Context, in *GetDataQualityRequest, opts ...grpc.CallOption) (*GetDataQualityResponse, error)
+	// ExportRates streams the rate history of a market as a CSV, NDJSON or Parquet file, raw or as candles
+	ExportRates(ctx context.Context, in *ExportRatesRequest, opts ...grpc.CallOption) (RateService_ExportRatesClient, error)
+	// StreamRates streams the new rates of a market as they are saved by any instance sharing the database
+	StreamRates(ctx context.Context, in *StreamRatesRequest, opts ...grpc.CallOption) (RateService_StreamRatesClient, error)
 }
 
 type rateServiceClient struct {
@@ -62,6 +89,133 @@ func (c *rateServiceClient) HealthCheck(ctx context.Context, in *HealthCheckRequ
 	return out, nil
 }
 
+func (c *rateServiceClient) CreateAPIKey(ctx context.Context, in *CreateAPIKeyRequest, opts ...grpc.CallOption) (*CreateAPIKeyResponse, error) {
+	out := new(CreateAPIKeyResponse)
+	err := c.cc.Invoke(ctx, RateService_CreateAPIKey_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *rateServiceClient) RevokeAPIKey(ctx context.Context, in *RevokeAPIKeyRequest, opts ...grpc.CallOption) (*RevokeAPIKeyResponse, error) {
+	out := new(RevokeAPIKeyResponse)
+	err := c.cc.Invoke(ctx, RateService_RevokeAPIKey_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *rateServiceClient) GetOrderBook(ctx context.Context, in *GetOrderBookRequest, opts ...grpc.CallOption) (*GetOrderBookResponse, error) {
+	out := new(GetOrderBookResponse)
+	err := c.cc.Invoke(ctx, RateService_GetOrderBook_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *rateServiceClient) GetRateAt(ctx context.Context, in *GetRateAtRequest, opts ...grpc.CallOption) (*GetRateAtResponse, error) {
+	out := new(GetRateAtResponse)
+	err := c.cc.Invoke(ctx, RateService_GetRateAt_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *rateServiceClient) GetFixing(ctx context.Context, in *GetFixingRequest, opts ...grpc.CallOption) (*Fixing, error) {
+	out := new(Fixing)
+	err := c.cc.Invoke(ctx, RateService_GetFixing_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *rateServiceClient) ListFixings(ctx context.Context, in *ListFixingsRequest, opts ...grpc.CallOption) (*ListFixingsResponse, error) {
+	out := new(ListFixingsResponse)
+	err := c.cc.Invoke(ctx, RateService_ListFixings_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *rateServiceClient) GetDataQuality(ctx context.Context, in *GetDataQualityRequest, opts ...grpc.CallOption) (*GetDataQualityResponse, error) {
+	out := new(GetDataQualityResponse)
+	err := c.cc.Invoke(ctx, RateService_GetDataQuality_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *rateServiceClient) ExportRates(ctx context.Context, in *ExportRatesRequest, opts ...grpc.CallOption) (RateService_ExportRatesClient, error) {
+	stream, err := c.cc.NewStream(ctx, &RateService_ServiceDesc.Streams[0], RateService_ExportRates_FullMethodName, opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &rateServiceExportRatesClient{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type RateService_ExportRatesClient interface {
+	Recv() (*ExportChunk, error)
+	grpc.ClientStream
+}
+
+type rateServiceExportRatesClient struct {
+	grpc.ClientStream
+}
+
+func (x *rateServiceExportRatesClient) Recv() (*ExportChunk, error) {
+	m := new(ExportChunk)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *rateServiceClient) StreamRates(ctx context.Context, in *StreamRatesRequest, opts ...grpc.CallOption) (RateService_StreamRatesClient, error) {
+	stream, err := c.cc.NewStream(ctx, &RateService_ServiceDesc.Streams[1], RateService_StreamRates_FullMethodName, opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &rateServiceStreamRatesClient{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type RateService_StreamRatesClient interface {
+	Recv() (*RateUpdate, error)
+	grpc.ClientStream
+}
+
+type rateServiceStreamRatesClient struct {
+	grpc.ClientStream
+}
+
+func (x *rateServiceStreamRatesClient) Recv() (*RateUpdate, error) {
+	m := new(RateUpdate)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
 // RateServiceServer is the server API for RateService service.
 // All implementations must embed UnimplementedRateServiceServer
 // for forward compatibility.
@@ -72,6 +226,24 @@ type RateServiceServer interface {
 	GetRates(context.Context, *GetRatesRequest) (*GetRatesResponse, error)
 	// HealthCheck checks the service health status
 	HealthCheck(context.Context, *HealthCheckRequest) (*HealthCheckResponse, error)
+	// CreateAPIKey creates a new API key; requires the admin scope
+	CreateAPIKey(context.Context, *CreateAPIKeyRequest) (*CreateAPIKeyResponse, error)
+	// RevokeAPIKey revokes an existing API key; requires the admin scope
+	RevokeAPIKey(context.Context, *RevokeAPIKeyRequest) (*RevokeAPIKeyResponse, error)
+	// GetOrderBook returns the latest stored order book snapshot, or the latest one taken at or before as_of
+	GetOrderBook(context.Context, *GetOrderBookRequest) (*GetOrderBookResponse, error)
+	// GetRateAt returns the stored rate of a market at an instant
+	GetRateAt(context.Context, *GetRateAtRequest) (*GetRateAtResponse, error)
+	// GetFixing returns the reference rate of a fixing window on a date
+	GetFixing(context.Context, *GetFixingRequest) (*Fixing, error)
+	// ListFixings lists computed fixings, newest date first
+	ListFixings(context.Context, *ListFixingsRequest) (*ListFixingsResponse, error)
+	// GetDataQuality returns the data quality issues of a market over a window and how complete its history is
+	GetDataQuality(context.Context, *GetDataQualityRequest) (*GetDataQualityResponse, error)
+	// ExportRates streams the rate history of a market as a CSV, NDJSON or Parquet file, raw or as candles
+	ExportRates(*ExportRatesRequest, RateService_ExportRatesServer) error
+	// StreamRates streams the new rates of a market as they are saved by any instance sharing the database
+	StreamRates(*StreamRatesRequest, RateService_StreamRatesServer) error
 	mustEmbedUnimplementedRateServiceServer()
 }
 
@@ -88,6 +260,33 @@ func (UnimplementedRateServiceServer) GetRates(context.Context, *GetRatesRequest
 func (UnimplementedRateServiceServer) HealthCheck(context.Context, *HealthCheckRequest) (*HealthCheckResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method HealthCheck not implemented")
 }
+func (UnimplementedRateServiceServer) CreateAPIKey(context.Context, *CreateAPIKeyRequest) (*CreateAPIKeyResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateAPIKey not implemented")
+}
+func (UnimplementedRateServiceServer) RevokeAPIKey(context.Context, *RevokeAPIKeyRequest) (*RevokeAPIKeyResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RevokeAPIKey not implemented")
+}
+func (UnimplementedRateServiceServer) GetOrderBook(context.Context, *GetOrderBookRequest) (*GetOrderBookResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetOrderBook not implemented")
+}
+func (UnimplementedRateServiceServer) GetRateAt(context.Context, *GetRateAtRequest) (*GetRateAtResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetRateAt not implemented")
+}
+func (UnimplementedRateServiceServer) GetFixing(context.Context, *GetFixingRequest) (*Fixing, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetFixing not implemented")
+}
+func (UnimplementedRateServiceServer) ListFixings(context.Context, *ListFixingsRequest) (*ListFixingsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListFixings not implemented")
+}
+func (UnimplementedRateServiceServer) GetDataQuality(context.Context, *GetDataQualityRequest) (*GetDataQualityResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetDataQuality not implemented")
+}
+func (UnimplementedRateServiceServer) ExportRates(*ExportRatesRequest, RateService_ExportRatesServer) error {
+	return status.Errorf(codes.Unimplemented, "method ExportRates not implemented")
+}
+func (UnimplementedRateServiceServer) StreamRates(*StreamRatesRequest, RateService_StreamRatesServer) error {
+	return status.Errorf(codes.Unimplemented, "method StreamRates not implemented")
+}
 func (UnimplementedRateServiceServer) mustEmbedUnimplementedRateServiceServer() {}
 func (UnimplementedRateServiceServer) testEmbeddedByValue()                     {}
 
@@ -145,6 +344,174 @@ func _RateService_HealthCheck_Handler(srv interface{}, ctx context.Context, dec
 	return interceptor(ctx, in, info, handler)
 }
 
+func _RateService_CreateAPIKey_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateAPIKeyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RateServiceServer).CreateAPIKey(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: RateService_CreateAPIKey_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RateServiceServer).CreateAPIKey(ctx, req.(*CreateAPIKeyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RateService_RevokeAPIKey_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RevokeAPIKeyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RateServiceServer).RevokeAPIKey(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: RateService_RevokeAPIKey_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RateServiceServer).RevokeAPIKey(ctx, req.(*RevokeAPIKeyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RateService_GetOrderBook_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetOrderBookRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RateServiceServer).GetOrderBook(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: RateService_GetOrderBook_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RateServiceServer).GetOrderBook(ctx, req.(*GetOrderBookRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RateService_GetRateAt_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetRateAtRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RateServiceServer).GetRateAt(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: RateService_GetRateAt_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RateServiceServer).GetRateAt(ctx, req.(*GetRateAtRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RateService_GetFixing_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetFixingRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RateServiceServer).GetFixing(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: RateService_GetFixing_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RateServiceServer).GetFixing(ctx, req.(*GetFixingRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RateService_ListFixings_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListFixingsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RateServiceServer).ListFixings(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: RateService_ListFixings_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RateServiceServer).ListFixings(ctx, req.(*ListFixingsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RateService_GetDataQuality_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetDataQualityRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RateServiceServer).GetDataQuality(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: RateService_GetDataQuality_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RateServiceServer).GetDataQuality(ctx, req.(*GetDataQualityRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RateService_ExportRates_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(ExportRatesRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(RateServiceServer).ExportRates(m, &rateServiceExportRatesServer{ServerStream: stream})
+}
+
+type RateService_ExportRatesServer interface {
+	Send(*ExportChunk) error
+	grpc.ServerStream
+}
+
+type rateServiceExportRatesServer struct {
+	grpc.ServerStream
+}
+
+func (x *rateServiceExportRatesServer) Send(m *ExportChunk) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _RateService_StreamRates_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(StreamRatesRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(RateServiceServer).StreamRates(m, &rateServiceStreamRatesServer{ServerStream: stream})
+}
+
+type RateService_StreamRatesServer interface {
+	Send(*RateUpdate) error
+	grpc.ServerStream
+}
+
+type rateServiceStreamRatesServer struct {
+	grpc.ServerStream
+}
+
+func (x *rateServiceStreamRatesServer) Send(m *RateUpdate) error {
+	return x.ServerStream.SendMsg(m)
+}
+
 // RateService_ServiceDesc is the grpc.ServiceDesc for RateService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -160,7 +527,46 @@ var RateService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "HealthCheck",
 			Handler:    _RateService_HealthCheck_Handler,
 		},
+		{
+			MethodName: "CreateAPIKey",
+			Handler:    _RateService_CreateAPIKey_Handler,
+		},
+		{
+			MethodName: "RevokeAPIKey",
+			Handler:    _RateService_RevokeAPIKey_Handler,
+		},
+		{
+			MethodName: "GetOrderBook",
+			Handler:    _RateService_GetOrderBook_Handler,
+		},
+		{
+			MethodName: "GetRateAt",
+			Handler:    _RateService_GetRateAt_Handler,
+		},
+		{
+			MethodName: "GetFixing",
+			Handler:    _RateService_GetFixing_Handler,
+		},
+		{
+			MethodName: "ListFixings",
+			Handler:    _RateService_ListFixings_Handler,
+		},
+		{
+			MethodName: "GetDataQuality",
+			Handler:    _RateService_GetDataQuality_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "ExportRates",
+			Handler:       _RateService_ExportRates_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "StreamRates",
+			Handler:       _RateService_StreamRates_Handler,
+			ServerStreams: true,
+		},
 	},
-	Streams:  []grpc.StreamDesc{},
 	Metadata: "proto/rate_service.v1/rate_service.proto",
 }
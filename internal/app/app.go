@@ -2,31 +2,56 @@ package app
 
 import (
 	"context"
+	"crypto/tls"
 	"fmt"
 	"net/http"
 	"os"
 	"os/signal"
+	"sync"
 	"syscall"
 	"time"
 
 	"github.com/cawa87/garantex-test/internal/config"
 	"github.com/cawa87/garantex-test/internal/lib/logger/sl"
+	"github.com/cawa87/garantex-test/internal/lib/tlsutil"
+	"github.com/cawa87/garantex-test/internal/repository"
+	"github.com/cawa87/garantex-test/internal/repository/memory"
 	"github.com/cawa87/garantex-test/internal/repository/postgres"
+	"github.com/cawa87/garantex-test/internal/repository/sqlite"
+	"github.com/cawa87/garantex-test/internal/service/auth"
 	"github.com/cawa87/garantex-test/internal/service/exchange"
+	"github.com/cawa87/garantex-test/internal/service/feed"
+	"github.com/cawa87/garantex-test/internal/service/fixing"
+	"github.com/cawa87/garantex-test/internal/service/guard"
+	"github.com/cawa87/garantex-test/internal/service/ingest"
+	"github.com/cawa87/garantex-test/internal/service/leader"
+	"github.com/cawa87/garantex-test/internal/service/orderbook"
+	"github.com/cawa87/garantex-test/internal/service/quality"
+	"github.com/cawa87/garantex-test/internal/service/retention"
 	"github.com/cawa87/garantex-test/internal/transport/grpc"
 	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"github.com/shopspring/decimal"
 	"go.opentelemetry.io/otel"
 	"go.opentelemetry.io/otel/exporters/prometheus"
 	"go.opentelemetry.io/otel/sdk/metric"
 )
 
+// backgroundJob is a periodic task that runs until its context is cancelled
+type backgroundJob interface {
+	Run(ctx context.Context)
+}
+
 // App represents the main application with all components
 type App struct {
 	config  *config.Config
 	logger  *sl.Logger
-	repo    *postgres.Repository
+	repo    repository.Store
 	server  *grpc.Server
 	metrics *http.Server
+	certs   *tlsutil.Reloader
+	jobs    []backgroundJob
+	jobsWG  sync.WaitGroup
+	cancel  context.CancelFunc
 }
 
 // New creates a new application instance with all dependencies
@@ -36,13 +61,143 @@ func New(cfg *config.Config) (*App, error) {
 		return nil, fmt.Errorf("failed to create logger: %w", err)
 	}
 
-	repo, err := postgres.NewRepository(cfg.Database.GetDSN(), logger)
+	repo, pg, err := newStore(cfg.Database, logger)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create repository: %w", err)
 	}
 
-	exchangeClient := exchange.NewClient(cfg.Exchange.BaseURL, cfg.Exchange.Timeout, logger)
-	server := grpc.NewServer(repo, exchangeClient, logger)
+	exchangeClient := NewExchangeClient(cfg.Exchange, logger)
+
+	serverOpts := []grpc.Option{
+		grpc.WithRateLimits(rateLimitPolicy(cfg.Server.RateLimit)),
+		grpc.WithLookupTolerance(cfg.History.LookupTolerance),
+	}
+
+	// singletons run on one replica only; see cfg.Leader
+	var jobs, singletons []backgroundJob
+	if pg != nil && cfg.Database.Listen {
+		broadcaster := feed.NewBroadcaster()
+		serverOpts = append(serverOpts, grpc.WithRateFeed(broadcaster))
+		jobs = append(jobs, postgres.NewListener(pg, broadcaster.Publish, logger))
+	}
+
+	if pg != nil && cfg.Database.Partitions.Enabled {
+		singletons = append(singletons, postgres.NewPartitionMaintainer(pg, cfg.Database.Partitions.Premake,
+			cfg.Database.Partitions.DetachAfter, cfg.Database.Partitions.Interval, logger))
+	}
+
+	if cfg.Ingest.Enabled {
+		buffer := ingest.NewBuffer(repo, cfg.Ingest.BatchSize, cfg.Ingest.FlushInterval, logger)
+		serverOpts = append(serverOpts, grpc.WithIngestBuffer(buffer))
+		jobs = append(jobs, buffer)
+	}
+
+	if cfg.Guard.Enabled {
+		if pg == nil {
+			return nil, fmt.Errorf("price guard requires the %s driver", config.DriverPostgres)
+		}
+		priceGuard, err := guard.New(pg, guard.Limits{
+			Window:       cfg.Guard.MedianWindow,
+			MaxDeviation: decimal.NewFromFloat(cfg.Guard.MaxDeviation),
+			MinVolume:    decimal.NewFromFloat(cfg.Guard.MinVolume),
+			MaxSpread:    decimal.NewFromFloat(cfg.Guard.MaxSpread),
+		}, guard.Alert{
+			Threshold: cfg.Guard.AlertThreshold,
+			Window:    cfg.Guard.AlertWindow,
+		}, logger)
+		if err != nil {
+			return nil, fmt.Errorf("failed to configure price guard: %w", err)
+		}
+		serverOpts = append(serverOpts, grpc.WithPriceGuard(priceGuard))
+	}
+
+	if cfg.OrderBook.Enabled {
+		serverOpts = append(serverOpts, grpc.WithOrderBooks(cfg.OrderBook.Depth))
+		singletons = append(singletons, orderbook.NewPruner(repo, cfg.OrderBook.Retention, cfg.OrderBook.PruneInterval, logger))
+	}
+
+	if cfg.Retention.Enabled {
+		if pg == nil {
+			return nil, fmt.Errorf("retention requires the %s driver", config.DriverPostgres)
+		}
+		job, err := retention.NewJob(pg, retention.Policy{
+			Raw:    cfg.Retention.Raw,
+			Minute: cfg.Retention.Minute,
+			Hour:   cfg.Retention.Hour,
+		}, cfg.Retention.Interval, cfg.Retention.BatchSize, cfg.Retention.DryRun, logger)
+		if err != nil {
+			return nil, fmt.Errorf("failed to configure retention: %w", err)
+		}
+		singletons = append(singletons, job)
+	}
+
+	if cfg.Fixings.Enabled {
+		windows, err := fixingWindows(cfg.Fixings, cfg.OrderBook.Enabled)
+		if err != nil {
+			return nil, fmt.Errorf("failed to configure fixings: %w", err)
+		}
+		// Fixings are stored once per window, so they are computed from the primary rather than a lagging replica
+		var store fixing.Store = repo
+		if pg != nil {
+			store = pg.Primary()
+		}
+		singletons = append(singletons, fixing.NewService(store, windows, logger,
+			fixing.WithSettleDelay(fixingSettleDelay(cfg))))
+	}
+
+	if cfg.Quality.Enabled {
+		if pg == nil {
+			return nil, fmt.Errorf("data quality checks require the %s driver", config.DriverPostgres)
+		}
+		checker, err := quality.NewChecker(pg.Primary(), cfg.Quality.Markets, quality.Thresholds{
+			ExpectedInterval: cfg.Quality.ExpectedInterval,
+			MaxGap:           cfg.Quality.MaxGap,
+			FrozenSamples:    cfg.Quality.FrozenSamples,
+			MaxJump:          decimal.NewFromFloat(cfg.Quality.MaxJump),
+		}, cfg.Quality.Interval, cfg.Quality.Lookback, logger)
+		if err != nil {
+			return nil, fmt.Errorf("failed to configure data quality checks: %w", err)
+		}
+		serverOpts = append(serverOpts, grpc.WithDataQuality(checker))
+		singletons = append(singletons, checker)
+	}
+
+	if pg != nil && cfg.Leader.Enabled {
+		identity, err := leaderIdentity(cfg.Leader)
+		if err != nil {
+			return nil, fmt.Errorf("failed to configure leader election: %w", err)
+		}
+		elector := leader.NewElector(pg.LeaderLock(identity), identity, cfg.Leader.Interval, logger)
+		for _, job := range singletons {
+			elector.Add(job)
+		}
+		serverOpts = append(serverOpts, grpc.WithLeader(elector))
+		jobs = append(jobs, elector)
+	} else {
+		jobs = append(jobs, singletons...)
+	}
+
+	var (
+		certs     *tlsutil.Reloader
+		tlsConfig *tls.Config
+	)
+	if cfg.Server.TLS.Enabled {
+		certs, tlsConfig, err = newTLS(cfg.Server.TLS, logger)
+		if err != nil {
+			return nil, fmt.Errorf("failed to configure TLS: %w", err)
+		}
+		serverOpts = append(serverOpts, grpc.WithTLS(tlsConfig))
+	}
+
+	if cfg.Auth.Enabled {
+		authenticator, policy, err := newAuth(cfg.Auth, repo)
+		if err != nil {
+			return nil, fmt.Errorf("failed to configure authentication: %w", err)
+		}
+		serverOpts = append(serverOpts, grpc.WithAuth(authenticator, policy))
+	}
+
+	server := grpc.NewServer(repo, exchangeClient, logger, serverOpts...)
 
 	exporter, err := prometheus.New()
 	if err != nil {
@@ -55,8 +210,9 @@ func New(cfg *config.Config) (*App, error) {
 	metricsMux := http.NewServeMux()
 	metricsMux.Handle("/metrics", promhttp.Handler())
 	metricsServer := &http.Server{
-		Addr:    fmt.Sprintf(":%d", cfg.Server.MetricsPort),
-		Handler: metricsMux,
+		Addr:      fmt.Sprintf(":%d", cfg.Server.MetricsPort),
+		Handler:   metricsMux,
+		TLSConfig: tlsConfig,
 	}
 
 	return &App{
@@ -65,6 +221,8 @@ func New(cfg *config.Config) (*App, error) {
 		repo:    repo,
 		server:  server,
 		metrics: metricsServer,
+		certs:   certs,
+		jobs:    jobs,
 	}, nil
 }
 
@@ -72,7 +230,13 @@ func New(cfg *config.Config) (*App, error) {
 func (a *App) Run() error {
 	go func() {
 		a.logger.Info("Starting metrics server", "port", a.config.Server.MetricsPort)
-		if err := a.metrics.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+		var err error
+		if a.metrics.TLSConfig != nil {
+			err = a.metrics.ListenAndServeTLS("", "")
+		} else {
+			err = a.metrics.ListenAndServe()
+		}
+		if err != nil && err != http.ErrServerClosed {
 			a.logger.Error("Metrics server failed", "error", err)
 		}
 	}()
@@ -84,6 +248,26 @@ func (a *App) Run() error {
 		}
 	}()
 
+	ctx, cancel := context.WithCancel(context.Background())
+	a.cancel = cancel
+
+	for _, job := range a.jobs {
+		a.jobsWG.Add(1)
+		go func(job backgroundJob) {
+			defer a.jobsWG.Done()
+			job.Run(ctx)
+		}(job)
+	}
+
+	config.Watch(func(cfg *config.Config, err error) {
+		if err != nil {
+			a.logger.Error("Failed to reload configuration", "error", err)
+			return
+		}
+		a.server.UpdateRateLimits(rateLimitPolicy(cfg.Server.RateLimit))
+		a.logger.Info("Rate limits reloaded from configuration")
+	})
+
 	quit := make(chan os.Signal, 1)
 	signal.Notify(quit, syscall.SIGINT, syscall.SIGTERM)
 	<-quit
@@ -94,6 +278,12 @@ func (a *App) Run() error {
 
 // Shutdown gracefully shuts down the application
 func (a *App) Shutdown() error {
+	if a.cancel != nil {
+		a.cancel()
+	}
+	// Jobs finish their current pass (the ingest buffer its final flush) before the pool closes
+	a.jobsWG.Wait()
+
 	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
 	defer cancel()
 
@@ -103,6 +293,188 @@ func (a *App) Shutdown() error {
 
 	a.repo.Close()
 
+	if a.certs != nil {
+		_ = a.certs.Close()
+	}
+
 	a.logger.Info("Application shutdown completed")
 	return nil
 }
+
+// NewExchangeClient creates the exchange client with the provider's outbound rate limits
+func NewExchangeClient(cfg config.ExchangeConfig, logger *sl.Logger) *exchange.Client {
+	limits := cfg.ProviderRateLimits()
+	endpointLimits := make(map[string]exchange.RateLimit, len(limits.Endpoints))
+	for endpoint, limit := range limits.Endpoints {
+		endpointLimits[endpoint] = exchange.RateLimit{RPS: limit.RPS, Burst: limit.Burst}
+	}
+	limiter := exchange.NewLimiter(cfg.Provider,
+		exchange.RateLimit{RPS: limits.RPS, Burst: limits.Burst}, endpointLimits)
+
+	return exchange.NewClient(cfg.BaseURL, cfg.Timeout, logger,
+		exchange.WithRateLimiter(limiter),
+		exchange.WithSource(cfg.Provider),
+		exchange.WithMaxClockSkew(cfg.MaxClockSkew))
+}
+
+// leaderIdentity returns the configured leader election identity, defaulting to hostname-pid
+func leaderIdentity(cfg config.LeaderConfig) (string, error) {
+	if cfg.Identity != "" {
+		return cfg.Identity, nil
+	}
+	hostname, err := os.Hostname()
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("%s-%d", hostname, os.Getpid()), nil
+}
+
+// OpenPostgres opens the postgres repository with the configured pool settings and read replicas
+func OpenPostgres(cfg config.DatabaseConfig, logger *sl.Logger) (*postgres.Repository, error) {
+	var opts []postgres.Option
+	if len(cfg.Replicas.DSNs) > 0 {
+		opts = append(opts, postgres.WithReplicas(cfg.Replicas.DSNs, cfg.Replicas.MaxLag, cfg.Replicas.LagCheckInterval))
+	}
+
+	return postgres.NewRepository(cfg.GetDSN(), postgres.PoolConfig{
+		MaxConns:          cfg.Pool.MaxConns,
+		MinConns:          cfg.Pool.MinConns,
+		MaxConnLifetime:   cfg.Pool.MaxConnLifetime,
+		MaxConnIdleTime:   cfg.Pool.MaxConnIdleTime,
+		HealthCheckPeriod: cfg.Pool.HealthCheckPeriod,
+		StatementTimeout:  cfg.Pool.StatementTimeout,
+		ConnectTimeout:    cfg.Pool.ConnectTimeout,
+		StartupTimeout:    cfg.Pool.StartupTimeout,
+	}, logger, opts...)
+}
+
+// OpenStore opens the configured storage backend
+func OpenStore(cfg config.DatabaseConfig, logger *sl.Logger) (repository.Store, error) {
+	store, _, err := newStore(cfg, logger)
+	return store, err
+}
+
+// newStore opens the configured storage backend. The postgres repository is also returned on its own,
+// nil for other drivers, for the jobs that only Postgres supports
+func newStore(cfg config.DatabaseConfig, logger *sl.Logger) (repository.Store, *postgres.Repository, error) {
+	switch cfg.Driver {
+	case config.DriverPostgres, "":
+		pg, err := OpenPostgres(cfg, logger)
+		if err != nil {
+			return nil, nil, err
+		}
+		return pg, pg, nil
+	case config.DriverMemory:
+		logger.Warn("Using the in-memory store, data is lost on restart")
+		return memory.New(), nil, nil
+	case config.DriverSQLite:
+		store, err := sqlite.NewRepository(cfg.Path, logger)
+		if err != nil {
+			return nil, nil, err
+		}
+		return store, nil, nil
+	default:
+		return nil, nil, fmt.Errorf("unknown database driver %q", cfg.Driver)
+	}
+}
+
+// newTLS loads the server certificate (reloaded on change) and builds the TLS config for all listeners
+func newTLS(cfg config.TLSConfig, logger *sl.Logger) (*tlsutil.Reloader, *tls.Config, error) {
+	minVersion, err := tlsutil.ParseMinVersion(cfg.MinVersion)
+	if err != nil {
+		return nil, nil, err
+	}
+	clientAuth, err := tlsutil.ParseClientAuth(cfg.ClientAuth)
+	if err != nil {
+		return nil, nil, err
+	}
+	if clientAuth != tls.NoClientCert && cfg.ClientCAFile == "" {
+		return nil, nil, fmt.Errorf("client_auth %q requires client_ca_file", cfg.ClientAuth)
+	}
+
+	certs, err := tlsutil.NewReloader(cfg.CertFile, cfg.KeyFile, cfg.ClientCAFile, logger)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return certs, certs.ServerConfig(minVersion, clientAuth), nil
+}
+
+// newAuth builds the authenticator and authorization policy from configuration
+func newAuth(cfg config.AuthConfig, keys auth.KeyStore) (*auth.Authenticator, *auth.Policy, error) {
+	var jwtVerifier *auth.JWTVerifier
+	if cfg.JWT.JWKSFile != "" {
+		verifier, err := auth.NewJWTVerifier(cfg.JWT.JWKSFile, cfg.JWT.Issuer, cfg.JWT.Audience)
+		if err != nil {
+			return nil, nil, err
+		}
+		jwtVerifier = verifier
+	}
+
+	rules := make([]auth.Rule, 0, len(cfg.Rules))
+	for _, rule := range cfg.Rules {
+		rules = append(rules, auth.Rule{
+			Method: rule.Method,
+			Public: rule.Public,
+			Scopes: rule.Scopes,
+		})
+	}
+
+	certScopes := make(map[string][]string, len(cfg.ClientCerts))
+	for _, cert := range cfg.ClientCerts {
+		certScopes[cert.Subject] = cert.Scopes
+	}
+
+	authenticator := auth.NewAuthenticator(auth.NewKeyVerifier(keys, cfg.KeyCacheTTL), jwtVerifier, certScopes)
+	return authenticator, auth.NewPolicy(rules), nil
+}
+
+// fixingSettleDelay is how long after a window closes its last rates may still be reaching the store: a
+// fetch can take up to the exchange timeout and a buffered rate waits up to the ingest flush interval
+func fixingSettleDelay(cfg *config.Config) time.Duration {
+	delay := cfg.Exchange.Timeout
+	if cfg.Ingest.Enabled {
+		delay += cfg.Ingest.FlushInterval
+	}
+	return delay
+}
+
+// fixingWindows parses the configured fixing windows; VWAP windows need stored order books
+func fixingWindows(cfg config.FixingsConfig, orderBooks bool) ([]fixing.Window, error) {
+	windows := make([]fixing.Window, 0, len(cfg.Windows))
+	for _, wc := range cfg.Windows {
+		market := wc.Market
+		if market == "" {
+			market = exchange.DefaultMarket
+		}
+		w, err := fixing.NewWindow(wc.Name, market, wc.Timezone, wc.Start, wc.End, wc.Method, wc.Depth)
+		if err != nil {
+			return nil, err
+		}
+		if w.Method == fixing.MethodVWAP && !orderBooks {
+			return nil, fmt.Errorf("fixing %q uses vwap, which requires order_book.enabled", w.Name)
+		}
+		windows = append(windows, w)
+	}
+	return windows, nil
+}
+
+// rateLimitPolicy converts the per-client rate limit configuration into a server policy
+func rateLimitPolicy(cfg config.ClientRateLimitConfig) grpc.RateLimitPolicy {
+	policy := grpc.RateLimitPolicy{
+		DefaultTier: cfg.DefaultTier,
+		Tiers:       make(map[string]grpc.RateLimitTier, len(cfg.Tiers)),
+		Clients:     make(map[string]string, len(cfg.Clients)),
+	}
+	for name, tier := range cfg.Tiers {
+		policy.Tiers[name] = grpc.RateLimitTier{
+			RPS:        tier.RPS,
+			Burst:      tier.Burst,
+			DailyQuota: tier.DailyQuota,
+		}
+	}
+	for _, client := range cfg.Clients {
+		policy.Clients[client.Identity] = client.Tier
+	}
+	return policy
+}
@@ -1,11 +1,13 @@
 package app
 
 import (
+	"path/filepath"
 	"testing"
 	"time"
 
 	"github.com/cawa87/garantex-test/internal/config"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestNew(t *testing.T) {
@@ -17,12 +19,7 @@ func TestNew(t *testing.T) {
 			Timeout:     30 * time.Second,
 		},
 		Database: config.DatabaseConfig{
-			Host:     "localhost",
-			Port:     5432,
-			User:     "postgres",
-			Password: "password",
-			DBName:   "garantex_test",
-			SSLMode:  "disable",
+			Driver: config.DriverMemory,
 		},
 		Exchange: config.ExchangeConfig{
 			BaseURL: "https://grinex.io",
@@ -34,12 +31,7 @@ func TestNew(t *testing.T) {
 	}
 
 	app, err := New(cfg)
-
-	// Note: This test will fail if PostgreSQL is not running
-	// In a real test environment, you would use a test database or mock
-	if err != nil {
-		t.Skipf("Skipping test due to database connection error: %v", err)
-	}
+	require.NoError(t, err)
 
 	assert.NotNil(t, app)
 	assert.NotNil(t, app.config)
@@ -55,12 +47,7 @@ func TestApp_Shutdown(t *testing.T) {
 			Timeout:     30 * time.Second,
 		},
 		Database: config.DatabaseConfig{
-			Host:     "localhost",
-			Port:     5432,
-			User:     "postgres",
-			Password: "password",
-			DBName:   "garantex_test",
-			SSLMode:  "disable",
+			Driver: config.DriverMemory,
 		},
 		Exchange: config.ExchangeConfig{
 			BaseURL: "https://grinex.io",
@@ -72,15 +59,44 @@ func TestApp_Shutdown(t *testing.T) {
 	}
 
 	app, err := New(cfg)
-	if err != nil {
-		t.Skipf("Skipping test due to database connection error: %v", err)
-	}
+	require.NoError(t, err)
 
 	// Test shutdown
 	err = app.Shutdown()
 	assert.NoError(t, err)
 }
 
+func TestNew_Driver(t *testing.T) {
+	cfg := &config.Config{
+		Database: config.DatabaseConfig{Driver: "oracle"},
+		Log:      config.LogConfig{Level: "info"},
+	}
+
+	_, err := New(cfg)
+	assert.ErrorContains(t, err, "unknown database driver")
+
+	cfg.Database.Driver = config.DriverMemory
+	cfg.Retention = config.RetentionConfig{Enabled: true, Interval: time.Hour, BatchSize: 100, Raw: time.Hour}
+	_, err = New(cfg)
+	assert.ErrorContains(t, err, "retention requires the postgres driver")
+
+	cfg.Retention = config.RetentionConfig{}
+	cfg.Quality = config.QualityConfig{Enabled: true}
+	_, err = New(cfg)
+	assert.ErrorContains(t, err, "data quality checks require the postgres driver")
+
+	cfg.Quality = config.QualityConfig{}
+	cfg.Guard = config.GuardConfig{Enabled: true}
+	_, err = New(cfg)
+	assert.ErrorContains(t, err, "price guard requires the postgres driver")
+
+	cfg.Database = config.DatabaseConfig{Driver: config.DriverSQLite, Path: filepath.Join(t.TempDir(), "rates.db")}
+	cfg.Guard = config.GuardConfig{}
+	app, err := New(cfg)
+	require.NoError(t, err)
+	assert.NoError(t, app.Shutdown())
+}
+
 func TestConfig_GetDSN(t *testing.T) {
 	dbConfig := config.DatabaseConfig{
 		Host:     "localhost",
@@ -95,3 +111,19 @@ func TestConfig_GetDSN(t *testing.T) {
 	expected := "host=localhost port=5432 user=testuser password=testpass dbname=testdb sslmode=disable"
 	assert.Equal(t, expected, dsn)
 }
+
+func TestFixingWindows(t *testing.T) {
+	cfg := config.FixingsConfig{
+		Windows: []config.FixingWindowConfig{
+			{Name: "msk-noon", Timezone: "Europe/Moscow", Start: "11:45", End: "12:00", Method: "vwap", Depth: 10},
+		},
+	}
+
+	_, err := fixingWindows(cfg, false)
+	assert.ErrorContains(t, err, "order_book.enabled")
+
+	windows, err := fixingWindows(cfg, true)
+	assert.NoError(t, err)
+	assert.Len(t, windows, 1)
+	assert.Equal(t, "btcusdt", windows[0].Market)
+}
@@ -5,39 +5,261 @@ import (
 	"strings"
 	"time"
 
+	"github.com/fsnotify/fsnotify"
 	"github.com/spf13/viper"
 )
 
 // Config holds all configuration for the application
 type Config struct {
-	Server   ServerConfig   `mapstructure:"server"`
-	Database DatabaseConfig `mapstructure:"database"`
-	Exchange ExchangeConfig `mapstructure:"exchange"`
-	Log      LogConfig      `mapstructure:"log"`
+	Server    ServerConfig    `mapstructure:"server"`
+	Database  DatabaseConfig  `mapstructure:"database"`
+	Exchange  ExchangeConfig  `mapstructure:"exchange"`
+	Ingest    IngestConfig    `mapstructure:"ingest"`
+	OrderBook OrderBookConfig `mapstructure:"order_book"`
+	History   HistoryConfig   `mapstructure:"history"`
+	Fixings   FixingsConfig   `mapstructure:"fixings"`
+	Retention RetentionConfig `mapstructure:"retention"`
+	Leader    LeaderConfig    `mapstructure:"leader"`
+	Quality   QualityConfig   `mapstructure:"quality"`
+	Guard     GuardConfig     `mapstructure:"price_guard"`
+	Auth      AuthConfig      `mapstructure:"auth"`
+	Log       LogConfig       `mapstructure:"log"`
 }
 
 // ServerConfig holds server-related configuration
 type ServerConfig struct {
-	GRPCPort    int           `mapstructure:"grpc_port"`
-	HTTPPort    int           `mapstructure:"http_port"`
-	MetricsPort int           `mapstructure:"metrics_port"`
-	Timeout     time.Duration `mapstructure:"timeout"`
+	GRPCPort    int                   `mapstructure:"grpc_port"`
+	HTTPPort    int                   `mapstructure:"http_port"`
+	MetricsPort int                   `mapstructure:"metrics_port"`
+	Timeout     time.Duration         `mapstructure:"timeout"`
+	RateLimit   ClientRateLimitConfig `mapstructure:"rate_limit"`
+	TLS         TLSConfig             `mapstructure:"tls"`
 }
 
-// DatabaseConfig holds database connection configuration
+// TLSConfig holds TLS settings shared by the gRPC and metrics listeners.
+// ClientAuth is one of none, optional or require; optional and require need ClientCAFile
+type TLSConfig struct {
+	Enabled      bool   `mapstructure:"enabled"`
+	CertFile     string `mapstructure:"cert_file"`
+	KeyFile      string `mapstructure:"key_file"`
+	ClientCAFile string `mapstructure:"client_ca_file"`
+	MinVersion   string `mapstructure:"min_version"`
+	ClientAuth   string `mapstructure:"client_auth"`
+}
+
+// ClientRateLimitConfig holds per-client rate limit tiers for the gRPC server
+type ClientRateLimitConfig struct {
+	DefaultTier string                `mapstructure:"default_tier"`
+	Tiers       map[string]TierConfig `mapstructure:"tiers"`
+	Clients     []ClientTierConfig    `mapstructure:"clients"`
+}
+
+// TierConfig holds the request rate and daily quota of a tier; zero disables either limit
+type TierConfig struct {
+	RateLimitConfig `mapstructure:",squash"`
+	DailyQuota      int64 `mapstructure:"daily_quota"`
+}
+
+// ClientTierConfig assigns a client identity (key:<id>, cn:<name> or ip:<addr>) to a tier
+type ClientTierConfig struct {
+	Identity string `mapstructure:"identity"`
+	Tier     string `mapstructure:"tier"`
+}
+
+// Storage drivers selectable with database.driver
+const (
+	DriverPostgres = "postgres"
+	DriverMemory   = "memory"
+	DriverSQLite   = "sqlite"
+)
+
+// DatabaseConfig holds database connection configuration.
+// Driver selects the storage backend; Path is the sqlite database file, the connection settings apply to postgres only.
+// Listen subscribes to the rates saved by other instances sharing the postgres database
 type DatabaseConfig struct {
-	Host     string `mapstructure:"host"`
-	Port     int    `mapstructure:"port"`
-	User     string `mapstructure:"user"`
-	Password string `mapstructure:"password"`
-	DBName   string `mapstructure:"dbname"`
-	SSLMode  string `mapstructure:"sslmode"`
+	Driver     string           `mapstructure:"driver"`
+	Path       string           `mapstructure:"path"`
+	Listen     bool             `mapstructure:"listen"`
+	Host       string           `mapstructure:"host"`
+	Port       int              `mapstructure:"port"`
+	User       string           `mapstructure:"user"`
+	Password   string           `mapstructure:"password"`
+	DBName     string           `mapstructure:"dbname"`
+	SSLMode    string           `mapstructure:"sslmode"`
+	Pool       PoolConfig       `mapstructure:"pool"`
+	Replicas   ReplicasConfig   `mapstructure:"replicas"`
+	Partitions PartitionsConfig `mapstructure:"partitions"`
+}
+
+// ReplicasConfig lists postgres read replicas for history and analytics queries. A replica lagging more
+// than MaxLag is skipped until it catches up; lag is checked in the background every LagCheckInterval
+type ReplicasConfig struct {
+	DSNs             []string      `mapstructure:"dsns"`
+	MaxLag           time.Duration `mapstructure:"max_lag"`
+	LagCheckInterval time.Duration `mapstructure:"lag_check_interval"`
+}
+
+// PoolConfig holds postgres connection pool settings; zero values keep the driver defaults.
+// StartupTimeout is how long startup retries an unreachable database, zero tries once
+type PoolConfig struct {
+	MaxConns          int32         `mapstructure:"max_conns"`
+	MinConns          int32         `mapstructure:"min_conns"`
+	MaxConnLifetime   time.Duration `mapstructure:"max_conn_lifetime"`
+	MaxConnIdleTime   time.Duration `mapstructure:"max_conn_idle_time"`
+	HealthCheckPeriod time.Duration `mapstructure:"health_check_period"`
+	StatementTimeout  time.Duration `mapstructure:"statement_timeout"`
+	ConnectTimeout    time.Duration `mapstructure:"connect_timeout"`
+	StartupTimeout    time.Duration `mapstructure:"startup_timeout"`
+}
+
+// PartitionsConfig holds monthly rates partition maintenance settings. Premake months are created ahead
+// of the current one; partitions whose month ended more than DetachAfter ago are detached, zero keeps all
+type PartitionsConfig struct {
+	Enabled     bool          `mapstructure:"enabled"`
+	Premake     int           `mapstructure:"premake"`
+	DetachAfter time.Duration `mapstructure:"detach_after"`
+	Interval    time.Duration `mapstructure:"interval"`
 }
 
-// ExchangeConfig holds exchange API configuration
+// ExchangeConfig holds exchange API configuration.
+// MaxClockSkew is the exchange clock drift tolerated before warning; zero disables the warning
 type ExchangeConfig struct {
-	BaseURL string        `mapstructure:"base_url"`
-	Timeout time.Duration `mapstructure:"timeout"`
+	BaseURL      string                             `mapstructure:"base_url"`
+	Timeout      time.Duration                      `mapstructure:"timeout"`
+	Provider     string                             `mapstructure:"provider"`
+	MaxClockSkew time.Duration                      `mapstructure:"max_clock_skew"`
+	RateLimits   map[string]ProviderRateLimitConfig `mapstructure:"rate_limits"`
+}
+
+// RateLimitConfig holds token bucket settings; zero RPS disables the limit
+type RateLimitConfig struct {
+	RPS   float64 `mapstructure:"rps"`
+	Burst int     `mapstructure:"burst"`
+}
+
+// ProviderRateLimitConfig holds the provider-wide limit and per-endpoint overrides
+type ProviderRateLimitConfig struct {
+	RateLimitConfig `mapstructure:",squash"`
+	Endpoints       map[string]RateLimitConfig `mapstructure:"endpoints"`
+}
+
+// IngestConfig holds the rate ingest buffer settings. Rates are written in batches of BatchSize
+// or every FlushInterval, whichever comes first
+type IngestConfig struct {
+	Enabled       bool          `mapstructure:"enabled"`
+	BatchSize     int           `mapstructure:"batch_size"`
+	FlushInterval time.Duration `mapstructure:"flush_interval"`
+}
+
+// OrderBookConfig holds order book snapshot storage settings.
+// Depth limits stored levels per side (0 stores the full book); Retention is independent of rate retention
+type OrderBookConfig struct {
+	Enabled       bool          `mapstructure:"enabled"`
+	Depth         int           `mapstructure:"depth"`
+	Retention     time.Duration `mapstructure:"retention"`
+	PruneInterval time.Duration `mapstructure:"prune_interval"`
+}
+
+// HistoryConfig holds historical rate lookup settings.
+// LookupTolerance is the furthest a sample may be from the requested time; zero accepts any distance
+type HistoryConfig struct {
+	LookupTolerance time.Duration `mapstructure:"lookup_tolerance"`
+}
+
+// FixingsConfig holds the daily reference rate windows
+type FixingsConfig struct {
+	Enabled bool                 `mapstructure:"enabled"`
+	Windows []FixingWindowConfig `mapstructure:"windows"`
+}
+
+// FixingWindowConfig describes a daily fixing window. Start and End are "HH:MM" in Timezone;
+// Method is twap (stored ticks) or vwap (order book snapshots, top Depth levels per side, 0 for all)
+type FixingWindowConfig struct {
+	Name     string `mapstructure:"name"`
+	Market   string `mapstructure:"market"`
+	Timezone string `mapstructure:"timezone"`
+	Start    string `mapstructure:"start"`
+	End      string `mapstructure:"end"`
+	Method   string `mapstructure:"method"`
+	Depth    int    `mapstructure:"depth"`
+}
+
+// RetentionConfig holds the rates retention job settings. Raw ticks older than Raw are downsampled into
+// 1-minute buckets, kept for Minute, then rolled up into 1-hour buckets kept for Hour; zero keeps a tier forever
+type RetentionConfig struct {
+	Enabled   bool          `mapstructure:"enabled"`
+	DryRun    bool          `mapstructure:"dry_run"`
+	Interval  time.Duration `mapstructure:"interval"`
+	BatchSize int           `mapstructure:"batch_size"`
+	Raw       time.Duration `mapstructure:"raw"`
+	Minute    time.Duration `mapstructure:"minute"`
+	Hour      time.Duration `mapstructure:"hour"`
+}
+
+// LeaderConfig holds leader election settings for the singleton jobs (retention, fixings, order book
+// pruning, partition maintenance and data quality checks). Replicas campaign and the leader checks its lock every Interval;
+// Identity names this replica, defaulting to hostname-pid
+type LeaderConfig struct {
+	Enabled  bool          `mapstructure:"enabled"`
+	Interval time.Duration `mapstructure:"interval"`
+	Identity string        `mapstructure:"identity"`
+}
+
+// QualityConfig holds the data quality checker settings. Every Interval the checker scans the last Lookback
+// of each market for gaps longer than MaxGap, runs of FrozenSamples identical quotes, crossed quotes and mid
+// price moves larger than MaxJump (a fraction, 0.05 for 5%); ExpectedInterval is the poll interval
+type QualityConfig struct {
+	Enabled          bool          `mapstructure:"enabled"`
+	Markets          []string      `mapstructure:"markets"`
+	Interval         time.Duration `mapstructure:"interval"`
+	Lookback         time.Duration `mapstructure:"lookback"`
+	ExpectedInterval time.Duration `mapstructure:"expected_interval"`
+	MaxGap           time.Duration `mapstructure:"max_gap"`
+	FrozenSamples    int           `mapstructure:"frozen_samples"`
+	MaxJump          float64       `mapstructure:"max_jump"`
+}
+
+// GuardConfig holds the price sanity guard settings. A tick is rejected when its mid price is further than
+// MaxDeviation (a fraction) from the median of the last MedianWindow ticks, when the best level of either side
+// holds less than MinVolume, or when the top-of-book spread exceeds MaxSpread (a fraction of the mid price);
+// zero disables a check. AlertThreshold rejections of a market within AlertWindow raise an alert
+type GuardConfig struct {
+	Enabled        bool          `mapstructure:"enabled"`
+	MedianWindow   int           `mapstructure:"median_window"`
+	MaxDeviation   float64       `mapstructure:"max_deviation"`
+	MinVolume      float64       `mapstructure:"min_volume"`
+	MaxSpread      float64       `mapstructure:"max_spread"`
+	AlertThreshold int           `mapstructure:"alert_threshold"`
+	AlertWindow    time.Duration `mapstructure:"alert_window"`
+}
+
+// AuthConfig holds authentication and method-level authorization configuration
+type AuthConfig struct {
+	Enabled     bool             `mapstructure:"enabled"`
+	KeyCacheTTL time.Duration    `mapstructure:"key_cache_ttl"`
+	JWT         JWTConfig        `mapstructure:"jwt"`
+	ClientCerts []ClientCertAuth `mapstructure:"client_certs"`
+	Rules       []AuthRuleConfig `mapstructure:"rules"`
+}
+
+// ClientCertAuth grants scopes to callers presenting a verified mTLS certificate with the given common name
+type ClientCertAuth struct {
+	Subject string   `mapstructure:"subject"`
+	Scopes  []string `mapstructure:"scopes"`
+}
+
+// JWTConfig holds JWT verification settings; JWTs are rejected when JWKSFile is empty
+type JWTConfig struct {
+	JWKSFile string `mapstructure:"jwks_file"`
+	Issuer   string `mapstructure:"issuer"`
+	Audience string `mapstructure:"audience"`
+}
+
+// AuthRuleConfig grants access to a gRPC method (or a prefix ending in "*") to public callers or to callers holding any of the scopes
+type AuthRuleConfig struct {
+	Method string   `mapstructure:"method"`
+	Public bool     `mapstructure:"public"`
+	Scopes []string `mapstructure:"scopes"`
 }
 
 // LogConfig holds logging configuration
@@ -45,12 +267,17 @@ type LogConfig struct {
 	Level string `mapstructure:"level"`
 }
 
-// Load reads configuration from file, environment variables, and command line flags
-func Load() (*Config, error) {
-	viper.SetConfigName("config")
-	viper.SetConfigType("yaml")
-	viper.AddConfigPath(".")
-	viper.AddConfigPath("./config")
+// Load reads configuration from file, environment variables, and command line flags.
+// An empty path searches for config.yaml in the working directory and ./config
+func Load(path string) (*Config, error) {
+	if path != "" {
+		viper.SetConfigFile(path)
+	} else {
+		viper.SetConfigName("config")
+		viper.SetConfigType("yaml")
+		viper.AddConfigPath(".")
+		viper.AddConfigPath("./config")
+	}
 
 	// Set default values
 	setDefaults()
@@ -66,6 +293,23 @@ func Load() (*Config, error) {
 		}
 	}
 
+	return unmarshal()
+}
+
+// Watch reloads the config file on change and passes the new configuration to onChange.
+// It is a no-op when no config file was loaded
+func Watch(onChange func(*Config, error)) {
+	if viper.ConfigFileUsed() == "" {
+		return
+	}
+
+	viper.OnConfigChange(func(fsnotify.Event) {
+		onChange(unmarshal())
+	})
+	viper.WatchConfig()
+}
+
+func unmarshal() (*Config, error) {
 	var config Config
 	if err := viper.Unmarshal(&config); err != nil {
 		return nil, fmt.Errorf("failed to unmarshal config: %w", err)
@@ -81,23 +325,125 @@ func setDefaults() {
 	viper.SetDefault("server.http_port", 8080)
 	viper.SetDefault("server.metrics_port", 9090)
 	viper.SetDefault("server.timeout", "30s")
+	viper.SetDefault("server.tls.enabled", false)
+	viper.SetDefault("server.tls.min_version", "1.2")
+	viper.SetDefault("server.tls.client_auth", "none")
+	viper.SetDefault("server.rate_limit.default_tier", "standard")
+	viper.SetDefault("server.rate_limit.tiers.standard.rps", 10)
+	viper.SetDefault("server.rate_limit.tiers.standard.burst", 20)
 
 	// Database defaults
+	viper.SetDefault("database.driver", DriverPostgres)
+	viper.SetDefault("database.path", "garantex.db")
+	viper.SetDefault("database.listen", true)
 	viper.SetDefault("database.host", "localhost")
 	viper.SetDefault("database.port", 5432)
 	viper.SetDefault("database.user", "postgres")
 	viper.SetDefault("database.password", "password")
 	viper.SetDefault("database.dbname", "garantex_test")
 	viper.SetDefault("database.sslmode", "disable")
+	viper.SetDefault("database.pool.max_conns", 10)
+	viper.SetDefault("database.pool.min_conns", 0)
+	viper.SetDefault("database.pool.max_conn_lifetime", "1h")
+	viper.SetDefault("database.pool.max_conn_idle_time", "30m")
+	viper.SetDefault("database.pool.health_check_period", "1m")
+	viper.SetDefault("database.pool.statement_timeout", "30s")
+	viper.SetDefault("database.pool.connect_timeout", "5s")
+	viper.SetDefault("database.pool.startup_timeout", "30s")
+	viper.SetDefault("database.replicas.dsns", []string{})
+	viper.SetDefault("database.replicas.max_lag", "5s")
+	viper.SetDefault("database.replicas.lag_check_interval", "5s")
+	viper.SetDefault("database.partitions.enabled", true)
+	viper.SetDefault("database.partitions.premake", 3)
+	viper.SetDefault("database.partitions.detach_after", "0s")
+	viper.SetDefault("database.partitions.interval", "24h")
 
 	// Exchange defaults
 	viper.SetDefault("exchange.base_url", "https://grinex.io")
 	viper.SetDefault("exchange.timeout", "10s")
+	viper.SetDefault("exchange.provider", "garantex")
+	viper.SetDefault("exchange.max_clock_skew", "5s")
+	viper.SetDefault("exchange.rate_limits.garantex.rps", 5)
+	viper.SetDefault("exchange.rate_limits.garantex.burst", 5)
+	viper.SetDefault("exchange.rate_limits.garantex.endpoints.depth.rps", 2)
+	viper.SetDefault("exchange.rate_limits.garantex.endpoints.depth.burst", 2)
+
+	// Ingest defaults
+	viper.SetDefault("ingest.enabled", false)
+	viper.SetDefault("ingest.batch_size", 500)
+	viper.SetDefault("ingest.flush_interval", "1s")
+
+	// Order book defaults
+	viper.SetDefault("order_book.enabled", false)
+	viper.SetDefault("order_book.depth", 20)
+	viper.SetDefault("order_book.retention", "720h")
+	viper.SetDefault("order_book.prune_interval", "1h")
+
+	// History defaults
+	viper.SetDefault("history.lookup_tolerance", "5m")
+
+	// Fixings defaults
+	viper.SetDefault("fixings.enabled", false)
+
+	// Retention defaults
+	viper.SetDefault("retention.enabled", false)
+	viper.SetDefault("retention.dry_run", false)
+	viper.SetDefault("retention.interval", "1h")
+	viper.SetDefault("retention.batch_size", 5000)
+	viper.SetDefault("retention.raw", "720h")
+	viper.SetDefault("retention.minute", "2160h")
+	viper.SetDefault("retention.hour", "0s")
+
+	// Leader election defaults
+	viper.SetDefault("leader.enabled", true)
+	viper.SetDefault("leader.interval", "5s")
+	viper.SetDefault("leader.identity", "")
+
+	// Data quality defaults
+	viper.SetDefault("quality.enabled", false)
+	viper.SetDefault("quality.markets", []string{"btcusdt"})
+	viper.SetDefault("quality.interval", "5m")
+	viper.SetDefault("quality.lookback", "1h")
+	viper.SetDefault("quality.expected_interval", "10s")
+	viper.SetDefault("quality.max_gap", "1m")
+	viper.SetDefault("quality.frozen_samples", 30)
+	viper.SetDefault("quality.max_jump", 0.05)
+
+	// Price guard defaults
+	viper.SetDefault("price_guard.enabled", false)
+	viper.SetDefault("price_guard.median_window", 20)
+	viper.SetDefault("price_guard.max_deviation", 0.1)
+	viper.SetDefault("price_guard.min_volume", 0)
+	viper.SetDefault("price_guard.max_spread", 0.05)
+	viper.SetDefault("price_guard.alert_threshold", 5)
+	viper.SetDefault("price_guard.alert_window", "5m")
+
+	// Auth defaults
+	viper.SetDefault("auth.enabled", false)
+	viper.SetDefault("auth.key_cache_ttl", "30s")
+	viper.SetDefault("auth.rules", []map[string]interface{}{
+		{"method": "/rate_service.v1.RateService/HealthCheck", "public": true},
+		{"method": "/rate_service.v1.RateService/GetRates", "scopes": []string{"rates:read"}},
+		{"method": "/rate_service.v1.RateService/StreamRates", "scopes": []string{"rates:read"}},
+		{"method": "/rate_service.v1.RateService/GetOrderBook", "scopes": []string{"history:read"}},
+		{"method": "/rate_service.v1.RateService/GetRateAt", "scopes": []string{"history:read"}},
+		{"method": "/rate_service.v1.RateService/GetFixing", "scopes": []string{"history:read"}},
+		{"method": "/rate_service.v1.RateService/ListFixings", "scopes": []string{"history:read"}},
+		{"method": "/rate_service.v1.RateService/GetDataQuality", "scopes": []string{"history:read"}},
+		{"method": "/rate_service.v1.RateService/ExportRates", "scopes": []string{"history:read"}},
+		{"method": "/rate_service.v1.RateService/CreateAPIKey", "scopes": []string{"admin"}},
+		{"method": "/rate_service.v1.RateService/RevokeAPIKey", "scopes": []string{"admin"}},
+	})
 
 	// Log defaults
 	viper.SetDefault("log.level", "info")
 }
 
+// ProviderRateLimits returns the rate limits configured for the active provider
+func (c *ExchangeConfig) ProviderRateLimits() ProviderRateLimitConfig {
+	return c.RateLimits[c.Provider]
+}
+
 // GetDSN returns the database connection string
 func (c *DatabaseConfig) GetDSN() string {
 	return fmt.Sprintf("host=%s port=%d user=%s password=%s dbname=%s sslmode=%s",
@@ -1,6 +1,7 @@
 package sl
 
 import (
+	"context"
 	"os"
 
 	"go.uber.org/zap"
@@ -39,6 +40,26 @@ func New(level string) (*Logger, error) {
 	return &Logger{Logger: logger}, nil
 }
 
+// With returns a child logger that adds keysAndValues to every entry
+func (l *Logger) With(keysAndValues ...interface{}) *Logger {
+	return &Logger{Logger: l.Logger.Sugar().With(keysAndValues...).Desugar()}
+}
+
+type loggerKey struct{}
+
+// WithContext returns a context carrying the logger
+func WithContext(ctx context.Context, l *Logger) context.Context {
+	return context.WithValue(ctx, loggerKey{}, l)
+}
+
+// FromContext returns the logger stored in ctx, or fallback if there is none
+func FromContext(ctx context.Context, fallback *Logger) *Logger {
+	if l, ok := ctx.Value(loggerKey{}).(*Logger); ok {
+		return l
+	}
+	return fallback
+}
+
 // Fatal logs a fatal message and exits
 func (l *Logger) Fatal(msg string, keysAndValues ...interface{}) {
 	l.Logger.Sugar().Fatalw(msg, keysAndValues...)
@@ -0,0 +1,181 @@
+package tlsutil
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"github.com/cawa87/garantex-test/internal/lib/logger/sl"
+	"github.com/fsnotify/fsnotify"
+)
+
+// Client authentication modes
+const (
+	ClientAuthNone     = "none"
+	ClientAuthOptional = "optional"
+	ClientAuthRequire  = "require"
+)
+
+// Reloader serves a certificate pair and an optional client CA bundle,
+// reloading them whenever the files change on disk
+type Reloader struct {
+	certFile string
+	keyFile  string
+	caFile   string
+	logger   *sl.Logger
+
+	mu       sync.RWMutex
+	cert     *tls.Certificate
+	clientCA *x509.CertPool
+
+	watcher *fsnotify.Watcher
+	done    chan struct{}
+}
+
+// NewReloader loads the files and starts watching them for changes
+func NewReloader(certFile, keyFile, caFile string, logger *sl.Logger) (*Reloader, error) {
+	r := &Reloader{
+		certFile: certFile,
+		keyFile:  keyFile,
+		caFile:   caFile,
+		logger:   logger,
+		done:     make(chan struct{}),
+	}
+
+	if err := r.reload(); err != nil {
+		return nil, err
+	}
+
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return nil, fmt.Errorf("failed to create file watcher: %w", err)
+	}
+
+	// Watch directories rather than files so atomic replacements (e.g. Kubernetes secret symlink swaps) are seen
+	dirs := map[string]struct{}{}
+	for _, file := range []string{certFile, keyFile, caFile} {
+		if file != "" {
+			dirs[filepath.Dir(file)] = struct{}{}
+		}
+	}
+	for dir := range dirs {
+		if err := watcher.Add(dir); err != nil {
+			_ = watcher.Close()
+			return nil, fmt.Errorf("failed to watch %s: %w", dir, err)
+		}
+	}
+
+	r.watcher = watcher
+	go r.watch()
+
+	return r, nil
+}
+
+// Close stops watching for changes
+func (r *Reloader) Close() error {
+	close(r.done)
+	return r.watcher.Close()
+}
+
+// ServerConfig returns a TLS config that always uses the most recently loaded certificate and client CAs
+func (r *Reloader) ServerConfig(minVersion uint16, clientAuth tls.ClientAuthType) *tls.Config {
+	base := &tls.Config{
+		MinVersion: minVersion,
+		ClientAuth: clientAuth,
+		NextProtos: []string{"h2", "http/1.1"},
+	}
+
+	base.GetConfigForClient = func(*tls.ClientHelloInfo) (*tls.Config, error) {
+		r.mu.RLock()
+		defer r.mu.RUnlock()
+
+		cfg := base.Clone()
+		cfg.GetConfigForClient = nil
+		cfg.Certificates = []tls.Certificate{*r.cert}
+		cfg.ClientCAs = r.clientCA
+		return cfg, nil
+	}
+
+	return base
+}
+
+func (r *Reloader) watch() {
+	for {
+		select {
+		case <-r.done:
+			return
+		case event, ok := <-r.watcher.Events:
+			if !ok {
+				return
+			}
+			if event.Op&(fsnotify.Write|fsnotify.Create|fsnotify.Rename|fsnotify.Remove) == 0 {
+				continue
+			}
+			if err := r.reload(); err != nil {
+				r.logger.Error("Failed to reload TLS certificates, keeping previous ones", "error", err)
+				continue
+			}
+			r.logger.Info("TLS certificates reloaded", "cert", r.certFile)
+		case err, ok := <-r.watcher.Errors:
+			if !ok {
+				return
+			}
+			r.logger.Error("TLS certificate watcher error", "error", err)
+		}
+	}
+}
+
+func (r *Reloader) reload() error {
+	cert, err := tls.LoadX509KeyPair(r.certFile, r.keyFile)
+	if err != nil {
+		return fmt.Errorf("failed to load certificate pair: %w", err)
+	}
+
+	var pool *x509.CertPool
+	if r.caFile != "" {
+		pem, err := os.ReadFile(r.caFile)
+		if err != nil {
+			return fmt.Errorf("failed to read client CA file: %w", err)
+		}
+		pool = x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(pem) {
+			return fmt.Errorf("no certificates found in client CA file %s", r.caFile)
+		}
+	}
+
+	r.mu.Lock()
+	r.cert = &cert
+	r.clientCA = pool
+	r.mu.Unlock()
+
+	return nil
+}
+
+// ParseMinVersion converts "1.2" or "1.3" to a TLS version constant; empty defaults to TLS 1.2
+func ParseMinVersion(version string) (uint16, error) {
+	switch version {
+	case "", "1.2":
+		return tls.VersionTLS12, nil
+	case "1.3":
+		return tls.VersionTLS13, nil
+	default:
+		return 0, fmt.Errorf("unsupported TLS min version %q", version)
+	}
+}
+
+// ParseClientAuth converts a client authentication mode to its tls.ClientAuthType
+func ParseClientAuth(mode string) (tls.ClientAuthType, error) {
+	switch mode {
+	case "", ClientAuthNone:
+		return tls.NoClientCert, nil
+	case ClientAuthOptional:
+		return tls.VerifyClientCertIfGiven, nil
+	case ClientAuthRequire:
+		return tls.RequireAndVerifyClientCert, nil
+	default:
+		return 0, fmt.Errorf("unsupported client auth mode %q", mode)
+	}
+}
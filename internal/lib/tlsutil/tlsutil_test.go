@@ -0,0 +1,117 @@
+package tlsutil
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/cawa87/garantex-test/internal/lib/logger/sl"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func writeSelfSigned(t *testing.T, dir, commonName string) (certFile, keyFile string) {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(time.Now().UnixNano()),
+		Subject:      pkix.Name{CommonName: commonName},
+		DNSNames:     []string{"localhost"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	require.NoError(t, err)
+
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	require.NoError(t, err)
+
+	certFile = filepath.Join(dir, "tls.crt")
+	keyFile = filepath.Join(dir, "tls.key")
+	require.NoError(t, os.WriteFile(certFile, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), 0o600))
+	require.NoError(t, os.WriteFile(keyFile, pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER}), 0o600))
+
+	return certFile, keyFile
+}
+
+func servedCommonName(t *testing.T, cfg *tls.Config) string {
+	t.Helper()
+
+	lis, err := tls.Listen("tcp", "127.0.0.1:0", cfg)
+	require.NoError(t, err)
+	defer lis.Close()
+
+	go func() {
+		conn, err := lis.Accept()
+		if err != nil {
+			return
+		}
+		_ = conn.(*tls.Conn).Handshake()
+		_ = conn.Close()
+	}()
+
+	conn, err := tls.Dial("tcp", lis.Addr().String(), &tls.Config{InsecureSkipVerify: true})
+	require.NoError(t, err)
+	defer conn.Close()
+
+	return conn.ConnectionState().PeerCertificates[0].Subject.CommonName
+}
+
+func TestReloader_ReloadsOnChange(t *testing.T) {
+	logger, err := sl.New("info")
+	require.NoError(t, err)
+
+	dir := t.TempDir()
+	certFile, keyFile := writeSelfSigned(t, dir, "first")
+
+	reloader, err := NewReloader(certFile, keyFile, "", logger)
+	require.NoError(t, err)
+	defer reloader.Close()
+
+	cfg := reloader.ServerConfig(tls.VersionTLS12, tls.NoClientCert)
+	assert.Equal(t, "first", servedCommonName(t, cfg))
+
+	writeSelfSigned(t, dir, "second")
+
+	assert.Eventually(t, func() bool {
+		return servedCommonName(t, cfg) == "second"
+	}, 5*time.Second, 50*time.Millisecond)
+}
+
+func TestNewReloader_MissingFiles(t *testing.T) {
+	logger, err := sl.New("info")
+	require.NoError(t, err)
+
+	_, err = NewReloader("/nonexistent/tls.crt", "/nonexistent/tls.key", "", logger)
+	assert.Error(t, err)
+}
+
+func TestParseOptions(t *testing.T) {
+	version, err := ParseMinVersion("1.3")
+	require.NoError(t, err)
+	assert.Equal(t, uint16(tls.VersionTLS13), version)
+
+	_, err = ParseMinVersion("1.0")
+	assert.Error(t, err)
+
+	mode, err := ParseClientAuth(ClientAuthRequire)
+	require.NoError(t, err)
+	assert.Equal(t, tls.RequireAndVerifyClientCert, mode)
+
+	_, err = ParseClientAuth("sometimes")
+	assert.Error(t, err)
+}
@@ -0,0 +1,106 @@
+package repository
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+// Candle is an OHLC summary of the ask and bid of a market over one interval starting at Start
+type Candle struct {
+	Market   string
+	Start    time.Time
+	AskOpen  decimal.Decimal
+	AskHigh  decimal.Decimal
+	AskLow   decimal.Decimal
+	AskClose decimal.Decimal
+	BidOpen  decimal.Decimal
+	BidHigh  decimal.Decimal
+	BidLow   decimal.Decimal
+	BidClose decimal.Decimal
+	Samples  int64
+}
+
+// CheckCandleInterval reports whether interval is usable for candles: a positive whole number of seconds
+func CheckCandleInterval(interval time.Duration) error {
+	if interval < time.Second || interval%time.Second != 0 {
+		return fmt.Errorf("candle interval %s must be a positive whole number of seconds", interval)
+	}
+	return nil
+}
+
+// CandleStart returns the start of the candle holding t. Candles are aligned to multiples of interval
+// since the Unix epoch, so hourly and daily candles start on UTC hours and days
+func CandleStart(t time.Time, interval time.Duration) time.Time {
+	seconds := int64(interval / time.Second)
+	unix := t.Unix()
+	start := unix - unix%seconds
+	if unix%seconds < 0 {
+		start -= seconds
+	}
+	return time.Unix(start, 0).UTC()
+}
+
+// BuildCandles folds the rates of one market, sorted by ascending timestamp, into candles
+func BuildCandles(rates []*Rate, interval time.Duration) []*Candle {
+	var candles []*Candle
+	builder := CandleBuilder{Interval: interval, Emit: func(c *Candle) error {
+		candles = append(candles, c)
+		return nil
+	}}
+	for _, rate := range rates {
+		_ = builder.Add(rate)
+	}
+	_ = builder.Flush()
+	return candles
+}
+
+// CandleBuilder folds the rates of one market, sorted by ascending timestamp, into candles without holding
+// more than the current one; Emit receives each candle once the next one starts, and the last on Flush
+type CandleBuilder struct {
+	Interval time.Duration
+	Emit     func(*Candle) error
+
+	current *Candle
+}
+
+// Add folds a rate into the current candle, emitting the current candle first if the rate starts a new one
+func (b *CandleBuilder) Add(rate *Rate) error {
+	start := CandleStart(rate.Timestamp, b.Interval)
+	if b.current == nil || !b.current.Start.Equal(start) {
+		if err := b.Flush(); err != nil {
+			return err
+		}
+		b.current = &Candle{
+			Market:  rate.Market,
+			Start:   start,
+			AskOpen: rate.Ask,
+			AskHigh: rate.Ask,
+			AskLow:  rate.Ask,
+			BidOpen: rate.Bid,
+			BidHigh: rate.Bid,
+			BidLow:  rate.Bid,
+		}
+	}
+
+	c := b.current
+	c.AskHigh = decimal.Max(c.AskHigh, rate.Ask)
+	c.AskLow = decimal.Min(c.AskLow, rate.Ask)
+	c.AskClose = rate.Ask
+	c.BidHigh = decimal.Max(c.BidHigh, rate.Bid)
+	c.BidLow = decimal.Min(c.BidLow, rate.Bid)
+	c.BidClose = rate.Bid
+	c.Samples++
+	return nil
+}
+
+// Flush emits the current candle, if any
+func (b *CandleBuilder) Flush() error {
+	if b.current == nil {
+		return nil
+	}
+	c := b.current
+	b.current = nil
+	return b.Emit(c)
+}
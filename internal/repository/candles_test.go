@@ -0,0 +1,52 @@
+package repository
+
+import (
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCandleStart(t *testing.T) {
+	ts := time.Date(2024, 3, 1, 12, 34, 56, 789, time.FixedZone("MSK", 3*60*60))
+
+	assert.Equal(t, time.Date(2024, 3, 1, 9, 34, 0, 0, time.UTC), CandleStart(ts, time.Minute))
+	assert.Equal(t, time.Date(2024, 3, 1, 9, 0, 0, 0, time.UTC), CandleStart(ts, time.Hour))
+	assert.Equal(t, time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC), CandleStart(ts, 24*time.Hour))
+	assert.Equal(t, time.Date(1969, 12, 31, 23, 59, 0, 0, time.UTC), CandleStart(time.Unix(-1, 0), time.Minute))
+}
+
+func TestCheckCandleInterval(t *testing.T) {
+	assert.NoError(t, CheckCandleInterval(time.Second))
+	assert.NoError(t, CheckCandleInterval(15*time.Minute))
+	assert.Error(t, CheckCandleInterval(0))
+	assert.Error(t, CheckCandleInterval(500*time.Millisecond))
+	assert.Error(t, CheckCandleInterval(1500*time.Millisecond))
+}
+
+func TestBuildCandles(t *testing.T) {
+	base := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+	rate := func(ask int64, ts time.Time) *Rate {
+		return &Rate{Market: "btcusdt", Ask: decimal.NewFromInt(ask), Bid: decimal.NewFromInt(ask - 1), Timestamp: ts}
+	}
+
+	candles := BuildCandles([]*Rate{
+		rate(100, base),
+		rate(90, base.Add(10*time.Second)),
+		rate(95, base.Add(50*time.Second)),
+		rate(120, base.Add(2*time.Minute)),
+	}, time.Minute)
+
+	require.Len(t, candles, 2)
+	assert.True(t, decimal.NewFromInt(100).Equal(candles[0].AskOpen))
+	assert.True(t, decimal.NewFromInt(100).Equal(candles[0].AskHigh))
+	assert.True(t, decimal.NewFromInt(90).Equal(candles[0].AskLow))
+	assert.True(t, decimal.NewFromInt(95).Equal(candles[0].AskClose))
+	assert.Equal(t, int64(3), candles[0].Samples)
+	assert.True(t, decimal.NewFromInt(120).Equal(candles[1].AskClose))
+	assert.Equal(t, int64(1), candles[1].Samples)
+
+	assert.Empty(t, BuildCandles(nil, time.Minute))
+}
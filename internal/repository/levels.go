@@ -0,0 +1,40 @@
+package repository
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/cawa87/garantex-test/internal/service/exchange"
+	"github.com/shopspring/decimal"
+)
+
+// EncodeLevels stores levels as compact [price, volume] string pairs to keep exact decimals
+func EncodeLevels(levels []exchange.PriceLevel) ([]byte, error) {
+	pairs := make([][2]string, len(levels))
+	for i, level := range levels {
+		pairs[i] = [2]string{level.Price.String(), level.Volume.String()}
+	}
+	return json.Marshal(pairs)
+}
+
+// DecodeLevels parses levels stored by EncodeLevels
+func DecodeLevels(data []byte) ([]exchange.PriceLevel, error) {
+	var pairs [][2]string
+	if err := json.Unmarshal(data, &pairs); err != nil {
+		return nil, err
+	}
+
+	levels := make([]exchange.PriceLevel, len(pairs))
+	for i, pair := range pairs {
+		price, err := decimal.NewFromString(pair[0])
+		if err != nil {
+			return nil, fmt.Errorf("level %d: invalid price %q", i, pair[0])
+		}
+		volume, err := decimal.NewFromString(pair[1])
+		if err != nil {
+			return nil, fmt.Errorf("level %d: invalid volume %q", i, pair[1])
+		}
+		levels[i] = exchange.PriceLevel{Price: price, Volume: volume}
+	}
+	return levels, nil
+}
@@ -0,0 +1,486 @@
+// Package memory implements a thread-safe in-memory storage backend for tests and local runs.
+// Nothing survives a restart
+package memory
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/cawa87/garantex-test/internal/repository"
+	"github.com/cawa87/garantex-test/internal/service/auth"
+	"github.com/cawa87/garantex-test/internal/service/exchange"
+	"github.com/cawa87/garantex-test/internal/service/fixing"
+	"github.com/shopspring/decimal"
+)
+
+var _ repository.Store = (*Store)(nil)
+
+// sampleKey identifies a rate sample, mirroring the unique index of the SQL backends
+type sampleKey struct {
+	market    string
+	source    string
+	timestamp time.Time
+}
+
+// fixingKey identifies a fixing by window name and date
+type fixingKey struct {
+	name string
+	date string
+}
+
+// Store keeps all records in memory. Values are normalized the way the SQL backends store them:
+// prices are rounded to repository.PriceScale places and times to microseconds
+type Store struct {
+	mu sync.RWMutex
+
+	rates      []repository.Rate
+	samples    map[sampleKey]struct{}
+	books      []repository.OrderBook
+	fixings    map[fixingKey]fixing.Fixing
+	keys       map[string]auth.APIKey
+	lastRateID int64
+	lastBookID int64
+	lastFixID  int64
+}
+
+// New creates an empty store
+func New() *Store {
+	return &Store{
+		samples: make(map[sampleKey]struct{}),
+		fixings: make(map[fixingKey]fixing.Fixing),
+		keys:    make(map[string]auth.APIKey),
+	}
+}
+
+// Close is a no-op; it satisfies repository.Store
+func (s *Store) Close() {}
+
+// SaveRate saves a rate and reports whether it was new
+func (s *Store) SaveRate(_ context.Context, rate *exchange.Rate) (bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	_, created := s.insertRate(rate, time.Now())
+	return created, nil
+}
+
+// SaveRates saves rates and returns the number of new ones
+func (s *Store) SaveRates(_ context.Context, rates []*exchange.Rate) (int64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := time.Now()
+	var n int64
+	for _, rate := range rates {
+		if _, created := s.insertRate(rate, now); created {
+			n++
+		}
+	}
+	return n, nil
+}
+
+// insertRate stores a rate unless its sample is known and returns its ID; the caller holds the lock
+func (s *Store) insertRate(rate *exchange.Rate, now time.Time) (int64, bool) {
+	source := rate.Source
+	if source == "" {
+		source = exchange.DefaultSource
+	}
+
+	key := sampleKey{market: rate.Market, source: source, timestamp: normalizeTime(rate.Timestamp)}
+	if _, ok := s.samples[key]; ok {
+		return 0, false
+	}
+	s.samples[key] = struct{}{}
+
+	s.lastRateID++
+	s.rates = append(s.rates, repository.Rate{
+		ID:         s.lastRateID,
+		Market:     rate.Market,
+		Source:     source,
+		Ask:        normalizePrice(rate.Ask),
+		Bid:        normalizePrice(rate.Bid),
+		Timestamp:  key.timestamp,
+		ReceivedAt: normalizeTime(rate.ReceivedAt),
+		Latency:    rate.Latency.Truncate(time.Microsecond),
+		CreatedAt:  normalizeTime(now),
+	})
+	return s.lastRateID, true
+}
+
+// GetLatestRate returns the rate with the latest timestamp
+func (s *Store) GetLatestRate(_ context.Context) (*repository.Rate, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	var latest *repository.Rate
+	for i := range s.rates {
+		if latest == nil || !s.rates[i].Timestamp.Before(latest.Timestamp) {
+			latest = &s.rates[i]
+		}
+	}
+	if latest == nil {
+		return nil, sql.ErrNoRows
+	}
+
+	rate := *latest
+	return &rate, nil
+}
+
+// GetRatesByTimeRange returns the rates with timestamps in [from, to], newest first
+func (s *Store) GetRatesByTimeRange(_ context.Context, from, to time.Time) ([]*repository.Rate, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	var rates []*repository.Rate
+	for _, rate := range s.rates {
+		if !rate.Timestamp.Before(from) && !rate.Timestamp.After(to) {
+			rate := rate
+			rates = append(rates, &rate)
+		}
+	}
+
+	sort.SliceStable(rates, func(i, j int) bool { return rates[i].Timestamp.After(rates[j].Timestamp) })
+	return rates, nil
+}
+
+// GetRatesAround returns the latest rate of a market at or before at and the earliest one after it
+func (s *Store) GetRatesAround(_ context.Context, market string, at time.Time) (before, after *repository.Rate, err error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	for _, rate := range s.rates {
+		if rate.Market != market {
+			continue
+		}
+		rate := rate
+		if rate.Timestamp.After(at) {
+			if after == nil || rate.Timestamp.Before(after.Timestamp) {
+				after = &rate
+			}
+		} else if before == nil || rate.Timestamp.After(before.Timestamp) {
+			before = &rate
+		}
+	}
+
+	if before == nil && after == nil {
+		return nil, nil, sql.ErrNoRows
+	}
+	return before, after, nil
+}
+
+// GetRatesCount returns the number of stored rates
+func (s *Store) GetRatesCount(_ context.Context) (int64, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return int64(len(s.rates)), nil
+}
+
+// GetCandles aggregates the rates of a market in [from, to) into candles of interval, ascending
+func (s *Store) GetCandles(_ context.Context, market string, interval time.Duration, from, to time.Time) ([]*repository.Candle, error) {
+	if err := repository.CheckCandleInterval(interval); err != nil {
+		return nil, err
+	}
+	return repository.BuildCandles(s.ratesBetween(market, from, to), interval), nil
+}
+
+// StreamRates calls fn with each rate of a market in [from, to), ascending
+func (s *Store) StreamRates(_ context.Context, market string, from, to time.Time, fn func(*repository.Rate) error) error {
+	for _, rate := range s.ratesBetween(market, from, to) {
+		if err := fn(rate); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// StreamCandles calls fn with each candle of a market over the rates in [from, to), ascending
+func (s *Store) StreamCandles(ctx context.Context, market string, interval time.Duration, from, to time.Time, fn func(*repository.Candle) error) error {
+	if err := repository.CheckCandleInterval(interval); err != nil {
+		return err
+	}
+
+	builder := repository.CandleBuilder{Interval: interval, Emit: fn}
+	if err := s.StreamRates(ctx, market, from, to, builder.Add); err != nil {
+		return err
+	}
+	return builder.Flush()
+}
+
+// ratesBetween returns copies of the rates of a market in [from, to), ascending
+func (s *Store) ratesBetween(market string, from, to time.Time) []*repository.Rate {
+	s.mu.RLock()
+	var rates []*repository.Rate
+	for _, rate := range s.rates {
+		if rate.Market == market && !rate.Timestamp.Before(from) && rate.Timestamp.Before(to) {
+			rate := rate
+			rates = append(rates, &rate)
+		}
+	}
+	s.mu.RUnlock()
+
+	sort.SliceStable(rates, func(i, j int) bool { return rates[i].Timestamp.Before(rates[j].Timestamp) })
+	return rates
+}
+
+// SaveRateWithOrderBook saves a rate and its snapshot; nothing is stored for a known sample
+func (s *Store) SaveRateWithOrderBook(_ context.Context, rate *exchange.Rate, book *exchange.OrderBookSnapshot) (bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := time.Now()
+	rateID, created := s.insertRate(rate, now)
+	if !created {
+		return false, nil
+	}
+
+	depth := len(book.Asks)
+	if len(book.Bids) > depth {
+		depth = len(book.Bids)
+	}
+
+	s.lastBookID++
+	s.books = append(s.books, repository.OrderBook{
+		ID:        s.lastBookID,
+		RateID:    &rateID,
+		Market:    book.Market,
+		Timestamp: normalizeTime(book.Timestamp),
+		Depth:     depth,
+		Asks:      copyLevels(book.Asks),
+		Bids:      copyLevels(book.Bids),
+		CreatedAt: normalizeTime(now),
+	})
+	return true, nil
+}
+
+// GetOrderBook returns the latest snapshot of a market taken at or before asOf; zero asOf means the latest
+func (s *Store) GetOrderBook(_ context.Context, market string, asOf time.Time) (*repository.OrderBook, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	var latest *repository.OrderBook
+	for i := range s.books {
+		book := &s.books[i]
+		if book.Market != market || (!asOf.IsZero() && book.Timestamp.After(asOf)) {
+			continue
+		}
+		if latest == nil || !book.Timestamp.Before(latest.Timestamp) {
+			latest = book
+		}
+	}
+	if latest == nil {
+		return nil, sql.ErrNoRows
+	}
+
+	book := *latest
+	rateID := *latest.RateID
+	book.RateID = &rateID
+	book.Asks = copyLevels(latest.Asks)
+	book.Bids = copyLevels(latest.Bids)
+	return &book, nil
+}
+
+// DeleteOrderBooksBefore deletes snapshots older than cutoff and returns the number deleted
+func (s *Store) DeleteOrderBooksBefore(_ context.Context, cutoff time.Time, _ int) (int64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	kept := s.books[:0]
+	for _, book := range s.books {
+		if !book.Timestamp.Before(cutoff) {
+			kept = append(kept, book)
+		}
+	}
+	deleted := int64(len(s.books) - len(kept))
+	s.books = kept
+	return deleted, nil
+}
+
+// GetTicks returns the rate in force at from (if any) followed by the rates in [from, to), ascending
+func (s *Store) GetTicks(_ context.Context, market string, from, to time.Time) ([]fixing.Tick, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	var (
+		inForce *repository.Rate
+		ticks   []fixing.Tick
+	)
+	for i := range s.rates {
+		rate := &s.rates[i]
+		if rate.Market != market {
+			continue
+		}
+		switch {
+		case rate.Timestamp.Before(from):
+			if inForce == nil || rate.Timestamp.After(inForce.Timestamp) {
+				inForce = rate
+			}
+		case rate.Timestamp.Before(to):
+			ticks = append(ticks, fixing.Tick{Ask: rate.Ask, Bid: rate.Bid, Timestamp: rate.Timestamp})
+		}
+	}
+	if inForce != nil {
+		ticks = append(ticks, fixing.Tick{Ask: inForce.Ask, Bid: inForce.Bid, Timestamp: inForce.Timestamp})
+	}
+
+	sort.SliceStable(ticks, func(i, j int) bool { return ticks[i].Timestamp.Before(ticks[j].Timestamp) })
+	return ticks, nil
+}
+
+// GetOrderBooks returns the snapshots of a market taken in [from, to), ascending
+func (s *Store) GetOrderBooks(_ context.Context, market string, from, to time.Time) ([]*exchange.OrderBookSnapshot, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	var books []*exchange.OrderBookSnapshot
+	for _, book := range s.books {
+		if book.Market != market || book.Timestamp.Before(from) || !book.Timestamp.Before(to) {
+			continue
+		}
+		books = append(books, &exchange.OrderBookSnapshot{
+			Market:    book.Market,
+			Timestamp: book.Timestamp,
+			Asks:      copyLevels(book.Asks),
+			Bids:      copyLevels(book.Bids),
+		})
+	}
+
+	sort.SliceStable(books, func(i, j int) bool { return books[i].Timestamp.Before(books[j].Timestamp) })
+	return books, nil
+}
+
+// SaveFixing inserts a fixing, replacing an earlier computation of the same name and date
+func (s *Store) SaveFixing(_ context.Context, f *fixing.Fixing) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	key := fixingKey{name: f.Name, date: f.Date.Format(time.DateOnly)}
+	if existing, ok := s.fixings[key]; ok {
+		f.ID = existing.ID
+	} else {
+		s.lastFixID++
+		f.ID = s.lastFixID
+	}
+
+	stored := *f
+	stored.Date = time.Date(f.Date.Year(), f.Date.Month(), f.Date.Day(), 0, 0, 0, 0, time.UTC)
+	stored.WindowStart = normalizeTime(f.WindowStart)
+	stored.WindowEnd = normalizeTime(f.WindowEnd)
+	stored.ComputedAt = normalizeTime(f.ComputedAt)
+	stored.Ask = normalizePrice(f.Ask)
+	stored.Bid = normalizePrice(f.Bid)
+	stored.Mid = normalizePrice(f.Mid)
+	s.fixings[key] = stored
+	return nil
+}
+
+// GetFixing returns the fixing of a window on a calendar date
+func (s *Store) GetFixing(_ context.Context, name string, date time.Time) (*fixing.Fixing, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	f, ok := s.fixings[fixingKey{name: name, date: date.Format(time.DateOnly)}]
+	if !ok {
+		return nil, sql.ErrNoRows
+	}
+	return &f, nil
+}
+
+// ListFixings returns the fixings matching the filter, newest date first, then by name
+func (s *Store) ListFixings(_ context.Context, filter fixing.ListFilter) ([]*fixing.Fixing, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	from, to := filter.From.Format(time.DateOnly), filter.To.Format(time.DateOnly)
+
+	var fixings []*fixing.Fixing
+	for key, f := range s.fixings {
+		switch {
+		case filter.Name != "" && f.Name != filter.Name,
+			filter.Market != "" && f.Market != filter.Market,
+			!filter.From.IsZero() && key.date < from,
+			!filter.To.IsZero() && key.date > to:
+			continue
+		}
+		f := f
+		fixings = append(fixings, &f)
+	}
+
+	sort.Slice(fixings, func(i, j int) bool {
+		if !fixings[i].Date.Equal(fixings[j].Date) {
+			return fixings[i].Date.After(fixings[j].Date)
+		}
+		return fixings[i].Name < fixings[j].Name
+	})
+	if filter.Limit > 0 && len(fixings) > filter.Limit {
+		fixings = fixings[:filter.Limit]
+	}
+	return fixings, nil
+}
+
+// CreateAPIKey stores a new API key
+func (s *Store) CreateAPIKey(_ context.Context, key *auth.APIKey) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.keys[key.ID]; ok {
+		return fmt.Errorf("failed to create API key: key %s already exists", key.ID)
+	}
+
+	stored := *key
+	stored.Scopes = append([]string(nil), key.Scopes...)
+	stored.CreatedAt = normalizeTime(key.CreatedAt)
+	stored.RevokedAt = nil
+	s.keys[key.ID] = stored
+	return nil
+}
+
+// GetAPIKey returns an API key by ID, including revoked keys
+func (s *Store) GetAPIKey(_ context.Context, id string) (*auth.APIKey, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	key, ok := s.keys[id]
+	if !ok {
+		return nil, sql.ErrNoRows
+	}
+	key.Scopes = append([]string(nil), key.Scopes...)
+	return &key, nil
+}
+
+// RevokeAPIKey marks an active API key as revoked and returns the revocation time
+func (s *Store) RevokeAPIKey(_ context.Context, id string) (time.Time, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	key, ok := s.keys[id]
+	if !ok || key.RevokedAt != nil {
+		return time.Time{}, sql.ErrNoRows
+	}
+
+	revokedAt := normalizeTime(time.Now())
+	key.RevokedAt = &revokedAt
+	s.keys[id] = key
+	return revokedAt, nil
+}
+
+// normalizePrice rounds a price the way DECIMAL(20, 8) columns do
+func normalizePrice(d decimal.Decimal) decimal.Decimal {
+	return d.Round(repository.PriceScale)
+}
+
+// normalizeTime drops the monotonic reading and sub-microsecond precision, like TIMESTAMPTZ columns
+func normalizeTime(t time.Time) time.Time {
+	return t.Round(0).Truncate(time.Microsecond)
+}
+
+func copyLevels(levels []exchange.PriceLevel) []exchange.PriceLevel {
+	if levels == nil {
+		return nil
+	}
+	return append([]exchange.PriceLevel(nil), levels...)
+}
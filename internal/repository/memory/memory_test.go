@@ -0,0 +1,51 @@
+package memory
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/cawa87/garantex-test/internal/repository"
+	"github.com/cawa87/garantex-test/internal/repository/storetest"
+	"github.com/cawa87/garantex-test/internal/service/exchange"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConformance(t *testing.T) {
+	storetest.Run(t, func(t *testing.T) repository.Store {
+		return New()
+	})
+}
+
+func TestConcurrentSaves(t *testing.T) {
+	store := New()
+	ctx := context.Background()
+	base := time.Now()
+
+	var wg sync.WaitGroup
+	for worker := 0; worker < 4; worker++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			// Every worker saves the same samples, as replicas polling at once would
+			for i := 0; i < 100; i++ {
+				_, err := store.SaveRate(ctx, &exchange.Rate{
+					Market:    exchange.DefaultMarket,
+					Ask:       decimal.NewFromInt(int64(i)),
+					Bid:       decimal.NewFromInt(int64(i)),
+					Timestamp: base.Add(time.Duration(i) * time.Second),
+				})
+				assert.NoError(t, err)
+				_, _ = store.GetLatestRate(ctx)
+			}
+		}()
+	}
+	wg.Wait()
+
+	count, err := store.GetRatesCount(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, int64(100), count)
+}
@@ -0,0 +1,85 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/cawa87/garantex-test/internal/service/auth"
+	"github.com/jackc/pgx/v5"
+)
+
+// CreateAPIKey stores a new API key
+func (r *Repository) CreateAPIKey(ctx context.Context, key *auth.APIKey) error {
+	query := `
+		INSERT INTO api_keys (id, name, key_hash, scopes, created_by, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6)
+	`
+
+	_, err := r.pool.Exec(ctx, query, key.ID, key.Name, key.Hash, key.Scopes, key.CreatedBy, key.CreatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to create API key: %w", err)
+	}
+
+	r.logger.Info("API key created",
+		"id", key.ID,
+		"name", key.Name,
+		"scopes", key.Scopes,
+		"created_by", key.CreatedBy)
+
+	return nil
+}
+
+// GetAPIKey retrieves an API key by ID, including revoked keys
+func (r *Repository) GetAPIKey(ctx context.Context, id string) (*auth.APIKey, error) {
+	query := `
+		SELECT id, name, key_hash, scopes, created_by, created_at, revoked_at
+		FROM api_keys
+		WHERE id = $1
+	`
+
+	var key auth.APIKey
+	err := r.pool.QueryRow(ctx, query, id).Scan(
+		&key.ID,
+		&key.Name,
+		&key.Hash,
+		&key.Scopes,
+		&key.CreatedBy,
+		&key.CreatedAt,
+		&key.RevokedAt,
+	)
+
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, sql.ErrNoRows
+		}
+		return nil, fmt.Errorf("failed to get API key: %w", err)
+	}
+
+	return &key, nil
+}
+
+// RevokeAPIKey marks an active API key as revoked and returns the revocation time.
+// It returns sql.ErrNoRows if the key does not exist or is already revoked
+func (r *Repository) RevokeAPIKey(ctx context.Context, id string) (time.Time, error) {
+	query := `
+		UPDATE api_keys
+		SET revoked_at = NOW()
+		WHERE id = $1 AND revoked_at IS NULL
+		RETURNING revoked_at
+	`
+
+	var revokedAt time.Time
+	err := r.pool.QueryRow(ctx, query, id).Scan(&revokedAt)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return time.Time{}, sql.ErrNoRows
+		}
+		return time.Time{}, fmt.Errorf("failed to revoke API key: %w", err)
+	}
+
+	r.logger.Info("API key revoked", "id", id)
+
+	return revokedAt, nil
+}
@@ -0,0 +1,52 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	"github.com/cawa87/garantex-test/internal/lib/logger/sl"
+	"github.com/cawa87/garantex-test/internal/service/auth"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAPIKeys(t *testing.T) {
+	pool, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	logger, err := sl.New("info")
+	require.NoError(t, err)
+
+	repo := &Repository{
+		pool:   pool,
+		logger: logger,
+	}
+
+	ctx := context.Background()
+
+	key, _, err := auth.GenerateAPIKey("reporting", []string{auth.ScopeRatesRead, auth.ScopeHistoryRead}, "cli")
+	require.NoError(t, err)
+	require.NoError(t, repo.CreateAPIKey(ctx, key))
+
+	stored, err := repo.GetAPIKey(ctx, key.ID)
+	require.NoError(t, err)
+	assert.Equal(t, key.Name, stored.Name)
+	assert.Equal(t, key.Hash, stored.Hash)
+	assert.Equal(t, key.Scopes, stored.Scopes)
+	assert.Nil(t, stored.RevokedAt)
+
+	_, err = repo.RevokeAPIKey(ctx, key.ID)
+	require.NoError(t, err)
+
+	stored, err = repo.GetAPIKey(ctx, key.ID)
+	require.NoError(t, err)
+	assert.NotNil(t, stored.RevokedAt)
+
+	// Revoking twice reports not found
+	_, err = repo.RevokeAPIKey(ctx, key.ID)
+	assert.Equal(t, sql.ErrNoRows, err)
+
+	_, err = repo.GetAPIKey(ctx, "missing")
+	assert.Equal(t, sql.ErrNoRows, err)
+}
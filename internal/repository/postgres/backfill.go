@@ -0,0 +1,83 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/cawa87/garantex-test/internal/service/backfill"
+	"github.com/cawa87/garantex-test/internal/service/exchange"
+	"github.com/jackc/pgx/v5"
+)
+
+var _ backfill.Store = (*Repository)(nil)
+
+// SaveBackfilledRates saves a page of backfilled rates like SaveRates and returns the number of new rows.
+// Backfilled history is not announced on RatesChannel, whose subscribers follow live rates
+func (r *Repository) SaveBackfilledRates(ctx context.Context, rates []*exchange.Rate) (int64, error) {
+	return r.saveRates(ctx, rates, false)
+}
+
+// GetBackfillCheckpoint retrieves the checkpoint of a backfill job
+func (r *Repository) GetBackfillCheckpoint(ctx context.Context, name string) (*backfill.Checkpoint, error) {
+	query := `
+		SELECT name, market, mode, period_seconds, window_start, window_end, cursor, last_trade_id,
+			inserted, completed_at, updated_at
+		FROM backfill_checkpoints
+		WHERE name = $1
+	`
+
+	var (
+		cp            backfill.Checkpoint
+		mode          string
+		periodSeconds int64
+	)
+	err := r.pool.QueryRow(ctx, query, name).Scan(
+		&cp.Name,
+		&cp.Market,
+		&mode,
+		&periodSeconds,
+		&cp.From,
+		&cp.To,
+		&cp.Cursor,
+		&cp.LastTradeID,
+		&cp.Inserted,
+		&cp.CompletedAt,
+		&cp.UpdatedAt,
+	)
+
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, sql.ErrNoRows
+		}
+		return nil, fmt.Errorf("failed to get backfill checkpoint: %w", err)
+	}
+
+	cp.Mode = backfill.Mode(mode)
+	cp.Period = time.Duration(periodSeconds) * time.Second
+	return &cp, nil
+}
+
+// SaveBackfillCheckpoint inserts or updates the checkpoint of a backfill job
+func (r *Repository) SaveBackfillCheckpoint(ctx context.Context, cp *backfill.Checkpoint) error {
+	query := `
+		INSERT INTO backfill_checkpoints (name, market, mode, period_seconds, window_start, window_end, cursor,
+			last_trade_id, inserted, completed_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)
+		ON CONFLICT (name) DO UPDATE SET
+			window_end = EXCLUDED.window_end,
+			cursor = EXCLUDED.cursor,
+			last_trade_id = EXCLUDED.last_trade_id,
+			inserted = EXCLUDED.inserted,
+			completed_at = EXCLUDED.completed_at,
+			updated_at = EXCLUDED.updated_at
+	`
+
+	_, err := r.pool.Exec(ctx, query, cp.Name, cp.Market, string(cp.Mode), int64(cp.Period/time.Second),
+		cp.From, cp.To, cp.Cursor, cp.LastTradeID, cp.Inserted, cp.CompletedAt, cp.UpdatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to save backfill checkpoint: %w", err)
+	}
+	return nil
+}
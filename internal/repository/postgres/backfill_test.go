@@ -0,0 +1,116 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/cawa87/garantex-test/internal/lib/logger/sl"
+	"github.com/cawa87/garantex-test/internal/service/backfill"
+	"github.com/cawa87/garantex-test/internal/service/exchange"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBackfillCheckpoints(t *testing.T) {
+	pool, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	logger, err := sl.New("info")
+	require.NoError(t, err)
+
+	repo := &Repository{
+		pool:   pool,
+		logger: logger,
+	}
+	ctx := context.Background()
+
+	_, err = repo.GetBackfillCheckpoint(ctx, "jan")
+	assert.ErrorIs(t, err, sql.ErrNoRows)
+
+	from := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	cp := &backfill.Checkpoint{
+		Name:      "jan",
+		Market:    "btcusdt",
+		Mode:      backfill.ModeKLine,
+		Period:    5 * time.Minute,
+		From:      from,
+		To:        from.AddDate(0, 1, 0),
+		Cursor:    from,
+		UpdatedAt: time.Now().UTC().Truncate(time.Microsecond),
+	}
+	require.NoError(t, repo.SaveBackfillCheckpoint(ctx, cp))
+
+	completedAt := time.Now().UTC().Truncate(time.Microsecond)
+	cp.Cursor = cp.To
+	cp.Inserted = 8928
+	cp.CompletedAt = &completedAt
+	require.NoError(t, repo.SaveBackfillCheckpoint(ctx, cp))
+
+	got, err := repo.GetBackfillCheckpoint(ctx, "jan")
+	require.NoError(t, err)
+	assert.Equal(t, backfill.ModeKLine, got.Mode)
+	assert.Equal(t, 5*time.Minute, got.Period)
+	assert.True(t, cp.To.Equal(got.Cursor))
+	assert.Equal(t, int64(8928), got.Inserted)
+	require.NotNil(t, got.CompletedAt)
+	assert.True(t, completedAt.Equal(*got.CompletedAt))
+}
+
+func TestSaveBackfilledRates(t *testing.T) {
+	pool, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	logger, err := sl.New("info")
+	require.NoError(t, err)
+
+	repo := &Repository{
+		pool:   pool,
+		logger: logger,
+	}
+	ctx := context.Background()
+
+	conn, err := pool.Acquire(ctx)
+	require.NoError(t, err)
+	defer conn.Release()
+	_, err = conn.Exec(ctx, "LISTEN "+RatesChannel)
+	require.NoError(t, err)
+
+	base := time.Now().UTC().Truncate(time.Second)
+	page := make([]*exchange.Rate, 3)
+	for i := range page {
+		page[i] = &exchange.Rate{
+			Market:    "btcusdt",
+			Source:    backfill.Source,
+			Ask:       decimal.NewFromInt(100),
+			Bid:       decimal.NewFromInt(99),
+			Timestamp: base.Add(time.Duration(i) * time.Second),
+		}
+	}
+
+	inserted, err := repo.SaveBackfilledRates(ctx, page)
+	require.NoError(t, err)
+	assert.Equal(t, int64(3), inserted)
+
+	// A backfilled page reaches no live subscriber
+	waitCtx, cancel := context.WithTimeout(ctx, 200*time.Millisecond)
+	defer cancel()
+	_, err = conn.Conn().WaitForNotification(waitCtx)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+
+	// while live rates saved the same way are announced
+	_, err = repo.SaveRates(ctx, []*exchange.Rate{{
+		Market:    "btcusdt",
+		Ask:       decimal.NewFromInt(100),
+		Bid:       decimal.NewFromInt(99),
+		Timestamp: base.Add(time.Minute),
+	}})
+	require.NoError(t, err)
+	waitCtx, cancel = context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+	notification, err := conn.Conn().WaitForNotification(waitCtx)
+	require.NoError(t, err)
+	assert.Equal(t, RatesChannel, notification.Channel)
+}
@@ -0,0 +1,69 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+)
+
+// duplicateRates selects all but the first stored row of every sample identified by key
+func duplicateRates(key string) string {
+	return `
+		SELECT id, timestamp FROM (
+			SELECT id, timestamp,
+				row_number() OVER (PARTITION BY ` + key + ` ORDER BY id) AS n
+			FROM rates
+		) samples
+		WHERE n > 1
+	`
+}
+
+// sampleKey returns the columns identifying a sample. Duplicates are removed before migration 000009
+// adds source, when every row came from garantex and market and timestamp identify a sample
+func (r *Repository) sampleKey(ctx context.Context) (string, error) {
+	query := `
+		SELECT EXISTS (
+			SELECT 1 FROM information_schema.columns
+			WHERE table_schema = current_schema() AND table_name = 'rates' AND column_name = 'source'
+		)
+	`
+
+	var hasSource bool
+	if err := r.pool.QueryRow(ctx, query).Scan(&hasSource); err != nil {
+		return "", fmt.Errorf("failed to inspect rates columns: %w", err)
+	}
+	if hasSource {
+		return "market, source, timestamp", nil
+	}
+	return "market, timestamp", nil
+}
+
+// CountDuplicateRates returns the number of rows that duplicate an already stored sample
+func (r *Repository) CountDuplicateRates(ctx context.Context) (int64, error) {
+	key, err := r.sampleKey(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	var count int64
+	err = r.pool.QueryRow(ctx, `SELECT COUNT(*) FROM (`+duplicateRates(key)+`) d`).Scan(&count)
+	if err != nil {
+		return 0, fmt.Errorf("failed to count duplicate rates: %w", err)
+	}
+	return count, nil
+}
+
+// DeleteDuplicateRates deletes all but the first stored row of every sample in batches
+// and returns the number deleted
+func (r *Repository) DeleteDuplicateRates(ctx context.Context, batchSize int) (int64, error) {
+	key, err := r.sampleKey(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	query := `
+		DELETE FROM rates
+		WHERE (id, timestamp) IN (` + duplicateRates(key) + ` LIMIT $1)
+	`
+
+	return r.deleteInBatches(ctx, "duplicate rates", batchSize, query, batchSize)
+}
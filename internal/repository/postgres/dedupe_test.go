@@ -0,0 +1,59 @@
+package postgres
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/cawa87/garantex-test/internal/lib/logger/sl"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDeleteDuplicateRates(t *testing.T) {
+	pool, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	logger, err := sl.New("info")
+	require.NoError(t, err)
+
+	repo := &Repository{
+		pool:   pool,
+		logger: logger,
+	}
+
+	ctx := context.Background()
+	now := time.Now().UTC().Truncate(time.Second)
+
+	// History written before the sample index existed
+	_, err = pool.Exec(ctx, `DROP INDEX idx_rates_sample`)
+	require.NoError(t, err)
+	_, err = pool.Exec(ctx, `
+		INSERT INTO rates (market, source, ask, bid, timestamp)
+		VALUES
+			('btcusdt', 'garantex', 100, 99, $1),
+			('btcusdt', 'garantex', 100, 99, $1),
+			('btcusdt', 'garantex', 100, 99, $1),
+			('btcusdt', 'backfill', 100, 99, $1),
+			('btcusdt', 'garantex', 101, 100, $2)
+	`, now, now.Add(time.Second))
+	require.NoError(t, err)
+
+	count, err := repo.CountDuplicateRates(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), count)
+
+	deleted, err := repo.DeleteDuplicateRates(ctx, 1)
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), deleted)
+
+	total, err := repo.GetRatesCount(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, int64(3), total)
+
+	// The index migration 000009 creates now succeeds
+	_, err = pool.Exec(ctx, `CREATE UNIQUE INDEX idx_rates_sample ON rates(market, source, timestamp)`)
+	require.NoError(t, err)
+	_, err = pool.Exec(ctx, `INSERT INTO rates (market, source, ask, bid, timestamp) VALUES ('btcusdt', 'garantex', 1, 1, $1)`, now)
+	assert.Error(t, err)
+}
@@ -0,0 +1,235 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/cawa87/garantex-test/internal/repository"
+	"github.com/cawa87/garantex-test/internal/service/exchange"
+	"github.com/cawa87/garantex-test/internal/service/fixing"
+	"github.com/jackc/pgx/v5"
+)
+
+// fixingColumns is the select list matching scanFixing
+const fixingColumns = `id, name, market, fixing_date, method, methodology, window_start, window_end,
+	ask, bid, mid, sample_count, computed_at`
+
+// GetTicks retrieves the rate in force at from (if any) followed by the rates in [from, to), ascending
+func (r *Repository) GetTicks(ctx context.Context, market string, from, to time.Time) ([]fixing.Tick, error) {
+	query := `
+		SELECT ask, bid, timestamp FROM (
+			(SELECT ask, bid, timestamp
+			FROM rates
+			WHERE market = $1 AND timestamp < $2
+			ORDER BY timestamp DESC
+			LIMIT 1)
+			UNION ALL
+			(SELECT ask, bid, timestamp
+			FROM rates
+			WHERE market = $1 AND timestamp >= $2 AND timestamp < $3)
+		) ticks
+		ORDER BY timestamp
+	`
+
+	rows, err := r.reader(ctx, "GetTicks").Query(ctx, query, market, from, to)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query ticks: %w", err)
+	}
+	defer rows.Close()
+
+	var ticks []fixing.Tick
+	for rows.Next() {
+		var tick fixing.Tick
+		if err := rows.Scan(&tick.Ask, &tick.Bid, &tick.Timestamp); err != nil {
+			return nil, fmt.Errorf("failed to scan tick: %w", err)
+		}
+		ticks = append(ticks, tick)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating over rows: %w", err)
+	}
+
+	return ticks, nil
+}
+
+// GetOrderBooks retrieves the order book snapshots of a market taken in [from, to), ascending
+func (r *Repository) GetOrderBooks(ctx context.Context, market string, from, to time.Time) ([]*exchange.OrderBookSnapshot, error) {
+	query := `
+		SELECT market, timestamp, asks, bids
+		FROM order_book_snapshots
+		WHERE market = $1 AND timestamp >= $2 AND timestamp < $3
+		ORDER BY timestamp
+	`
+
+	rows, err := r.reader(ctx, "GetOrderBooks").Query(ctx, query, market, from, to)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query order books: %w", err)
+	}
+	defer rows.Close()
+
+	var books []*exchange.OrderBookSnapshot
+	for rows.Next() {
+		var (
+			book       exchange.OrderBookSnapshot
+			asks, bids []byte
+		)
+		if err := rows.Scan(&book.Market, &book.Timestamp, &asks, &bids); err != nil {
+			return nil, fmt.Errorf("failed to scan order book: %w", err)
+		}
+		if book.Asks, err = repository.DecodeLevels(asks); err != nil {
+			return nil, fmt.Errorf("failed to decode asks: %w", err)
+		}
+		if book.Bids, err = repository.DecodeLevels(bids); err != nil {
+			return nil, fmt.Errorf("failed to decode bids: %w", err)
+		}
+		books = append(books, &book)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating over rows: %w", err)
+	}
+
+	return books, nil
+}
+
+// SaveFixing inserts a fixing, replacing an earlier computation of the same name and date
+func (r *Repository) SaveFixing(ctx context.Context, f *fixing.Fixing) error {
+	query := `
+		INSERT INTO fixings (name, market, fixing_date, method, methodology, window_start, window_end,
+			ask, bid, mid, sample_count, computed_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12)
+		ON CONFLICT (name, fixing_date) DO UPDATE SET
+			market = EXCLUDED.market,
+			method = EXCLUDED.method,
+			methodology = EXCLUDED.methodology,
+			window_start = EXCLUDED.window_start,
+			window_end = EXCLUDED.window_end,
+			ask = EXCLUDED.ask,
+			bid = EXCLUDED.bid,
+			mid = EXCLUDED.mid,
+			sample_count = EXCLUDED.sample_count,
+			computed_at = EXCLUDED.computed_at
+		RETURNING id
+	`
+
+	err := r.pool.QueryRow(ctx, query, f.Name, f.Market, f.Date, string(f.Method), f.Methodology,
+		f.WindowStart, f.WindowEnd, f.Ask, f.Bid, f.Mid, f.SampleCount, f.ComputedAt).Scan(&f.ID)
+	if err != nil {
+		return fmt.Errorf("failed to save fixing: %w", err)
+	}
+
+	r.logger.Debug("Fixing saved to database",
+		"id", f.ID,
+		"name", f.Name,
+		"date", f.Date.Format(time.DateOnly))
+
+	return nil
+}
+
+// GetFixing retrieves the fixing of a window on a calendar date
+func (r *Repository) GetFixing(ctx context.Context, name string, date time.Time) (*fixing.Fixing, error) {
+	query := `
+		SELECT ` + fixingColumns + `
+		FROM fixings
+		WHERE name = $1 AND fixing_date = $2
+	`
+
+	f, err := scanFixing(r.reader(ctx, "GetFixing").QueryRow(ctx, query, name, date))
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, sql.ErrNoRows
+		}
+		return nil, fmt.Errorf("failed to get fixing: %w", err)
+	}
+
+	return f, nil
+}
+
+// ListFixings retrieves fixings matching the filter, newest date first
+func (r *Repository) ListFixings(ctx context.Context, filter fixing.ListFilter) ([]*fixing.Fixing, error) {
+	var (
+		conditions []string
+		args       []interface{}
+	)
+	add := func(condition string, arg interface{}) {
+		args = append(args, arg)
+		conditions = append(conditions, fmt.Sprintf(condition, len(args)))
+	}
+
+	if filter.Name != "" {
+		add("name = $%d", filter.Name)
+	}
+	if filter.Market != "" {
+		add("market = $%d", filter.Market)
+	}
+	if !filter.From.IsZero() {
+		add("fixing_date >= $%d", filter.From)
+	}
+	if !filter.To.IsZero() {
+		add("fixing_date <= $%d", filter.To)
+	}
+
+	query := `SELECT ` + fixingColumns + ` FROM fixings`
+	if len(conditions) > 0 {
+		query += ` WHERE ` + strings.Join(conditions, " AND ")
+	}
+	query += ` ORDER BY fixing_date DESC, name`
+	if filter.Limit > 0 {
+		args = append(args, filter.Limit)
+		query += fmt.Sprintf(` LIMIT $%d`, len(args))
+	}
+
+	rows, err := r.reader(ctx, "ListFixings").Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query fixings: %w", err)
+	}
+	defer rows.Close()
+
+	var fixings []*fixing.Fixing
+	for rows.Next() {
+		f, err := scanFixing(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan fixing: %w", err)
+		}
+		fixings = append(fixings, f)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating over rows: %w", err)
+	}
+
+	return fixings, nil
+}
+
+// scanFixing scans a row selected with fixingColumns
+func scanFixing(row pgx.Row) (*fixing.Fixing, error) {
+	var (
+		f      fixing.Fixing
+		method string
+	)
+	err := row.Scan(
+		&f.ID,
+		&f.Name,
+		&f.Market,
+		&f.Date,
+		&method,
+		&f.Methodology,
+		&f.WindowStart,
+		&f.WindowEnd,
+		&f.Ask,
+		&f.Bid,
+		&f.Mid,
+		&f.SampleCount,
+		&f.ComputedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	f.Method = fixing.Method(method)
+	return &f, nil
+}
@@ -0,0 +1,101 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/cawa87/garantex-test/internal/lib/logger/sl"
+	"github.com/cawa87/garantex-test/internal/service/fixing"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetTicks(t *testing.T) {
+	pool, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	logger, err := sl.New("info")
+	require.NoError(t, err)
+
+	repo := &Repository{
+		pool:   pool,
+		logger: logger,
+	}
+
+	ctx := context.Background()
+	from := time.Now().UTC().Truncate(time.Second)
+	to := from.Add(15 * time.Minute)
+
+	_, err = pool.Exec(ctx, `
+		INSERT INTO rates (market, ask, bid, timestamp)
+		VALUES
+			('btcusdt', 99, 98, $1),
+			('btcusdt', 100, 99, $2),
+			('btcusdt', 101, 100, $3),
+			('btcusdt', 102, 101, $4),
+			('btcusdt', 103, 102, $5)
+	`, from.Add(-2*time.Minute), from.Add(-time.Minute), from.Add(5*time.Minute), from.Add(10*time.Minute), to)
+	require.NoError(t, err)
+
+	ticks, err := repo.GetTicks(ctx, "btcusdt", from, to)
+	require.NoError(t, err)
+	require.Len(t, ticks, 3)
+	assert.True(t, decimal.NewFromInt(100).Equal(ticks[0].Ask))
+	assert.True(t, decimal.NewFromInt(102).Equal(ticks[2].Ask))
+}
+
+func TestFixings(t *testing.T) {
+	pool, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	logger, err := sl.New("info")
+	require.NoError(t, err)
+
+	repo := &Repository{
+		pool:   pool,
+		logger: logger,
+	}
+
+	ctx := context.Background()
+	date := time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+	f := &fixing.Fixing{
+		Name:        "msk-noon",
+		Market:      "btcusdt",
+		Date:        date,
+		Method:      fixing.MethodTWAP,
+		Methodology: "TWAP",
+		WindowStart: date.Add(8*time.Hour + 45*time.Minute),
+		WindowEnd:   date.Add(9 * time.Hour),
+		Ask:         decimal.RequireFromString("100.5"),
+		Bid:         decimal.RequireFromString("99.5"),
+		Mid:         decimal.RequireFromString("100"),
+		SampleCount: 15,
+		ComputedAt:  time.Now(),
+	}
+	require.NoError(t, repo.SaveFixing(ctx, f))
+
+	// Recomputing replaces the stored fixing
+	f.SampleCount = 16
+	require.NoError(t, repo.SaveFixing(ctx, f))
+
+	stored, err := repo.GetFixing(ctx, "msk-noon", date)
+	require.NoError(t, err)
+	assert.Equal(t, 16, stored.SampleCount)
+	assert.Equal(t, fixing.MethodTWAP, stored.Method)
+	assert.True(t, f.Mid.Equal(stored.Mid))
+	assert.Equal(t, date, stored.Date)
+
+	_, err = repo.GetFixing(ctx, "msk-noon", date.AddDate(0, 0, 1))
+	assert.Equal(t, sql.ErrNoRows, err)
+
+	fixings, err := repo.ListFixings(ctx, fixing.ListFilter{Market: "btcusdt", From: date, Limit: 10})
+	require.NoError(t, err)
+	assert.Len(t, fixings, 1)
+
+	fixings, err = repo.ListFixings(ctx, fixing.ListFilter{Name: "other"})
+	require.NoError(t, err)
+	assert.Empty(t, fixings)
+}
@@ -0,0 +1,87 @@
+package postgres
+
+import (
+	"context"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/cawa87/garantex-test/internal/lib/logger/sl"
+	"github.com/cawa87/garantex-test/internal/service/exchange"
+	"github.com/cawa87/garantex-test/internal/service/importer"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestImportRates(t *testing.T) {
+	pool, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	logger, err := sl.New("info")
+	require.NoError(t, err)
+
+	repo := &Repository{
+		pool:   pool,
+		logger: logger,
+	}
+	ctx := context.Background()
+
+	base := time.Date(2021, 6, 1, 12, 0, 0, 0, time.UTC)
+	rate := func(i int) *exchange.Rate {
+		ts := base.Add(time.Duration(i) * time.Minute)
+		return &exchange.Rate{
+			Market:     "btcusdt",
+			Source:     importer.Source,
+			Ask:        decimal.NewFromInt(int64(35000 + i)),
+			Bid:        decimal.NewFromInt(int64(34990 + i)),
+			Timestamp:  ts,
+			ReceivedAt: ts,
+		}
+	}
+	load := func(file string, dryRun bool, rates ...*exchange.Rate) *importer.Load {
+		return &importer.Load{
+			File:   file,
+			From:   rates[0].Timestamp,
+			To:     rates[len(rates)-1].Timestamp,
+			DryRun: dryRun,
+			Next: func() (*exchange.Rate, error) {
+				if len(rates) == 0 {
+					return nil, io.EOF
+				}
+				next := rates[0]
+				rates = rates[1:]
+				return next, nil
+			},
+		}
+	}
+
+	inserted, err := repo.ImportRates(ctx, load("june.csv", true, rate(0), rate(1), rate(1)))
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), inserted)
+
+	var count int64
+	require.NoError(t, pool.QueryRow(ctx, `SELECT COUNT(*) FROM rates`).Scan(&count))
+	assert.Zero(t, count, "a dry run stores nothing")
+
+	inserted, err = repo.ImportRates(ctx, load("june.csv", false, rate(0), rate(1), rate(1)))
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), inserted)
+
+	// Overlapping files only add the samples that are not stored yet
+	inserted, err = repo.ImportRates(ctx, load("june-2.csv", true, rate(1), rate(2)))
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), inserted)
+	inserted, err = repo.ImportRates(ctx, load("june-2.csv", false, rate(1), rate(2)))
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), inserted)
+
+	var file string
+	require.NoError(t, pool.QueryRow(ctx, `SELECT source_file FROM rates WHERE timestamp = $1`, rate(2).Timestamp).Scan(&file))
+	assert.Equal(t, "june-2.csv", file)
+
+	stored, _, err := repo.GetRatesAround(ctx, "btcusdt", rate(1).Timestamp)
+	require.NoError(t, err)
+	assert.True(t, rate(1).Ask.Equal(stored.Ask))
+	assert.Equal(t, importer.Source, stored.Source)
+}
@@ -0,0 +1,105 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/cawa87/garantex-test/internal/service/leader"
+	"github.com/jackc/pgx/v5"
+)
+
+// leaderLockKey identifies leader election among Postgres advisory locks
+const leaderLockKey int64 = 0x72617465736c6472 // "ratesldr"
+
+// LeaderLock is the leader election lock: a session-level advisory lock held on a dedicated connection,
+// so it is lost exactly when that session ends. The connection's application_name is the holder identity
+type LeaderLock struct {
+	repo     *Repository
+	key      int64
+	identity string
+}
+
+var _ leader.Lock = (*LeaderLock)(nil)
+
+// LeaderLock returns the leader election lock for a replica identified by identity
+func (r *Repository) LeaderLock(identity string) *LeaderLock {
+	return &LeaderLock{
+		repo:     r,
+		key:      leaderLockKey,
+		identity: identity,
+	}
+}
+
+// TryAcquire opens a dedicated connection and takes the lock on it if it is free
+func (l *LeaderLock) TryAcquire(ctx context.Context) (leader.Lease, bool, error) {
+	config := l.repo.pool.Config().ConnConfig
+	config.RuntimeParams["application_name"] = l.identity
+
+	conn, err := pgx.ConnectConfig(ctx, config)
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to connect: %w", err)
+	}
+
+	var acquired bool
+	if err := conn.QueryRow(ctx, `SELECT pg_try_advisory_lock($1)`, l.key).Scan(&acquired); err != nil {
+		_ = conn.Close(context.Background())
+		return nil, false, fmt.Errorf("failed to acquire advisory lock: %w", err)
+	}
+	if !acquired {
+		_ = conn.Close(context.Background())
+		return nil, false, nil
+	}
+
+	return &leaderLease{conn: conn, key: l.key}, true, nil
+}
+
+// Holder returns the application_name of the session holding the lock, empty when it is free
+func (l *LeaderLock) Holder(ctx context.Context) (string, error) {
+	// A bigint advisory lock is reported with its high half in classid and its low half in objid
+	query := `
+		SELECT a.application_name
+		FROM pg_locks l
+		JOIN pg_stat_activity a ON a.pid = l.pid
+		WHERE l.locktype = 'advisory'
+			AND l.granted
+			AND l.objsubid = 1
+			AND l.classid = (($1::bigint >> 32) & 4294967295)::oid
+			AND l.objid = ($1::bigint & 4294967295)::oid
+	`
+
+	var holder string
+	err := l.repo.pool.QueryRow(ctx, query, l.key).Scan(&holder)
+	if err == pgx.ErrNoRows {
+		return "", nil
+	}
+	if err != nil {
+		return "", fmt.Errorf("failed to look up lock holder: %w", err)
+	}
+	return holder, nil
+}
+
+// leaderLease is a held LeaderLock
+type leaderLease struct {
+	conn *pgx.Conn
+	key  int64
+}
+
+// Check pings the session holding the lock
+func (l *leaderLease) Check(ctx context.Context) error {
+	if err := l.conn.Ping(ctx); err != nil {
+		return fmt.Errorf("leader session lost: %w", err)
+	}
+	return nil
+}
+
+// Release unlocks and closes the session; closing alone releases the lock if unlocking fails
+func (l *leaderLease) Release(ctx context.Context) error {
+	_, err := l.conn.Exec(ctx, `SELECT pg_advisory_unlock($1)`, l.key)
+	if closeErr := l.conn.Close(ctx); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return fmt.Errorf("failed to release advisory lock: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,50 @@
+package postgres
+
+import (
+	"context"
+	"testing"
+
+	"github.com/cawa87/garantex-test/internal/lib/logger/sl"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLeaderLock(t *testing.T) {
+	pool, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	logger, err := sl.New("info")
+	require.NoError(t, err)
+
+	repo := &Repository{
+		pool:   pool,
+		logger: logger,
+	}
+	ctx := context.Background()
+	a, b := repo.LeaderLock("replica-a"), repo.LeaderLock("replica-b")
+
+	lease, acquired, err := a.TryAcquire(ctx)
+	require.NoError(t, err)
+	require.True(t, acquired)
+
+	_, acquired, err = b.TryAcquire(ctx)
+	require.NoError(t, err)
+	assert.False(t, acquired)
+
+	holder, err := b.Holder(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, "replica-a", holder)
+	assert.NoError(t, lease.Check(ctx))
+
+	require.NoError(t, lease.Release(ctx))
+	assert.Error(t, lease.Check(ctx))
+
+	holder, err = b.Holder(ctx)
+	require.NoError(t, err)
+	assert.Empty(t, holder)
+
+	lease, acquired, err = b.TryAcquire(ctx)
+	require.NoError(t, err)
+	require.True(t, acquired)
+	require.NoError(t, lease.Release(ctx))
+}
@@ -0,0 +1,267 @@
+package postgres
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/cawa87/garantex-test/internal/lib/logger/sl"
+	"github.com/cawa87/garantex-test/internal/repository"
+	"github.com/jackc/pgx/v5"
+	"github.com/shopspring/decimal"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/metric"
+)
+
+// RatesChannel is the channel new rates are announced on
+const RatesChannel = "rates_new"
+
+// resyncLimit bounds the rates replayed after a reconnect; older missed rates are skipped
+const resyncLimit = 1000
+
+// resyncOverlap is how far before the newest rate passed on a resync looks again. Ids and creation times
+// are assigned before commit, so a rate can become visible after newer ones; rates committed up to this
+// much later are still replayed
+const resyncOverlap = time.Minute
+
+// Listener reconnect backoff bounds
+const (
+	minReconnectBackoff = time.Second
+	maxReconnectBackoff = 30 * time.Second
+)
+
+// notifyInserted turns an INSERT statement into one that also announces every new row on RatesChannel.
+// The notifications are delivered when the surrounding transaction commits; the statement returns the
+// new ids
+func notifyInserted(insert string) string {
+	return `
+		WITH inserted AS (` + insert + `
+			RETURNING id, market, source, ask, bid, timestamp, created_at
+		)
+		SELECT id, pg_notify('` + RatesChannel + `', json_build_object(
+			'id', id, 'm', market, 's', source, 'a', ask, 'b', bid, 't', timestamp, 'c', created_at
+		)::text)
+		FROM inserted
+	`
+}
+
+// rateNotification is the payload of a RatesChannel notification
+type rateNotification struct {
+	ID        int64           `json:"id"`
+	Market    string          `json:"m"`
+	Source    string          `json:"s"`
+	Ask       decimal.Decimal `json:"a"`
+	Bid       decimal.Decimal `json:"b"`
+	Timestamp time.Time       `json:"t"`
+	CreatedAt time.Time       `json:"c"`
+}
+
+// decodeNotification parses a RatesChannel payload
+func decodeNotification(payload string) (*repository.Rate, error) {
+	var n rateNotification
+	if err := json.Unmarshal([]byte(payload), &n); err != nil {
+		return nil, fmt.Errorf("invalid rate notification %q: %w", payload, err)
+	}
+	return &repository.Rate{
+		ID:        n.ID,
+		Market:    n.Market,
+		Source:    n.Source,
+		Ask:       n.Ask,
+		Bid:       n.Bid,
+		Timestamp: n.Timestamp,
+		CreatedAt: n.CreatedAt,
+	}, nil
+}
+
+// Listener receives the rates saved by every instance sharing the database and passes them to publish.
+// It reconnects with backoff when the connection drops and replays the rates stored meanwhile
+type Listener struct {
+	repo    *Repository
+	publish func(rate *repository.Rate)
+	logger  *sl.Logger
+
+	received   metric.Int64Counter
+	resynced   metric.Int64Counter
+	reconnects metric.Int64Counter
+}
+
+// NewListener creates a listener on the repository's primary database
+func NewListener(repo *Repository, publish func(rate *repository.Rate), logger *sl.Logger) *Listener {
+	l := &Listener{
+		repo:    repo,
+		publish: publish,
+		logger:  logger,
+	}
+
+	meter := otel.Meter("postgres")
+	l.received, _ = meter.Int64Counter("rates_notifications_received",
+		metric.WithDescription("Rate notifications received from the database"))
+	l.resynced, _ = meter.Int64Counter("rates_notifications_resynced",
+		metric.WithDescription("Rates replayed from the table after the listener reconnected"))
+	l.reconnects, _ = meter.Int64Counter("rates_listener_reconnects",
+		metric.WithDescription("Reconnects of the rate notification listener"))
+	return l
+}
+
+// listenCursor is what a listener has passed on: the newest creation time and the ids of the rates
+// created within resyncOverlap of it
+type listenCursor struct {
+	started bool
+	latest  time.Time
+	seen    map[int64]time.Time
+}
+
+// Run listens until ctx is cancelled
+func (l *Listener) Run(ctx context.Context) {
+	cursor := &listenCursor{seen: map[int64]time.Time{}}
+	backoff := minReconnectBackoff
+
+	for {
+		connected, err := l.listen(ctx, cursor)
+		if ctx.Err() != nil {
+			return
+		}
+		if connected {
+			backoff = minReconnectBackoff
+		}
+
+		l.logger.Warn("Rate notifications interrupted, reconnecting", "error", err, "retry_in", backoff)
+		l.reconnects.Add(ctx, 1)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(backoff):
+		}
+
+		backoff *= 2
+		if backoff > maxReconnectBackoff {
+			backoff = maxReconnectBackoff
+		}
+	}
+}
+
+// listen holds one connection: it subscribes, replays what was missed and then passes on notifications
+// until the connection fails. It reports whether the subscription was established
+func (l *Listener) listen(ctx context.Context, cursor *listenCursor) (bool, error) {
+	conn, err := pgx.ConnectConfig(ctx, l.repo.pool.Config().ConnConfig)
+	if err != nil {
+		return false, fmt.Errorf("failed to connect: %w", err)
+	}
+	defer func() { _ = conn.Close(context.Background()) }()
+
+	if err := afterConnect(ctx, conn); err != nil {
+		return false, err
+	}
+	if _, err := conn.Exec(ctx, `LISTEN `+RatesChannel); err != nil {
+		return false, fmt.Errorf("failed to listen: %w", err)
+	}
+
+	// Rates replayed here may also arrive as notifications sent after LISTEN; pass drops them by id
+	if err := l.resync(ctx, conn, cursor); err != nil {
+		return true, err
+	}
+
+	l.logger.Info("Listening for rate notifications", "channel", RatesChannel, "latest", cursor.latest)
+
+	for {
+		notification, err := conn.WaitForNotification(ctx)
+		if err != nil {
+			return true, err
+		}
+
+		rate, err := decodeNotification(notification.Payload)
+		if err != nil {
+			l.logger.Error("Failed to decode rate notification", "error", err)
+			continue
+		}
+		l.received.Add(ctx, 1)
+		l.pass(rate, cursor)
+	}
+}
+
+// resync passes on the rates created since resyncOverlap before the newest one passed on, at most
+// resyncLimit of the newest, skipping those already passed on. On the first connection it only records
+// the rates already stored
+func (l *Listener) resync(ctx context.Context, conn *pgx.Conn, cursor *listenCursor) error {
+	since := cursor.latest.Add(-resyncOverlap)
+	if !cursor.started {
+		if err := conn.QueryRow(ctx, `SELECT COALESCE(MAX(created_at), NOW()) FROM rates`).Scan(&cursor.latest); err != nil {
+			return fmt.Errorf("failed to read latest rate: %w", err)
+		}
+		since = cursor.latest.Add(-resyncOverlap)
+	}
+
+	query := `
+		SELECT ` + rateColumns + `
+		FROM rates
+		WHERE created_at > $1
+		ORDER BY created_at DESC, id DESC
+		LIMIT $2
+	`
+
+	rows, err := conn.Query(ctx, query, since, resyncLimit)
+	if err != nil {
+		return fmt.Errorf("failed to query missed rates: %w", err)
+	}
+	defer rows.Close()
+
+	var missed []*repository.Rate
+	for rows.Next() {
+		rate, err := scanRate(rows)
+		if err != nil {
+			return fmt.Errorf("failed to scan rate: %w", err)
+		}
+		missed = append(missed, rate)
+	}
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("error iterating over rows: %w", err)
+	}
+
+	if !cursor.started {
+		for _, rate := range missed {
+			cursor.seen[rate.ID] = rate.CreatedAt
+		}
+		cursor.started = true
+		return nil
+	}
+
+	if len(missed) == resyncLimit {
+		l.logger.Warn("Too many rates missed, replaying only the newest", "replayed", len(missed), "since", since)
+	}
+
+	replayed := 0
+	for i := len(missed) - 1; i >= 0; i-- {
+		if l.pass(missed[i], cursor) {
+			replayed++
+		}
+	}
+	l.resynced.Add(ctx, int64(replayed))
+
+	if replayed > 0 {
+		l.logger.Info("Replayed rates missed while disconnected", "count", replayed)
+	}
+	return nil
+}
+
+// pass publishes a rate unless it was passed on already and reports whether it did
+func (l *Listener) pass(rate *repository.Rate, cursor *listenCursor) bool {
+	if _, ok := cursor.seen[rate.ID]; ok {
+		return false
+	}
+	l.publish(rate)
+
+	cursor.seen[rate.ID] = rate.CreatedAt
+	if rate.CreatedAt.After(cursor.latest) {
+		cursor.latest = rate.CreatedAt
+		// Rates created before the overlap are never queried again
+		cutoff := cursor.latest.Add(-resyncOverlap)
+		for id, createdAt := range cursor.seen {
+			if !createdAt.After(cutoff) {
+				delete(cursor.seen, id)
+			}
+		}
+	}
+	return true
+}
@@ -0,0 +1,170 @@
+package postgres
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/cawa87/garantex-test/internal/lib/logger/sl"
+	"github.com/cawa87/garantex-test/internal/repository"
+	"github.com/cawa87/garantex-test/internal/service/exchange"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDecodeNotification(t *testing.T) {
+	// Payload as rendered by json_build_object
+	rate, err := decodeNotification(`{"id" : 42, "m" : "btcusdt", "s" : "garantex", "a" : 100.50000000, "b" : 99.25000000, "t" : "2024-01-01T12:00:00.123456+00:00", "c" : "2024-01-01T12:00:00.5+00:00"}`)
+	require.NoError(t, err)
+	assert.Equal(t, int64(42), rate.ID)
+	assert.Equal(t, "btcusdt", rate.Market)
+	assert.Equal(t, "garantex", rate.Source)
+	assert.True(t, decimal.RequireFromString("100.5").Equal(rate.Ask))
+	assert.True(t, decimal.RequireFromString("99.25").Equal(rate.Bid))
+	assert.True(t, time.Date(2024, 1, 1, 12, 0, 0, 123456000, time.UTC).Equal(rate.Timestamp))
+	assert.True(t, time.Date(2024, 1, 1, 12, 0, 0, 500000000, time.UTC).Equal(rate.CreatedAt))
+
+	_, err = decodeNotification(`not json`)
+	assert.Error(t, err)
+}
+
+func TestListener(t *testing.T) {
+	pool, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	logger, err := sl.New("info")
+	require.NoError(t, err)
+
+	repo := &Repository{
+		pool:   pool,
+		logger: logger,
+	}
+
+	published := make(chan *repository.Rate, 10)
+	probes := make(chan struct{}, 100)
+	listener := NewListener(repo, func(rate *repository.Rate) {
+		if rate.Market == "probe" {
+			probes <- struct{}{}
+			return
+		}
+		published <- rate
+	}, logger)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		listener.Run(ctx)
+		close(done)
+	}()
+	defer func() {
+		cancel()
+		<-done
+	}()
+
+	// Probe until the subscription is up
+	require.Eventually(t, func() bool {
+		_, err := pool.Exec(context.Background(), `SELECT pg_notify($1, '{"id": 0, "m": "probe"}')`, RatesChannel)
+		require.NoError(t, err)
+		select {
+		case <-probes:
+			return true
+		case <-time.After(50 * time.Millisecond):
+			return false
+		}
+	}, 5*time.Second, 10*time.Millisecond)
+
+	rate := &exchange.Rate{
+		Market:    "btcusdt",
+		Ask:       decimal.RequireFromString("100.5"),
+		Bid:       decimal.RequireFromString("99.5"),
+		Timestamp: time.Now().UTC().Truncate(time.Microsecond),
+	}
+	created, err := repo.SaveRate(context.Background(), rate)
+	require.NoError(t, err)
+	require.True(t, created)
+
+	select {
+	case got := <-published:
+		assert.Equal(t, "btcusdt", got.Market)
+		assert.True(t, rate.Ask.Equal(got.Ask))
+		assert.True(t, rate.Timestamp.Equal(got.Timestamp))
+	case <-time.After(5 * time.Second):
+		t.Fatal("rate notification not received")
+	}
+
+	// A known sample is not announced again
+	created, err = repo.SaveRate(context.Background(), rate)
+	require.NoError(t, err)
+	assert.False(t, created)
+	select {
+	case got := <-published:
+		t.Fatalf("unexpected notification for rate %d", got.ID)
+	case <-time.After(200 * time.Millisecond):
+	}
+}
+
+func TestListener_Resync(t *testing.T) {
+	pool, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	logger, err := sl.New("info")
+	require.NoError(t, err)
+
+	repo := &Repository{
+		pool:   pool,
+		logger: logger,
+	}
+
+	var published []*repository.Rate
+	listener := NewListener(repo, func(rate *repository.Rate) { published = append(published, rate) }, logger)
+
+	ctx := context.Background()
+	conn, err := pool.Acquire(ctx)
+	require.NoError(t, err)
+	defer conn.Release()
+
+	ensureTestPartitions(t, pool, time.Date(2023, 12, 1, 0, 0, 0, 0, time.UTC), time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+
+	// The first connection only records the rates already stored
+	_, err = repo.SaveRate(ctx, &exchange.Rate{
+		Market:    "btcusdt",
+		Ask:       decimal.NewFromInt(100),
+		Bid:       decimal.NewFromInt(99),
+		Timestamp: time.Date(2023, 12, 31, 0, 0, 0, 0, time.UTC),
+	})
+	require.NoError(t, err)
+	cursor := &listenCursor{seen: map[int64]time.Time{}}
+	require.NoError(t, listener.resync(ctx, conn.Conn(), cursor))
+	assert.Empty(t, published)
+
+	base := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	for i := 0; i < 3; i++ {
+		_, err := repo.SaveRate(ctx, &exchange.Rate{
+			Market:    "btcusdt",
+			Ask:       decimal.NewFromInt(100),
+			Bid:       decimal.NewFromInt(99),
+			Timestamp: base.Add(time.Duration(i) * time.Second),
+		})
+		require.NoError(t, err)
+	}
+
+	// After a gap the rates stored meanwhile are replayed oldest first
+	require.NoError(t, listener.resync(ctx, conn.Conn(), cursor))
+	require.Len(t, published, 3)
+	assert.Less(t, published[0].ID, published[2].ID)
+
+	// A rate that commits after newer ones, with a lower id and an earlier creation time, is still
+	// replayed; the rates already passed on are not
+	var lateID int64
+	err = pool.QueryRow(ctx, `
+		INSERT INTO rates (market, ask, bid, timestamp, created_at)
+		VALUES ('btcusdt', 100, 99, $1, $2)
+		RETURNING id
+	`, base.Add(time.Hour), cursor.latest.Add(-10*time.Second)).Scan(&lateID)
+	require.NoError(t, err)
+
+	require.NoError(t, listener.resync(ctx, conn.Conn(), cursor))
+	require.Len(t, published, 4)
+	assert.Equal(t, lateID, published[3].ID)
+}
@@ -0,0 +1,132 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/cawa87/garantex-test/internal/repository"
+	"github.com/cawa87/garantex-test/internal/service/exchange"
+	"github.com/jackc/pgx/v5"
+)
+
+// SaveRateWithOrderBook saves a rate and its order book snapshot in one transaction and reports whether
+// the rate was new; nothing is stored for a sample that already exists
+func (r *Repository) SaveRateWithOrderBook(ctx context.Context, rate *exchange.Rate, book *exchange.OrderBookSnapshot) (bool, error) {
+	asks, err := repository.EncodeLevels(book.Asks)
+	if err != nil {
+		return false, fmt.Errorf("failed to encode asks: %w", err)
+	}
+	bids, err := repository.EncodeLevels(book.Bids)
+	if err != nil {
+		return false, fmt.Errorf("failed to encode bids: %w", err)
+	}
+
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return false, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback(ctx) }()
+
+	var rateID int64
+	err = tx.QueryRow(ctx, notifyInserted(`
+		INSERT INTO rates (market, source, ask, bid, timestamp, received_at, latency_us, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+		ON CONFLICT DO NOTHING
+	`), rate.Market, rateSource(rate), rate.Ask, rate.Bid, rate.Timestamp, rate.ReceivedAt, rate.Latency.Microseconds(), time.Now()).Scan(&rateID, nil)
+	if err == pgx.ErrNoRows {
+		r.logger.Debug("Rate already stored, skipping order book", "market", rate.Market, "timestamp", rate.Timestamp)
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("failed to save rate: %w", err)
+	}
+
+	depth := len(book.Asks)
+	if len(book.Bids) > depth {
+		depth = len(book.Bids)
+	}
+
+	_, err = tx.Exec(ctx, `
+		INSERT INTO order_book_snapshots (rate_id, market, timestamp, depth, asks, bids)
+		VALUES ($1, $2, $3, $4, $5, $6)
+	`, rateID, book.Market, book.Timestamp, depth, asks, bids)
+	if err != nil {
+		return false, fmt.Errorf("failed to save order book: %w", err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return false, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	r.logger.Debug("Rate and order book saved to database",
+		"rate_id", rateID,
+		"market", book.Market,
+		"depth", depth,
+		"timestamp", rate.Timestamp)
+
+	return true, nil
+}
+
+// GetOrderBook retrieves the latest order book snapshot of a market taken at or before asOf;
+// a zero asOf returns the latest snapshot
+func (r *Repository) GetOrderBook(ctx context.Context, market string, asOf time.Time) (*repository.OrderBook, error) {
+	query := `
+		SELECT id, rate_id, market, timestamp, depth, asks, bids, created_at
+		FROM order_book_snapshots
+		WHERE market = $1 AND ($2::timestamptz IS NULL OR timestamp <= $2)
+		ORDER BY timestamp DESC
+		LIMIT 1
+	`
+
+	var bound *time.Time
+	if !asOf.IsZero() {
+		bound = &asOf
+	}
+
+	var (
+		book       repository.OrderBook
+		asks, bids []byte
+	)
+	err := r.reader(ctx, "GetOrderBook").QueryRow(ctx, query, market, bound).Scan(
+		&book.ID,
+		&book.RateID,
+		&book.Market,
+		&book.Timestamp,
+		&book.Depth,
+		&asks,
+		&bids,
+		&book.CreatedAt,
+	)
+
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, sql.ErrNoRows
+		}
+		return nil, fmt.Errorf("failed to get order book: %w", err)
+	}
+
+	if book.Asks, err = repository.DecodeLevels(asks); err != nil {
+		return nil, fmt.Errorf("failed to decode asks: %w", err)
+	}
+	if book.Bids, err = repository.DecodeLevels(bids); err != nil {
+		return nil, fmt.Errorf("failed to decode bids: %w", err)
+	}
+
+	return &book, nil
+}
+
+// DeleteOrderBooksBefore deletes snapshots older than cutoff in batches and returns the number deleted
+func (r *Repository) DeleteOrderBooksBefore(ctx context.Context, cutoff time.Time, batchSize int) (int64, error) {
+	query := `
+		DELETE FROM order_book_snapshots
+		WHERE id IN (
+			SELECT id FROM order_book_snapshots
+			WHERE timestamp < $1
+			LIMIT $2
+		)
+	`
+
+	return r.deleteInBatches(ctx, "order books", batchSize, query, cutoff, batchSize)
+}
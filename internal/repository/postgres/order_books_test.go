@@ -0,0 +1,80 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/cawa87/garantex-test/internal/lib/logger/sl"
+	"github.com/cawa87/garantex-test/internal/service/exchange"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOrderBooks(t *testing.T) {
+	pool, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	logger, err := sl.New("info")
+	require.NoError(t, err)
+
+	repo := &Repository{
+		pool:   pool,
+		logger: logger,
+	}
+
+	ctx := context.Background()
+	older := time.Now().Add(-time.Hour).UTC().Truncate(time.Microsecond)
+	newer := older.Add(30 * time.Minute)
+
+	for _, ts := range []time.Time{older, newer} {
+		book := &exchange.OrderBookSnapshot{
+			Market:    exchange.DefaultMarket,
+			Timestamp: ts,
+			Asks: []exchange.PriceLevel{
+				{Price: decimal.RequireFromString("100.50"), Volume: decimal.RequireFromString("1.25")},
+				{Price: decimal.RequireFromString("100.75"), Volume: decimal.RequireFromString("3")},
+			},
+			Bids: []exchange.PriceLevel{
+				{Price: decimal.RequireFromString("99.50"), Volume: decimal.RequireFromString("0.00000001")},
+			},
+		}
+		rate := &exchange.Rate{Market: book.Market, Ask: book.Asks[0].Price, Bid: book.Bids[0].Price, Timestamp: ts}
+		created, err := repo.SaveRateWithOrderBook(ctx, rate, book)
+		require.NoError(t, err)
+		assert.True(t, created)
+	}
+
+	// A repeated sample stores neither the rate nor another snapshot
+	rate := &exchange.Rate{Market: exchange.DefaultMarket, Ask: decimal.NewFromInt(1), Bid: decimal.NewFromInt(1), Timestamp: newer}
+	created, err := repo.SaveRateWithOrderBook(ctx, rate, &exchange.OrderBookSnapshot{Market: exchange.DefaultMarket, Timestamp: newer})
+	require.NoError(t, err)
+	assert.False(t, created)
+
+	latest, err := repo.GetOrderBook(ctx, exchange.DefaultMarket, time.Time{})
+	require.NoError(t, err)
+	assert.True(t, newer.Equal(latest.Timestamp))
+	assert.NotNil(t, latest.RateID)
+	assert.Equal(t, 2, latest.Depth)
+	require.Len(t, latest.Asks, 2)
+	assert.True(t, decimal.RequireFromString("100.75").Equal(latest.Asks[1].Price))
+	assert.True(t, decimal.RequireFromString("0.00000001").Equal(latest.Bids[0].Volume))
+
+	asOf, err := repo.GetOrderBook(ctx, exchange.DefaultMarket, newer.Add(-time.Minute))
+	require.NoError(t, err)
+	assert.True(t, older.Equal(asOf.Timestamp))
+
+	_, err = repo.GetOrderBook(ctx, exchange.DefaultMarket, older.Add(-time.Minute))
+	assert.Equal(t, sql.ErrNoRows, err)
+
+	deleted, err := repo.DeleteOrderBooksBefore(ctx, newer, 1)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), deleted)
+
+	// Rates are kept when their snapshots are pruned
+	count, err := repo.GetRatesCount(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), count)
+}
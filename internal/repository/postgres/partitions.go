@@ -0,0 +1,196 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/cawa87/garantex-test/internal/lib/logger/sl"
+	"github.com/jackc/pgx/v5"
+)
+
+// partitionLockKey identifies partition maintenance among Postgres advisory locks
+const partitionLockKey int64 = 0x7261746573707274 // "ratesprt"
+
+// partitionNameLayout names monthly rates partitions, e.g. rates_2024_03
+const partitionNameLayout = "rates_2006_01"
+
+// Partition is a monthly partition of the rates table covering [From, To) in UTC
+type Partition struct {
+	Name string
+	From time.Time
+	To   time.Time
+}
+
+// monthPartition returns the partition holding t
+func monthPartition(t time.Time) Partition {
+	t = t.UTC()
+	from := time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, time.UTC)
+	return Partition{
+		Name: from.Format(partitionNameLayout),
+		From: from,
+		To:   from.AddDate(0, 1, 0),
+	}
+}
+
+// ListPartitions returns the monthly partitions attached to rates, oldest first
+func (r *Repository) ListPartitions(ctx context.Context) ([]Partition, error) {
+	query := `
+		SELECT c.relname
+		FROM pg_inherits i
+		JOIN pg_class c ON c.oid = i.inhrelid
+		WHERE i.inhparent = 'rates'::regclass
+	`
+
+	rows, err := r.pool.Query(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list partitions: %w", err)
+	}
+	names, err := pgx.CollectRows(rows, pgx.RowTo[string])
+	if err != nil {
+		return nil, fmt.Errorf("failed to list partitions: %w", err)
+	}
+
+	partitions := make([]Partition, 0, len(names))
+	for _, name := range names {
+		month, err := time.Parse(partitionNameLayout, name)
+		if err != nil {
+			// Not created by us, e.g. a manually attached archive; leave it alone
+			continue
+		}
+		partitions = append(partitions, monthPartition(month))
+	}
+
+	sort.Slice(partitions, func(i, j int) bool { return partitions[i].From.Before(partitions[j].From) })
+	return partitions, nil
+}
+
+// EnsurePartitions creates the missing monthly partitions covering [from, to] and returns the ones created.
+// A month whose table exists but is not attached, e.g. after DetachPartitionsBefore, fails instead of being
+// re-attached behind the archiving operator's back
+func (r *Repository) EnsurePartitions(ctx context.Context, from, to time.Time) ([]Partition, error) {
+	existing, err := r.ListPartitions(ctx)
+	if err != nil {
+		return nil, err
+	}
+	attached := make(map[string]bool, len(existing))
+	for _, p := range existing {
+		attached[p.Name] = true
+	}
+
+	var created []Partition
+	for p := monthPartition(from); !p.From.After(to); p = monthPartition(p.To) {
+		if attached[p.Name] {
+			continue
+		}
+
+		var exists bool
+		if err := r.pool.QueryRow(ctx, `SELECT to_regclass($1) IS NOT NULL`, p.Name).Scan(&exists); err != nil {
+			return created, fmt.Errorf("failed to look up partition %s: %w", p.Name, err)
+		}
+		bounds := fmt.Sprintf(`FOR VALUES FROM ('%s') TO ('%s')`, p.From.Format(time.RFC3339), p.To.Format(time.RFC3339))
+		if exists {
+			return created, fmt.Errorf("partition %s exists but is not attached to rates, e.g. detached for archiving; "+
+				"re-attach it with ALTER TABLE rates ATTACH PARTITION %s %s, or drop it", p.Name, p.Name, bounds)
+		}
+
+		query := fmt.Sprintf(`CREATE TABLE %s PARTITION OF rates %s`, pgx.Identifier{p.Name}.Sanitize(), bounds)
+		if _, err := r.pool.Exec(ctx, query); err != nil {
+			return created, fmt.Errorf("failed to create partition %s: %w", p.Name, err)
+		}
+		created = append(created, p)
+	}
+	return created, nil
+}
+
+// DetachPartitionsBefore detaches the partitions that end at or before cutoff and returns them.
+// Detached partitions remain as standalone tables for archiving (e.g. pg_dump -t) and dropping
+func (r *Repository) DetachPartitionsBefore(ctx context.Context, cutoff time.Time) ([]Partition, error) {
+	partitions, err := r.ListPartitions(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var detached []Partition
+	for _, p := range partitions {
+		if p.To.After(cutoff) {
+			break
+		}
+		query := fmt.Sprintf(`ALTER TABLE rates DETACH PARTITION %s`, pgx.Identifier{p.Name}.Sanitize())
+		if _, err := r.pool.Exec(ctx, query); err != nil {
+			return detached, fmt.Errorf("failed to detach partition %s: %w", p.Name, err)
+		}
+		detached = append(detached, p)
+	}
+	return detached, nil
+}
+
+// PartitionMaintainer pre-creates upcoming rates partitions and detaches expired ones.
+// Only the replica holding the advisory lock runs a pass
+type PartitionMaintainer struct {
+	repo        *Repository
+	premake     int
+	detachAfter time.Duration
+	interval    time.Duration
+	logger      *sl.Logger
+}
+
+// NewPartitionMaintainer creates a maintainer that keeps premake months of partitions ahead of the current one
+// and detaches partitions whose month ended more than detachAfter ago; zero detachAfter keeps all partitions
+func NewPartitionMaintainer(repo *Repository, premake int, detachAfter, interval time.Duration, logger *sl.Logger) *PartitionMaintainer {
+	return &PartitionMaintainer{
+		repo:        repo,
+		premake:     premake,
+		detachAfter: detachAfter,
+		interval:    interval,
+		logger:      logger,
+	}
+}
+
+// Run maintains partitions immediately and then on every interval until ctx is cancelled
+func (m *PartitionMaintainer) Run(ctx context.Context) {
+	ticker := time.NewTicker(m.interval)
+	defer ticker.Stop()
+
+	for {
+		if err := m.Maintain(ctx, time.Now()); err != nil && ctx.Err() == nil {
+			m.logger.Error("Partition maintenance failed", "error", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Maintain runs one maintenance pass as of now
+func (m *PartitionMaintainer) Maintain(ctx context.Context, now time.Time) error {
+	acquired, err := m.repo.WithAdvisoryLock(ctx, partitionLockKey, func(ctx context.Context) error {
+		created, err := m.repo.EnsurePartitions(ctx, now, now.UTC().AddDate(0, m.premake, 0))
+		for _, p := range created {
+			m.logger.Info("Rates partition created", "partition", p.Name, "from", p.From, "to", p.To)
+		}
+		if err != nil {
+			return err
+		}
+
+		if m.detachAfter <= 0 {
+			return nil
+		}
+		detached, err := m.repo.DetachPartitionsBefore(ctx, now.Add(-m.detachAfter))
+		for _, p := range detached {
+			m.logger.Info("Rates partition detached for archiving", "partition", p.Name, "from", p.From, "to", p.To)
+		}
+		return err
+	})
+	if err != nil {
+		return err
+	}
+	if !acquired {
+		m.logger.Debug("Partition maintenance skipped, lock held by another replica")
+	}
+	return nil
+}
@@ -0,0 +1,100 @@
+package postgres
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/cawa87/garantex-test/internal/lib/logger/sl"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMonthPartition(t *testing.T) {
+	msk := time.FixedZone("MSK", 3*60*60)
+
+	p := monthPartition(time.Date(2024, 3, 1, 1, 0, 0, 0, msk))
+	assert.Equal(t, "rates_2024_02", p.Name)
+	assert.Equal(t, time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC), p.From)
+	assert.Equal(t, time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC), p.To)
+
+	p = monthPartition(time.Date(2024, 12, 31, 23, 59, 59, 0, time.UTC))
+	assert.Equal(t, "rates_2024_12", p.Name)
+	assert.Equal(t, time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC), p.To)
+}
+
+func TestPartitions(t *testing.T) {
+	pool, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	logger, err := sl.New("info")
+	require.NoError(t, err)
+
+	repo := &Repository{
+		pool:   pool,
+		logger: logger,
+	}
+
+	ctx := context.Background()
+	from := time.Date(2099, 1, 15, 0, 0, 0, 0, time.UTC)
+
+	// setupTestDB creates the months around now, like migration 000008
+	current, err := repo.ListPartitions(ctx)
+	require.NoError(t, err)
+	require.NotEmpty(t, current)
+
+	// Without a default partition, rates of a month not created yet are rejected
+	_, err = pool.Exec(ctx, `INSERT INTO rates (ask, bid, timestamp) VALUES (100, 99, $1)`, from)
+	require.Error(t, err)
+
+	created, err := repo.EnsurePartitions(ctx, from, from.AddDate(0, 2, 0))
+	require.NoError(t, err)
+	require.Len(t, created, 3)
+	assert.Equal(t, "rates_2099_01", created[0].Name)
+	assert.Equal(t, "rates_2099_03", created[2].Name)
+
+	// Existing partitions are not recreated
+	created, err = repo.EnsurePartitions(ctx, from, from.AddDate(0, 3, 0))
+	require.NoError(t, err)
+	require.Len(t, created, 1)
+	assert.Equal(t, "rates_2099_04", created[0].Name)
+
+	// Rows are routed to their month
+	_, err = pool.Exec(ctx, `INSERT INTO rates (ask, bid, timestamp) VALUES (100, 99, $1)`, from)
+	require.NoError(t, err)
+	var count int
+	require.NoError(t, pool.QueryRow(ctx, `SELECT count(*) FROM rates_2099_01`).Scan(&count))
+	assert.Equal(t, 1, count)
+
+	partitions, err := repo.ListPartitions(ctx)
+	require.NoError(t, err)
+	require.Len(t, partitions, len(current)+4)
+	assert.Equal(t, "rates_2099_01", partitions[len(current)].Name)
+
+	detached, err := repo.DetachPartitionsBefore(ctx, time.Date(2099, 3, 1, 0, 0, 0, 0, time.UTC))
+	defer func() {
+		for _, p := range detached {
+			_, _ = pool.Exec(ctx, "DROP TABLE IF EXISTS "+p.Name)
+		}
+	}()
+	require.NoError(t, err)
+	require.Len(t, detached, len(current)+2)
+
+	// Detached partitions keep their rows but leave rates
+	require.NoError(t, pool.QueryRow(ctx, `SELECT count(*) FROM rates_2099_01`).Scan(&count))
+	assert.Equal(t, 1, count)
+	require.NoError(t, pool.QueryRow(ctx, `SELECT count(*) FROM rates WHERE timestamp >= $1`, from).Scan(&count))
+	assert.Equal(t, 0, count)
+
+	partitions, err = repo.ListPartitions(ctx)
+	require.NoError(t, err)
+	require.Len(t, partitions, 2)
+	assert.Equal(t, "rates_2099_03", partitions[0].Name)
+
+	// A detached month is not silently recreated or re-attached
+	created, err = repo.EnsurePartitions(ctx, from, from)
+	require.Error(t, err)
+	assert.Empty(t, created)
+	assert.Contains(t, err.Error(), "rates_2099_01 exists but is not attached")
+	assert.Contains(t, err.Error(), "ATTACH PARTITION")
+}
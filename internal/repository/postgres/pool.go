@@ -0,0 +1,153 @@
+package postgres
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	"github.com/cawa87/garantex-test/internal/lib/logger/sl"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+)
+
+// Startup retry backoff bounds
+const (
+	minStartupBackoff = 500 * time.Millisecond
+	maxStartupBackoff = 10 * time.Second
+)
+
+// PoolConfig holds connection pool settings; zero values keep the pgx defaults.
+// StartupTimeout is how long NewRepository retries an unreachable database, zero tries once
+type PoolConfig struct {
+	MaxConns          int32
+	MinConns          int32
+	MaxConnLifetime   time.Duration
+	MaxConnIdleTime   time.Duration
+	HealthCheckPeriod time.Duration
+	StatementTimeout  time.Duration
+	ConnectTimeout    time.Duration
+	StartupTimeout    time.Duration
+}
+
+// apply sets the non-zero settings on a parsed pool configuration
+func (c PoolConfig) apply(config *pgxpool.Config) {
+	if c.MaxConns > 0 {
+		config.MaxConns = c.MaxConns
+	}
+	if c.MinConns > 0 {
+		config.MinConns = c.MinConns
+	}
+	if c.MaxConnLifetime > 0 {
+		config.MaxConnLifetime = c.MaxConnLifetime
+	}
+	if c.MaxConnIdleTime > 0 {
+		config.MaxConnIdleTime = c.MaxConnIdleTime
+	}
+	if c.HealthCheckPeriod > 0 {
+		config.HealthCheckPeriod = c.HealthCheckPeriod
+	}
+	if c.ConnectTimeout > 0 {
+		config.ConnConfig.ConnectTimeout = c.ConnectTimeout
+	}
+	if c.StatementTimeout > 0 {
+		config.ConnConfig.RuntimeParams["statement_timeout"] = strconv.FormatInt(c.StatementTimeout.Milliseconds(), 10)
+	}
+}
+
+// pingWithRetry pings the database until it answers or timeout has passed, backing off exponentially between
+// attempts. Each attempt is bounded by connectTimeout when set
+func pingWithRetry(pool *pgxpool.Pool, timeout, connectTimeout time.Duration, logger *sl.Logger) error {
+	deadline := time.Now().Add(timeout)
+	backoff := minStartupBackoff
+
+	for attempt := 1; ; attempt++ {
+		err := ping(pool, connectTimeout)
+		if err == nil {
+			return nil
+		}
+
+		wait := time.Until(deadline)
+		if wait <= 0 {
+			return err
+		}
+		if backoff < wait {
+			wait = backoff
+		}
+		logger.Warn("Database unavailable, retrying", "error", err, "attempt", attempt, "retry_in", wait)
+		time.Sleep(wait)
+
+		backoff *= 2
+		if backoff > maxStartupBackoff {
+			backoff = maxStartupBackoff
+		}
+	}
+}
+
+// ping checks the connection once, bounded by timeout when set
+func ping(pool *pgxpool.Pool, timeout time.Duration) error {
+	ctx := context.Background()
+	if timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+	return pool.Ping(ctx)
+}
+
+// registerPoolMetrics exports the pool statistics on the global meter provider
+func registerPoolMetrics(pool *pgxpool.Pool) (metric.Registration, error) {
+	meter := otel.Meter("postgres")
+
+	conns, err := meter.Int64ObservableGauge("db_pool_connections",
+		metric.WithDescription("Pool connections by state"))
+	if err != nil {
+		return nil, err
+	}
+	maxConns, err := meter.Int64ObservableGauge("db_pool_max_connections",
+		metric.WithDescription("Maximum size of the pool"))
+	if err != nil {
+		return nil, err
+	}
+	acquires, err := meter.Int64ObservableCounter("db_pool_acquires",
+		metric.WithDescription("Connections acquired from the pool"))
+	if err != nil {
+		return nil, err
+	}
+	emptyAcquires, err := meter.Int64ObservableCounter("db_pool_empty_acquires",
+		metric.WithDescription("Acquires that had to wait for a connection because the pool was empty"))
+	if err != nil {
+		return nil, err
+	}
+	canceledAcquires, err := meter.Int64ObservableCounter("db_pool_canceled_acquires",
+		metric.WithDescription("Acquires cancelled by their context"))
+	if err != nil {
+		return nil, err
+	}
+	acquireDuration, err := meter.Float64ObservableCounter("db_pool_acquire_duration",
+		metric.WithUnit("s"),
+		metric.WithDescription("Total time spent acquiring connections from the pool"))
+	if err != nil {
+		return nil, err
+	}
+	created, err := meter.Int64ObservableCounter("db_pool_connections_created",
+		metric.WithDescription("Connections opened by the pool"))
+	if err != nil {
+		return nil, err
+	}
+
+	return meter.RegisterCallback(func(_ context.Context, o metric.Observer) error {
+		stat := pool.Stat()
+		o.ObserveInt64(conns, int64(stat.IdleConns()), metric.WithAttributes(attribute.String("state", "idle")))
+		o.ObserveInt64(conns, int64(stat.AcquiredConns()), metric.WithAttributes(attribute.String("state", "acquired")))
+		o.ObserveInt64(conns, int64(stat.ConstructingConns()), metric.WithAttributes(attribute.String("state", "constructing")))
+		o.ObserveInt64(maxConns, int64(stat.MaxConns()))
+		o.ObserveInt64(acquires, stat.AcquireCount())
+		o.ObserveInt64(emptyAcquires, stat.EmptyAcquireCount())
+		o.ObserveInt64(canceledAcquires, stat.CanceledAcquireCount())
+		o.ObserveFloat64(acquireDuration, stat.AcquireDuration().Seconds())
+		o.ObserveInt64(created, stat.NewConnsCount())
+		return nil
+	}, conns, maxConns, acquires, emptyAcquires, canceledAcquires, acquireDuration, created)
+}
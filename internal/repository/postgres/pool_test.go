@@ -0,0 +1,47 @@
+package postgres
+
+import (
+	"testing"
+	"time"
+
+	"github.com/cawa87/garantex-test/internal/lib/logger/sl"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPoolConfig_Apply(t *testing.T) {
+	config, err := pgxpool.ParseConfig("host=localhost dbname=test")
+	require.NoError(t, err)
+	defaults := *config
+
+	PoolConfig{
+		MaxConns:         20,
+		MaxConnLifetime:  time.Hour,
+		StatementTimeout: 1500 * time.Millisecond,
+		ConnectTimeout:   2 * time.Second,
+	}.apply(config)
+
+	assert.Equal(t, int32(20), config.MaxConns)
+	assert.Equal(t, defaults.MinConns, config.MinConns)
+	assert.Equal(t, time.Hour, config.MaxConnLifetime)
+	assert.Equal(t, defaults.MaxConnIdleTime, config.MaxConnIdleTime)
+	assert.Equal(t, 2*time.Second, config.ConnConfig.ConnectTimeout)
+	assert.Equal(t, "1500", config.ConnConfig.RuntimeParams["statement_timeout"])
+}
+
+func TestNewRepository_StartupTimeout(t *testing.T) {
+	logger, err := sl.New("error")
+	require.NoError(t, err)
+
+	// Nothing listens on port 1, so every attempt fails until the deadline
+	start := time.Now()
+	_, err = NewRepository("host=127.0.0.1 port=1 user=postgres dbname=test sslmode=disable", PoolConfig{
+		ConnectTimeout: 100 * time.Millisecond,
+		StartupTimeout: time.Second,
+	}, logger)
+	require.Error(t, err)
+	assert.ErrorContains(t, err, "failed to ping database")
+	assert.GreaterOrEqual(t, time.Since(start), time.Second)
+	assert.Less(t, time.Since(start), 3*time.Second)
+}
@@ -0,0 +1,121 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/cawa87/garantex-test/internal/service/quality"
+)
+
+var _ quality.Store = (*Repository)(nil)
+
+// ReplaceQualityIssues deletes the issues of a market starting in [from, to) and stores issues in one
+// transaction; an issue with the market, kind and start of a stored one replaces it
+func (r *Repository) ReplaceQualityIssues(ctx context.Context, market string, from, to time.Time, issues []quality.Issue) error {
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback(ctx) }()
+
+	_, err = tx.Exec(ctx, `
+		DELETE FROM data_quality_issues
+		WHERE market = $1 AND start_time >= $2 AND start_time < $3
+	`, market, from, to)
+	if err != nil {
+		return fmt.Errorf("failed to delete issues: %w", err)
+	}
+
+	for _, issue := range issues {
+		_, err = tx.Exec(ctx, `
+			INSERT INTO data_quality_issues (market, kind, start_time, end_time, samples, detail, detected_at)
+			VALUES ($1, $2, $3, $4, $5, $6, $7)
+			ON CONFLICT (market, kind, start_time) DO UPDATE SET
+				end_time = EXCLUDED.end_time,
+				samples = EXCLUDED.samples,
+				detail = EXCLUDED.detail,
+				detected_at = EXCLUDED.detected_at
+		`, issue.Market, string(issue.Kind), issue.Start, issue.End, issue.Samples, issue.Detail, issue.DetectedAt)
+		if err != nil {
+			return fmt.Errorf("failed to save %s issue: %w", issue.Kind, err)
+		}
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	return nil
+}
+
+// ListQualityIssues retrieves the issues matching the filter, oldest first
+func (r *Repository) ListQualityIssues(ctx context.Context, filter quality.ListFilter) ([]quality.Issue, error) {
+	var (
+		conditions []string
+		args       []interface{}
+	)
+	add := func(condition string, arg interface{}) {
+		args = append(args, arg)
+		conditions = append(conditions, fmt.Sprintf(condition, len(args)))
+	}
+
+	if filter.Market != "" {
+		add("market = $%d", filter.Market)
+	}
+	if filter.Kind != "" {
+		add("kind = $%d", string(filter.Kind))
+	}
+	if !filter.From.IsZero() {
+		add("end_time >= $%d", filter.From)
+	}
+	if !filter.To.IsZero() {
+		add("start_time < $%d", filter.To)
+	}
+
+	query := `
+		SELECT id, market, kind, start_time, end_time, samples, detail, detected_at
+		FROM data_quality_issues`
+	if len(conditions) > 0 {
+		query += ` WHERE ` + strings.Join(conditions, " AND ")
+	}
+	query += ` ORDER BY start_time, kind`
+	if filter.Limit > 0 {
+		args = append(args, filter.Limit)
+		query += fmt.Sprintf(` LIMIT $%d`, len(args))
+	}
+
+	rows, err := r.reader(ctx, "ListQualityIssues").Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query issues: %w", err)
+	}
+	defer rows.Close()
+
+	var issues []quality.Issue
+	for rows.Next() {
+		var (
+			issue quality.Issue
+			kind  string
+		)
+		if err := rows.Scan(
+			&issue.ID,
+			&issue.Market,
+			&kind,
+			&issue.Start,
+			&issue.End,
+			&issue.Samples,
+			&issue.Detail,
+			&issue.DetectedAt,
+		); err != nil {
+			return nil, fmt.Errorf("failed to scan issue: %w", err)
+		}
+		issue.Kind = quality.Kind(kind)
+		issues = append(issues, issue)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating over rows: %w", err)
+	}
+
+	return issues, nil
+}
@@ -0,0 +1,77 @@
+package postgres
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/cawa87/garantex-test/internal/lib/logger/sl"
+	"github.com/cawa87/garantex-test/internal/service/quality"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestQualityIssues(t *testing.T) {
+	pool, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	logger, err := sl.New("info")
+	require.NoError(t, err)
+
+	repo := &Repository{
+		pool:   pool,
+		logger: logger,
+	}
+	ctx := context.Background()
+
+	now := time.Date(2024, 3, 1, 13, 0, 0, 0, time.UTC)
+	from := now.Add(-time.Hour)
+	gap := quality.Issue{
+		Market:     "btcusdt",
+		Kind:       quality.KindGap,
+		Start:      now.Add(-10 * time.Minute),
+		End:        now,
+		Samples:    1,
+		Detail:     "no samples for 10m0s (ongoing)",
+		DetectedAt: now,
+	}
+	crossed := quality.Issue{
+		Market:     "btcusdt",
+		Kind:       quality.KindCrossed,
+		Start:      now.Add(-30 * time.Minute),
+		End:        now.Add(-29 * time.Minute),
+		Samples:    6,
+		DetectedAt: now,
+	}
+	require.NoError(t, repo.ReplaceQualityIssues(ctx, "btcusdt", from, now, []quality.Issue{gap, crossed}))
+
+	// The next scan closes the gap and no longer sees the crossed quotes
+	later := now.Add(5 * time.Minute)
+	gap.End = now.Add(2 * time.Minute)
+	gap.Samples = 2
+	gap.Detail = "no samples for 12m0s"
+	gap.DetectedAt = later
+	require.NoError(t, repo.ReplaceQualityIssues(ctx, "btcusdt", later.Add(-time.Hour), later, []quality.Issue{gap}))
+
+	issues, err := repo.ListQualityIssues(ctx, quality.ListFilter{Market: "btcusdt"})
+	require.NoError(t, err)
+	require.Len(t, issues, 1)
+	assert.Equal(t, quality.KindGap, issues[0].Kind)
+	assert.True(t, gap.End.Equal(issues[0].End))
+	assert.Equal(t, 2, issues[0].Samples)
+	assert.Equal(t, gap.Detail, issues[0].Detail)
+
+	// Issues starting before the scanned window are kept
+	require.NoError(t, repo.ReplaceQualityIssues(ctx, "btcusdt", gap.End, later, nil))
+	issues, err = repo.ListQualityIssues(ctx, quality.ListFilter{Market: "btcusdt", Kind: quality.KindGap, From: now, To: later})
+	require.NoError(t, err)
+	assert.Len(t, issues, 1)
+
+	none, err := repo.ListQualityIssues(ctx, quality.ListFilter{Market: "btcusdt", From: later})
+	require.NoError(t, err)
+	assert.Empty(t, none)
+
+	none, err = repo.ListQualityIssues(ctx, quality.ListFilter{Market: "ethusdt"})
+	require.NoError(t, err)
+	assert.Empty(t, none)
+}
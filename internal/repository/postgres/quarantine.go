@@ -0,0 +1,34 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/cawa87/garantex-test/internal/service/guard"
+)
+
+var _ guard.Store = (*Repository)(nil)
+
+// QuarantineRate stores a tick rejected by the price guard and sets its ID and creation time
+func (r *Repository) QuarantineRate(ctx context.Context, rate *guard.QuarantinedRate) error {
+	query := `
+		INSERT INTO quarantined_rates (market, source, ask, bid, timestamp, received_at, reason, detail, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+		RETURNING id, created_at
+	`
+
+	var receivedAt *time.Time
+	if !rate.ReceivedAt.IsZero() {
+		receivedAt = &rate.ReceivedAt
+	}
+
+	err := r.pool.QueryRow(ctx, query, rate.Market, rate.Source, rate.Ask, rate.Bid, rate.Timestamp, receivedAt,
+		string(rate.Reason), rate.Detail, time.Now()).Scan(&rate.ID, &rate.CreatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to quarantine rate: %w", err)
+	}
+
+	r.logger.Debug("Rate quarantined", "id", rate.ID, "market", rate.Market, "reason", rate.Reason)
+	return nil
+}
@@ -0,0 +1,58 @@
+package postgres
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/cawa87/garantex-test/internal/lib/logger/sl"
+	"github.com/cawa87/garantex-test/internal/service/guard"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestQuarantineRate(t *testing.T) {
+	pool, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	logger, err := sl.New("info")
+	require.NoError(t, err)
+
+	repo := &Repository{
+		pool:   pool,
+		logger: logger,
+	}
+	ctx := context.Background()
+
+	rate := &guard.QuarantinedRate{
+		Market:    "btcusdt",
+		Source:    "garantex",
+		Ask:       decimal.RequireFromString("84500.5"),
+		Bid:       decimal.RequireFromString("84500.5"),
+		Timestamp: time.Now().UTC().Truncate(time.Microsecond),
+		Reason:    guard.ReasonDeviation,
+		Detail:    "mid price 84500.5 is 30.00% from the median 65000",
+	}
+	require.NoError(t, repo.QuarantineRate(ctx, rate))
+	assert.NotZero(t, rate.ID)
+	assert.False(t, rate.CreatedAt.IsZero())
+
+	var (
+		reason, detail string
+		bid            decimal.Decimal
+		receivedAt     *time.Time
+	)
+	err = pool.QueryRow(ctx, `SELECT reason, detail, bid, received_at FROM quarantined_rates WHERE id = $1`, rate.ID).
+		Scan(&reason, &detail, &bid, &receivedAt)
+	require.NoError(t, err)
+	assert.Equal(t, string(guard.ReasonDeviation), reason)
+	assert.Equal(t, rate.Detail, detail)
+	assert.True(t, rate.Bid.Equal(bid))
+	assert.Nil(t, receivedAt)
+
+	// Quarantined ticks are not rates
+	count, err := repo.GetRatesCount(ctx)
+	require.NoError(t, err)
+	assert.Zero(t, count)
+}
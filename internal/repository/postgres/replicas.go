@@ -0,0 +1,265 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/cawa87/garantex-test/internal/lib/logger/sl"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+)
+
+// Query routes reported by the db_queries_routed metric
+const (
+	routePrimary  = "primary"
+	routeReplica  = "replica"
+	routeFallback = "primary_fallback"
+)
+
+// lagCheckTimeout bounds a single replication lag query
+const lagCheckTimeout = time.Second
+
+// defaultLagCheckInterval is used when WithReplicas sets no positive check interval
+const defaultLagCheckInterval = 5 * time.Second
+
+// replicationLagQuery returns the replay lag of a standby in seconds; it is 0 when the standby has
+// replayed everything it received and on a primary
+const replicationLagQuery = `
+	SELECT CASE
+		WHEN pg_last_wal_receive_lsn() = pg_last_wal_replay_lsn() THEN 0
+		ELSE COALESCE(EXTRACT(EPOCH FROM now() - pg_last_xact_replay_timestamp()), 0)
+	END
+`
+
+// querier is the read interface shared by the primary and replica pools
+type querier interface {
+	Query(ctx context.Context, sql string, args ...any) (pgx.Rows, error)
+	QueryRow(ctx context.Context, sql string, args ...any) pgx.Row
+	BeginTx(ctx context.Context, txOptions pgx.TxOptions) (pgx.Tx, error)
+}
+
+// Option configures a Repository
+type Option func(*options)
+
+// options holds the optional Repository settings
+type options struct {
+	replicaDSNs      []string
+	maxLag           time.Duration
+	lagCheckInterval time.Duration
+}
+
+// WithReplicas routes read-only history and analytics queries (rates, candles, ticks, order books and
+// fixings) to read replicas; writes, API key lookups, maintenance and queries through Primary stay on the
+// primary. A replica whose replication lag exceeds maxLag is skipped; lag is measured in the background
+// every checkInterval
+func WithReplicas(dsns []string, maxLag, checkInterval time.Duration) Option {
+	return func(o *options) {
+		o.replicaDSNs = dsns
+		o.maxLag = maxLag
+		o.lagCheckInterval = checkInterval
+	}
+}
+
+// replica is a read replica pool with its last measured replication lag
+type replica struct {
+	name string
+	pool *pgxpool.Pool
+
+	mu        sync.Mutex
+	lag       time.Duration
+	lagErr    error
+	checkedAt time.Time
+}
+
+// router picks the pool a read-only query runs on
+type router struct {
+	primary       *pgxpool.Pool
+	replicas      []*replica
+	maxLag        time.Duration
+	checkInterval time.Duration
+	logger        *sl.Logger
+
+	next   atomic.Uint64
+	routed metric.Int64Counter
+
+	stop context.CancelFunc
+	wg   sync.WaitGroup
+}
+
+// newRouter creates a router over the primary and replica pools. Replicas take reads once start has
+// measured their lag
+func newRouter(primary *pgxpool.Pool, replicas []*replica, maxLag, checkInterval time.Duration, logger *sl.Logger) *router {
+	rt := &router{
+		primary:       primary,
+		replicas:      replicas,
+		maxLag:        maxLag,
+		checkInterval: checkInterval,
+		logger:        logger,
+	}
+	rt.routed, _ = otel.Meter("postgres").Int64Counter("db_queries_routed",
+		metric.WithDescription("Read queries by the pool they were routed to; primary_fallback means every replica was lagging or down"))
+	if rt.checkInterval <= 0 {
+		rt.checkInterval = defaultLagCheckInterval
+	}
+	return rt
+}
+
+// start measures the lag of every replica, then keeps refreshing it every checkInterval in the background
+// until close. Checks run on their own context, so queries never wait for them and a cancelled request
+// cannot fail one
+func (rt *router) start() {
+	ctx, cancel := context.WithCancel(context.Background())
+	rt.stop = cancel
+
+	var initial sync.WaitGroup
+	for _, rep := range rt.replicas {
+		initial.Add(1)
+		rt.wg.Add(1)
+		go func(rep *replica) {
+			defer rt.wg.Done()
+			rt.check(ctx, rep)
+			initial.Done()
+			rt.watch(ctx, rep)
+		}(rep)
+	}
+	initial.Wait()
+}
+
+// close stops the lag checks
+func (rt *router) close() {
+	if rt.stop != nil {
+		rt.stop()
+	}
+	rt.wg.Wait()
+}
+
+// watch refreshes the lag of a replica every checkInterval until ctx is done
+func (rt *router) watch(ctx context.Context, rep *replica) {
+	ticker := time.NewTicker(rt.checkInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			rt.check(ctx, rep)
+		}
+	}
+}
+
+// reader returns the pool for a read-only query. Replicas are tried round-robin; when none is within
+// maxLag the query falls back to the primary
+func (rt *router) reader(ctx context.Context, method string) querier {
+	route := routePrimary
+	var pool querier = rt.primary
+
+	if n := len(rt.replicas); n > 0 {
+		route = routeFallback
+		start := rt.next.Add(1)
+		for i := 0; i < n; i++ {
+			rep := rt.replicas[(start+uint64(i))%uint64(n)]
+			if rt.healthy(rep) {
+				route = routeReplica
+				pool = rep.pool
+				break
+			}
+		}
+	}
+
+	rt.routed.Add(ctx, 1, metric.WithAttributes(
+		attribute.String("route", route),
+		attribute.String("method", method),
+	))
+	return pool
+}
+
+// healthy reports whether a replica answered its last lag check within maxLag; a replica not checked yet
+// is not
+func (rt *router) healthy(rep *replica) bool {
+	rep.mu.Lock()
+	defer rep.mu.Unlock()
+
+	return rt.withinLag(rep)
+}
+
+// withinLag reports whether the last lag check of rep succeeded within maxLag; rep.mu must be held
+func (rt *router) withinLag(rep *replica) bool {
+	return !rep.checkedAt.IsZero() && rep.lagErr == nil && rep.lag <= rt.maxLag
+}
+
+// check measures the lag of a replica and logs when it leaves or rejoins the rotation. The query runs
+// without holding rep.mu
+func (rt *router) check(ctx context.Context, rep *replica) {
+	lag, lagErr := replicationLag(ctx, rep.pool)
+	if ctx.Err() != nil {
+		// Closing the repository, not a replica failure
+		return
+	}
+
+	rep.mu.Lock()
+	defer rep.mu.Unlock()
+
+	wasHealthy := rt.withinLag(rep)
+	rep.lag, rep.lagErr = lag, lagErr
+	rep.checkedAt = time.Now()
+
+	isHealthy := rt.withinLag(rep)
+	if wasHealthy && !isHealthy {
+		rt.logger.Warn("Replica skipped, reads fall back to other pools",
+			"replica", rep.name, "lag", rep.lag, "max_lag", rt.maxLag, "error", rep.lagErr)
+	} else if !wasHealthy && isHealthy {
+		rt.logger.Info("Replica back in rotation", "replica", rep.name, "lag", rep.lag)
+	}
+}
+
+// replicationLag measures the replay lag of a replica
+func replicationLag(ctx context.Context, pool *pgxpool.Pool) (time.Duration, error) {
+	ctx, cancel := context.WithTimeout(ctx, lagCheckTimeout)
+	defer cancel()
+
+	var seconds float64
+	if err := pool.QueryRow(ctx, replicationLagQuery).Scan(&seconds); err != nil {
+		return 0, fmt.Errorf("failed to check replication lag: %w", err)
+	}
+	return time.Duration(seconds * float64(time.Second)), nil
+}
+
+// openReplicas creates a pool per replica DSN with the primary's pool settings. Replicas are not pinged;
+// one that is down is skipped after its first lag check
+func openReplicas(dsns []string, poolConfig PoolConfig) ([]*replica, error) {
+	replicas := make([]*replica, 0, len(dsns))
+	for i, dsn := range dsns {
+		config, err := pgxpool.ParseConfig(dsn)
+		if err != nil {
+			closeReplicas(replicas)
+			return nil, fmt.Errorf("failed to parse replica %d DSN: %w", i, err)
+		}
+		config.AfterConnect = afterConnect
+		poolConfig.apply(config)
+
+		pool, err := pgxpool.NewWithConfig(context.Background(), config)
+		if err != nil {
+			closeReplicas(replicas)
+			return nil, fmt.Errorf("failed to create replica %d connection pool: %w", i, err)
+		}
+		replicas = append(replicas, &replica{
+			name: fmt.Sprintf("%s:%d", config.ConnConfig.Host, config.ConnConfig.Port),
+			pool: pool,
+		})
+	}
+	return replicas, nil
+}
+
+// closeReplicas closes the replica pools
+func closeReplicas(replicas []*replica) {
+	for _, rep := range replicas {
+		rep.pool.Close()
+	}
+}
@@ -0,0 +1,121 @@
+package postgres
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/cawa87/garantex-test/internal/lib/logger/sl"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// unreachableDSN points at a port nothing listens on
+const unreachableDSN = "host=127.0.0.1 port=1 user=postgres dbname=test sslmode=disable connect_timeout=1"
+
+func TestRouter_NoReplicas(t *testing.T) {
+	logger, err := sl.New("info")
+	require.NoError(t, err)
+
+	// Pools connect lazily, so the primary is never dialled
+	primary, err := pgxpool.New(context.Background(), unreachableDSN)
+	require.NoError(t, err)
+	defer primary.Close()
+
+	rt := newRouter(primary, nil, time.Second, time.Second, logger)
+	assert.Equal(t, primary, rt.reader(context.Background(), "GetRatesCount"))
+}
+
+func TestRouter_FallsBackWhenReplicaDown(t *testing.T) {
+	logger, err := sl.New("error")
+	require.NoError(t, err)
+
+	primary, err := pgxpool.New(context.Background(), unreachableDSN)
+	require.NoError(t, err)
+	defer primary.Close()
+
+	replicas, err := openReplicas([]string{unreachableDSN}, PoolConfig{})
+	require.NoError(t, err)
+	defer closeReplicas(replicas)
+
+	rt := newRouter(primary, replicas, time.Second, time.Hour, logger)
+
+	// Until its first check a replica takes no reads
+	assert.Equal(t, primary, rt.reader(context.Background(), "GetCandles"))
+	assert.True(t, replicas[0].checkedAt.IsZero())
+
+	rt.start()
+	defer rt.close()
+	assert.Equal(t, primary, rt.reader(context.Background(), "GetCandles"))
+	assert.Error(t, replicas[0].lagErr)
+}
+
+func TestRouter_CancelledRequestKeepsReplica(t *testing.T) {
+	logger, err := sl.New("info")
+	require.NoError(t, err)
+
+	primary, err := pgxpool.New(context.Background(), unreachableDSN)
+	require.NoError(t, err)
+	defer primary.Close()
+
+	replicas, err := openReplicas([]string{unreachableDSN}, PoolConfig{})
+	require.NoError(t, err)
+	defer closeReplicas(replicas)
+
+	rt := newRouter(primary, replicas, time.Second, time.Hour, logger)
+	replicas[0].checkedAt = time.Now()
+
+	// Routing only reads the cached lag, so a cancelled request neither queries nor fails the replica
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	assert.Equal(t, replicas[0].pool, rt.reader(ctx, "GetCandles"))
+	assert.NoError(t, replicas[0].lagErr)
+}
+
+func TestRouter_ReplicaWithinLag(t *testing.T) {
+	pool, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	logger, err := sl.New("info")
+	require.NoError(t, err)
+
+	// A primary reports no replication lag, so it stands in for an up-to-date replica
+	replicas, err := openReplicas([]string{pool.Config().ConnString()}, PoolConfig{})
+	require.NoError(t, err)
+	defer closeReplicas(replicas)
+
+	rt := newRouter(pool, replicas, time.Second, time.Hour, logger)
+	rt.start()
+	defer rt.close()
+	assert.Equal(t, replicas[0].pool, rt.reader(context.Background(), "GetRatesByTimeRange"))
+	require.NoError(t, replicas[0].lagErr)
+	assert.Zero(t, replicas[0].lag)
+
+	// A lag above the threshold sends reads back to the primary
+	replicas[0].mu.Lock()
+	replicas[0].lag = 2 * time.Second
+	replicas[0].mu.Unlock()
+	assert.Equal(t, pool, rt.reader(context.Background(), "GetRatesByTimeRange"))
+}
+
+func TestRepository_Primary(t *testing.T) {
+	logger, err := sl.New("info")
+	require.NoError(t, err)
+
+	primary, err := pgxpool.New(context.Background(), unreachableDSN)
+	require.NoError(t, err)
+	defer primary.Close()
+
+	replicas, err := openReplicas([]string{unreachableDSN}, PoolConfig{})
+	require.NoError(t, err)
+	defer closeReplicas(replicas)
+
+	rt := newRouter(primary, replicas, time.Second, time.Hour, logger)
+	replicas[0].checkedAt = time.Now()
+	repo := &Repository{pool: primary, router: rt, logger: logger}
+
+	assert.Equal(t, replicas[0].pool, repo.reader(context.Background(), "GetTicks"))
+	assert.Equal(t, primary, repo.Primary().reader(context.Background(), "GetTicks"))
+	assert.Equal(t, rt, repo.router, "the view leaves the repository routed")
+}
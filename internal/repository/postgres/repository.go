@@ -7,32 +7,53 @@ import (
 	"time"
 
 	"github.com/cawa87/garantex-test/internal/lib/logger/sl"
+	"github.com/cawa87/garantex-test/internal/repository"
 	"github.com/cawa87/garantex-test/internal/service/exchange"
+	pgxdecimal "github.com/jackc/pgx-shopspring-decimal"
 	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgxpool"
+	"go.opentelemetry.io/otel/metric"
 )
 
 // Repository represents the PostgreSQL repository for rate data operations
 type Repository struct {
-	pool   *pgxpool.Pool
-	logger *sl.Logger
+	pool    *pgxpool.Pool
+	router  *router
+	logger  *sl.Logger
+	metrics metric.Registration
 }
 
-// Rate represents a rate record in the database
-type Rate struct {
-	ID        int64     `db:"id"`
-	Ask       float64   `db:"ask"`
-	Bid       float64   `db:"bid"`
-	Timestamp time.Time `db:"timestamp"`
-	CreatedAt time.Time `db:"created_at"`
-}
+var _ repository.Store = (*Repository)(nil)
+
+// rateColumns is the select list matching scanRate
+const rateColumns = "id, market, source, ask, bid, timestamp, received_at, latency_us, created_at"
+
+// candlesQuery aggregates the rates of market $1 in [$2, $3) into candles of $4 seconds, ascending
+const candlesQuery = `
+	SELECT to_timestamp(floor(extract(epoch FROM timestamp) / $4) * $4) AS bucket,
+		(array_agg(ask ORDER BY timestamp, id))[1], max(ask), min(ask), (array_agg(ask ORDER BY timestamp DESC, id DESC))[1],
+		(array_agg(bid ORDER BY timestamp, id))[1], max(bid), min(bid), (array_agg(bid ORDER BY timestamp DESC, id DESC))[1],
+		count(*)
+	FROM rates
+	WHERE market = $1 AND timestamp >= $2 AND timestamp < $3
+	GROUP BY bucket
+	ORDER BY bucket
+`
+
+// NewRepository creates a new PostgreSQL repository with connection pool.
+// It retries an unreachable database until poolConfig.StartupTimeout has passed
+func NewRepository(dsn string, poolConfig PoolConfig, logger *sl.Logger, opts ...Option) (*Repository, error) {
+	var o options
+	for _, opt := range opts {
+		opt(&o)
+	}
 
-// NewRepository creates a new PostgreSQL repository with connection pool
-func NewRepository(dsn string, logger *sl.Logger) (*Repository, error) {
 	config, err := pgxpool.ParseConfig(dsn)
 	if err != nil {
 		return nil, fmt.Errorf("failed to parse DSN: %w", err)
 	}
+	config.AfterConnect = afterConnect
+	poolConfig.apply(config)
 
 	pool, err := pgxpool.NewWithConfig(context.Background(), config)
 	if err != nil {
@@ -40,61 +61,184 @@ func NewRepository(dsn string, logger *sl.Logger) (*Repository, error) {
 	}
 
 	// Test the connection
-	if err := pool.Ping(context.Background()); err != nil {
+	if err := pingWithRetry(pool, poolConfig.StartupTimeout, poolConfig.ConnectTimeout, logger); err != nil {
+		pool.Close()
 		return nil, fmt.Errorf("failed to ping database: %w", err)
 	}
 
+	replicas, err := openReplicas(o.replicaDSNs, poolConfig)
+	if err != nil {
+		pool.Close()
+		return nil, err
+	}
+
+	metrics, err := registerPoolMetrics(pool)
+	if err != nil {
+		logger.Warn("Failed to register pool metrics", "error", err)
+	}
+
+	router := newRouter(pool, replicas, o.maxLag, o.lagCheckInterval, logger)
+	router.start()
+
 	return &Repository{
-		pool:   pool,
-		logger: logger,
+		pool:    pool,
+		router:  router,
+		logger:  logger,
+		metrics: metrics,
 	}, nil
 }
 
+// reader returns the pool a read-only query runs on; see WithReplicas
+func (r *Repository) reader(ctx context.Context, method string) querier {
+	if r.router == nil {
+		return r.pool
+	}
+	return r.router.reader(ctx, method)
+}
+
+// Primary returns a view of the repository that runs every query on the primary, for jobs whose results
+// must not depend on replication lag. It shares the pools of r and must not be closed
+func (r *Repository) Primary() *Repository {
+	primary := *r
+	primary.router = nil
+	return &primary
+}
+
+// afterConnect registers exact decimal scanning for NUMERIC columns on each new connection
+func afterConnect(_ context.Context, conn *pgx.Conn) error {
+	pgxdecimal.Register(conn.TypeMap())
+	return nil
+}
+
 // Close closes the database connection pool
 func (r *Repository) Close() {
+	if r.metrics != nil {
+		_ = r.metrics.Unregister()
+	}
+	if r.router != nil {
+		r.router.close()
+		closeReplicas(r.router.replicas)
+	}
 	if r.pool != nil {
 		r.pool.Close()
 	}
 }
 
-// SaveRate saves a rate to the database
-func (r *Repository) SaveRate(ctx context.Context, rate *exchange.Rate) error {
-	query := `
-		INSERT INTO rates (ask, bid, timestamp, created_at)
-		VALUES ($1, $2, $3, $4)
-	`
+// SaveRate saves a rate to the database and reports whether it was new;
+// a sample already stored for the same market, source and timestamp is skipped.
+// New rates are announced on RatesChannel, as are those of SaveRates and SaveRateWithOrderBook
+func (r *Repository) SaveRate(ctx context.Context, rate *exchange.Rate) (bool, error) {
+	query := notifyInserted(`
+		INSERT INTO rates (market, source, ask, bid, timestamp, received_at, latency_us, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+		ON CONFLICT DO NOTHING
+	`)
 
-	_, err := r.pool.Exec(ctx, query, rate.Ask, rate.Bid, rate.Timestamp, time.Now())
+	tag, err := r.pool.Exec(ctx, query, rate.Market, rateSource(rate), rate.Ask, rate.Bid, rate.Timestamp, rate.ReceivedAt, rate.Latency.Microseconds(), time.Now())
 	if err != nil {
-		return fmt.Errorf("failed to save rate: %w", err)
+		return false, fmt.Errorf("failed to save rate: %w", err)
 	}
 
+	created := tag.RowsAffected() > 0
 	r.logger.Debug("Rate saved to database",
+		"market", rate.Market,
 		"ask", rate.Ask,
 		"bid", rate.Bid,
-		"timestamp", rate.Timestamp)
+		"timestamp", rate.Timestamp,
+		"received_at", rate.ReceivedAt,
+		"new", created)
 
-	return nil
+	return created, nil
+}
+
+// SaveRates saves rates in one round trip and returns the number of new rows. Rates are copied into
+// a temporary table and inserted from there, so samples that are already stored are skipped
+func (r *Repository) SaveRates(ctx context.Context, rates []*exchange.Rate) (int64, error) {
+	return r.saveRates(ctx, rates, true)
+}
+
+// saveRates implements SaveRates, announcing the new rows on RatesChannel if notify is set
+func (r *Repository) saveRates(ctx context.Context, rates []*exchange.Rate, notify bool) (int64, error) {
+	if len(rates) == 0 {
+		return 0, nil
+	}
+
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback(ctx) }()
+
+	_, err = tx.Exec(ctx, `
+		CREATE TEMPORARY TABLE rates_ingest (
+			market TEXT,
+			source TEXT,
+			ask DECIMAL(20, 8),
+			bid DECIMAL(20, 8),
+			timestamp TIMESTAMP WITH TIME ZONE,
+			received_at TIMESTAMP WITH TIME ZONE,
+			latency_us BIGINT,
+			created_at TIMESTAMP WITH TIME ZONE
+		) ON COMMIT DROP
+	`)
+	if err != nil {
+		return 0, fmt.Errorf("failed to create ingest table: %w", err)
+	}
+
+	now := time.Now()
+	_, err = tx.CopyFrom(ctx,
+		pgx.Identifier{"rates_ingest"},
+		[]string{"market", "source", "ask", "bid", "timestamp", "received_at", "latency_us", "created_at"},
+		pgx.CopyFromSlice(len(rates), func(i int) ([]any, error) {
+			rate := rates[i]
+			return []any{rate.Market, rateSource(rate), rate.Ask, rate.Bid, rate.Timestamp, rate.ReceivedAt, rate.Latency.Microseconds(), now}, nil
+		}),
+	)
+	if err != nil {
+		return 0, fmt.Errorf("failed to copy %d rates: %w", len(rates), err)
+	}
+
+	query := `
+		INSERT INTO rates (market, source, ask, bid, timestamp, received_at, latency_us, created_at)
+		SELECT market, source, ask, bid, timestamp, received_at, latency_us, created_at
+		FROM rates_ingest
+		ON CONFLICT DO NOTHING
+	`
+	if notify {
+		query = notifyInserted(query)
+	}
+	tag, err := tx.Exec(ctx, query)
+	if err != nil {
+		return 0, fmt.Errorf("failed to save %d rates: %w", len(rates), err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return 0, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	r.logger.Debug("Rates saved to database", "count", len(rates), "new", tag.RowsAffected())
+
+	return tag.RowsAffected(), nil
+}
+
+// rateSource returns the source of a rate, defaulting to the exchange the service was built for
+func rateSource(rate *exchange.Rate) string {
+	if rate.Source == "" {
+		return exchange.DefaultSource
+	}
+	return rate.Source
 }
 
 // GetLatestRate retrieves the most recent rate from the database
-func (r *Repository) GetLatestRate(ctx context.Context) (*Rate, error) {
+func (r *Repository) GetLatestRate(ctx context.Context) (*repository.Rate, error) {
 	query := `
-		SELECT id, ask, bid, timestamp, created_at
+		SELECT ` + rateColumns + `
 		FROM rates
 		ORDER BY timestamp DESC
 		LIMIT 1
 	`
 
-	var rate Rate
-	err := r.pool.QueryRow(ctx, query).Scan(
-		&rate.ID,
-		&rate.Ask,
-		&rate.Bid,
-		&rate.Timestamp,
-		&rate.CreatedAt,
-	)
-
+	rate, err := scanRate(r.reader(ctx, "GetLatestRate").QueryRow(ctx, query))
 	if err != nil {
 		if err == pgx.ErrNoRows {
 			return nil, sql.ErrNoRows
@@ -102,38 +246,31 @@ func (r *Repository) GetLatestRate(ctx context.Context) (*Rate, error) {
 		return nil, fmt.Errorf("failed to get latest rate: %w", err)
 	}
 
-	return &rate, nil
+	return rate, nil
 }
 
 // GetRatesByTimeRange retrieves rates within a time range
-func (r *Repository) GetRatesByTimeRange(ctx context.Context, from, to time.Time) ([]*Rate, error) {
+func (r *Repository) GetRatesByTimeRange(ctx context.Context, from, to time.Time) ([]*repository.Rate, error) {
 	query := `
-		SELECT id, ask, bid, timestamp, created_at
+		SELECT ` + rateColumns + `
 		FROM rates
 		WHERE timestamp BETWEEN $1 AND $2
 		ORDER BY timestamp DESC
 	`
 
-	rows, err := r.pool.Query(ctx, query, from, to)
+	rows, err := r.reader(ctx, "GetRatesByTimeRange").Query(ctx, query, from, to)
 	if err != nil {
 		return nil, fmt.Errorf("failed to query rates: %w", err)
 	}
 	defer rows.Close()
 
-	var rates []*Rate
+	var rates []*repository.Rate
 	for rows.Next() {
-		var rate Rate
-		err := rows.Scan(
-			&rate.ID,
-			&rate.Ask,
-			&rate.Bid,
-			&rate.Timestamp,
-			&rate.CreatedAt,
-		)
+		rate, err := scanRate(rows)
 		if err != nil {
 			return nil, fmt.Errorf("failed to scan rate: %w", err)
 		}
-		rates = append(rates, &rate)
+		rates = append(rates, rate)
 	}
 
 	if err := rows.Err(); err != nil {
@@ -143,12 +280,116 @@ func (r *Repository) GetRatesByTimeRange(ctx context.Context, from, to time.Time
 	return rates, nil
 }
 
+// GetRatesAround retrieves the latest rate of a market at or before at and the earliest rate after it.
+// Either may be nil; sql.ErrNoRows is returned when the market has no rates at all
+func (r *Repository) GetRatesAround(ctx context.Context, market string, at time.Time) (before, after *repository.Rate, err error) {
+	query := `
+		(SELECT ` + rateColumns + `
+		FROM rates
+		WHERE market = $1 AND timestamp <= $2
+		ORDER BY timestamp DESC
+		LIMIT 1)
+		UNION ALL
+		(SELECT ` + rateColumns + `
+		FROM rates
+		WHERE market = $1 AND timestamp > $2
+		ORDER BY timestamp ASC
+		LIMIT 1)
+	`
+
+	rows, err := r.reader(ctx, "GetRatesAround").Query(ctx, query, market, at)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to query rates around %s: %w", at, err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		rate, err := scanRate(rows)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to scan rate: %w", err)
+		}
+		if rate.Timestamp.After(at) {
+			after = rate
+		} else {
+			before = rate
+		}
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, nil, fmt.Errorf("error iterating over rows: %w", err)
+	}
+
+	if before == nil && after == nil {
+		return nil, nil, sql.ErrNoRows
+	}
+
+	return before, after, nil
+}
+
+// GetCandles aggregates the rates of a market in [from, to) into candles of interval, ascending
+func (r *Repository) GetCandles(ctx context.Context, market string, interval time.Duration, from, to time.Time) ([]*repository.Candle, error) {
+	if err := repository.CheckCandleInterval(interval); err != nil {
+		return nil, err
+	}
+
+	rows, err := r.reader(ctx, "GetCandles").Query(ctx, candlesQuery, market, from, to, int64(interval/time.Second))
+	if err != nil {
+		return nil, fmt.Errorf("failed to query candles: %w", err)
+	}
+	defer rows.Close()
+
+	var candles []*repository.Candle
+	for rows.Next() {
+		c := repository.Candle{Market: market}
+		err := rows.Scan(&c.Start,
+			&c.AskOpen, &c.AskHigh, &c.AskLow, &c.AskClose,
+			&c.BidOpen, &c.BidHigh, &c.BidLow, &c.BidClose,
+			&c.Samples)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan candle: %w", err)
+		}
+		c.Start = c.Start.UTC()
+		candles = append(candles, &c)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating over rows: %w", err)
+	}
+
+	return candles, nil
+}
+
+// scanRate scans a row selected with rateColumns
+func scanRate(row pgx.Row) (*repository.Rate, error) {
+	var (
+		rate      repository.Rate
+		latencyUS int64
+	)
+	err := row.Scan(
+		&rate.ID,
+		&rate.Market,
+		&rate.Source,
+		&rate.Ask,
+		&rate.Bid,
+		&rate.Timestamp,
+		&rate.ReceivedAt,
+		&latencyUS,
+		&rate.CreatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	rate.Latency = time.Duration(latencyUS) * time.Microsecond
+	return &rate, nil
+}
+
 // GetRatesCount returns the total number of rates in the database
 func (r *Repository) GetRatesCount(ctx context.Context) (int64, error) {
 	query := `SELECT COUNT(*) FROM rates`
 
 	var count int64
-	err := r.pool.QueryRow(ctx, query).Scan(&count)
+	err := r.reader(ctx, "GetRatesCount").QueryRow(ctx, query).Scan(&count)
 	if err != nil {
 		return 0, fmt.Errorf("failed to get rates count: %w", err)
 	}
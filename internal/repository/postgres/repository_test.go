@@ -7,8 +7,11 @@ import (
 	"time"
 
 	"github.com/cawa87/garantex-test/internal/lib/logger/sl"
+	"github.com/cawa87/garantex-test/internal/repository"
+	"github.com/cawa87/garantex-test/internal/repository/storetest"
 	"github.com/cawa87/garantex-test/internal/service/exchange"
 	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/shopspring/decimal"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -19,6 +22,7 @@ func setupTestDB(t *testing.T) (*pgxpool.Pool, func()) {
 
 	config, err := pgxpool.ParseConfig(dsn)
 	require.NoError(t, err)
+	config.AfterConnect = afterConnect
 
 	pool, err := pgxpool.NewWithConfig(context.Background(), config)
 	require.NoError(t, err)
@@ -26,23 +30,191 @@ func setupTestDB(t *testing.T) (*pgxpool.Pool, func()) {
 	// Create test table
 	_, err = pool.Exec(context.Background(), `
 		CREATE TABLE IF NOT EXISTS rates (
+			id BIGSERIAL,
+			market TEXT NOT NULL DEFAULT 'btcusdt',
+			source TEXT NOT NULL DEFAULT 'garantex',
+			ask DECIMAL(20, 8) NOT NULL,
+			bid DECIMAL(20, 8) NOT NULL,
+			timestamp TIMESTAMP WITH TIME ZONE NOT NULL,
+			received_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+			latency_us BIGINT NOT NULL DEFAULT 0,
+			source_file TEXT,
+			created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+			PRIMARY KEY (id, timestamp)
+		) PARTITION BY RANGE (timestamp)
+	`)
+	require.NoError(t, err)
+
+	// Like migration 000008 on an empty database: no default partition, the months around now are created
+	// and tests writing other months create theirs with ensureTestPartitions
+	now := time.Now()
+	ensureTestPartitions(t, pool, now.AddDate(0, -1, 0), now.AddDate(0, 2, 0))
+
+	_, err = pool.Exec(context.Background(), `CREATE UNIQUE INDEX IF NOT EXISTS idx_rates_sample ON rates(market, source, timestamp)`)
+	require.NoError(t, err)
+
+	_, err = pool.Exec(context.Background(), `
+		CREATE TABLE IF NOT EXISTS api_keys (
+			id TEXT PRIMARY KEY,
+			name TEXT NOT NULL,
+			key_hash TEXT NOT NULL,
+			scopes TEXT[] NOT NULL DEFAULT '{}',
+			created_by TEXT NOT NULL DEFAULT '',
+			created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+			revoked_at TIMESTAMP WITH TIME ZONE
+		)
+	`)
+	require.NoError(t, err)
+
+	_, err = pool.Exec(context.Background(), `
+		CREATE TABLE IF NOT EXISTS order_book_snapshots (
+			id BIGSERIAL PRIMARY KEY,
+			rate_id BIGINT,
+			market TEXT NOT NULL,
+			timestamp TIMESTAMP WITH TIME ZONE NOT NULL,
+			depth INTEGER NOT NULL,
+			asks JSONB NOT NULL,
+			bids JSONB NOT NULL,
+			created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+		)
+	`)
+	require.NoError(t, err)
+
+	_, err = pool.Exec(context.Background(), `
+		CREATE TABLE IF NOT EXISTS fixings (
 			id BIGSERIAL PRIMARY KEY,
+			name TEXT NOT NULL,
+			market TEXT NOT NULL,
+			fixing_date DATE NOT NULL,
+			method TEXT NOT NULL,
+			methodology TEXT NOT NULL,
+			window_start TIMESTAMP WITH TIME ZONE NOT NULL,
+			window_end TIMESTAMP WITH TIME ZONE NOT NULL,
+			ask DECIMAL(20, 8) NOT NULL,
+			bid DECIMAL(20, 8) NOT NULL,
+			mid DECIMAL(20, 8) NOT NULL,
+			sample_count INTEGER NOT NULL,
+			computed_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+			UNIQUE (name, fixing_date)
+		)
+	`)
+	require.NoError(t, err)
+
+	_, err = pool.Exec(context.Background(), `
+		CREATE TABLE IF NOT EXISTS rate_aggregates (
+			market TEXT NOT NULL,
+			source TEXT NOT NULL,
+			resolution TEXT NOT NULL,
+			bucket TIMESTAMP WITH TIME ZONE NOT NULL,
+			ask_open DECIMAL(20, 8) NOT NULL,
+			ask_high DECIMAL(20, 8) NOT NULL,
+			ask_low DECIMAL(20, 8) NOT NULL,
+			ask_close DECIMAL(20, 8) NOT NULL,
+			bid_open DECIMAL(20, 8) NOT NULL,
+			bid_high DECIMAL(20, 8) NOT NULL,
+			bid_low DECIMAL(20, 8) NOT NULL,
+			bid_close DECIMAL(20, 8) NOT NULL,
+			sample_count BIGINT NOT NULL,
+			first_at TIMESTAMP WITH TIME ZONE NOT NULL,
+			last_at TIMESTAMP WITH TIME ZONE NOT NULL,
+			created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+			PRIMARY KEY (market, source, resolution, bucket)
+		)
+	`)
+	require.NoError(t, err)
+
+	_, err = pool.Exec(context.Background(), `
+		CREATE TABLE IF NOT EXISTS backfill_checkpoints (
+			name TEXT PRIMARY KEY,
+			market TEXT NOT NULL,
+			mode TEXT NOT NULL,
+			period_seconds BIGINT NOT NULL DEFAULT 0,
+			window_start TIMESTAMP WITH TIME ZONE NOT NULL,
+			window_end TIMESTAMP WITH TIME ZONE NOT NULL,
+			cursor TIMESTAMP WITH TIME ZONE NOT NULL,
+			last_trade_id BIGINT NOT NULL DEFAULT 0,
+			inserted BIGINT NOT NULL DEFAULT 0,
+			completed_at TIMESTAMP WITH TIME ZONE,
+			updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+		)
+	`)
+	require.NoError(t, err)
+
+	_, err = pool.Exec(context.Background(), `
+		CREATE TABLE IF NOT EXISTS data_quality_issues (
+			id BIGSERIAL PRIMARY KEY,
+			market TEXT NOT NULL,
+			kind TEXT NOT NULL,
+			start_time TIMESTAMP WITH TIME ZONE NOT NULL,
+			end_time TIMESTAMP WITH TIME ZONE NOT NULL,
+			samples INTEGER NOT NULL DEFAULT 0,
+			detail TEXT NOT NULL DEFAULT '',
+			detected_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+			UNIQUE (market, kind, start_time)
+		)
+	`)
+	require.NoError(t, err)
+
+	_, err = pool.Exec(context.Background(), `
+		CREATE TABLE IF NOT EXISTS quarantined_rates (
+			id BIGSERIAL PRIMARY KEY,
+			market TEXT NOT NULL,
+			source TEXT NOT NULL,
 			ask DECIMAL(20, 8) NOT NULL,
 			bid DECIMAL(20, 8) NOT NULL,
 			timestamp TIMESTAMP WITH TIME ZONE NOT NULL,
+			received_at TIMESTAMP WITH TIME ZONE,
+			reason TEXT NOT NULL,
+			detail TEXT NOT NULL DEFAULT '',
 			created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
 		)
 	`)
 	require.NoError(t, err)
 
 	cleanup := func() {
+		_, _ = pool.Exec(context.Background(), "DROP TABLE IF EXISTS quarantined_rates")
+		_, _ = pool.Exec(context.Background(), "DROP TABLE IF EXISTS data_quality_issues")
+		_, _ = pool.Exec(context.Background(), "DROP TABLE IF EXISTS backfill_checkpoints")
+		_, _ = pool.Exec(context.Background(), "DROP TABLE IF EXISTS rate_aggregates")
+		_, _ = pool.Exec(context.Background(), "DROP TABLE IF EXISTS fixings")
+		_, _ = pool.Exec(context.Background(), "DROP TABLE IF EXISTS order_book_snapshots")
 		_, _ = pool.Exec(context.Background(), "DROP TABLE IF EXISTS rates")
+		_, _ = pool.Exec(context.Background(), "DROP TABLE IF EXISTS api_keys")
 		pool.Close()
 	}
 
 	return pool, cleanup
 }
 
+// ensureTestPartitions creates the monthly rates partitions covering [from, to] the way the partition
+// maintainer does
+func ensureTestPartitions(t *testing.T, pool *pgxpool.Pool, from, to time.Time) {
+	logger, err := sl.New("info")
+	require.NoError(t, err)
+
+	repo := &Repository{pool: pool, logger: logger}
+	_, err = repo.EnsurePartitions(context.Background(), from, to)
+	require.NoError(t, err)
+}
+
+func TestConformance(t *testing.T) {
+	storetest.Run(t, func(t *testing.T) repository.Store {
+		pool, cleanup := setupTestDB(t)
+		t.Cleanup(cleanup)
+		// The suite stores rates around 2024-03-01 12:00 UTC
+		march := time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+		ensureTestPartitions(t, pool, march, march)
+
+		logger, err := sl.New("info")
+		require.NoError(t, err)
+
+		return &Repository{
+			pool:   pool,
+			logger: logger,
+		}
+	})
+}
+
 func TestNewRepository(t *testing.T) {
 	pool, cleanup := setupTestDB(t)
 	defer cleanup()
@@ -72,22 +244,84 @@ func TestSaveRate(t *testing.T) {
 		logger: logger,
 	}
 
+	receivedAt := time.Now()
 	rate := &exchange.Rate{
-		Ask:       100.50,
-		Bid:       100.40,
-		Timestamp: time.Now(),
+		Market:     exchange.DefaultMarket,
+		Ask:        decimal.RequireFromString("100.50"),
+		Bid:        decimal.RequireFromString("100.40"),
+		Timestamp:  receivedAt.Add(-2 * time.Second),
+		ReceivedAt: receivedAt,
+		Latency:    150 * time.Millisecond,
 	}
 
 	ctx := context.Background()
-	err = repo.SaveRate(ctx, rate)
+	created, err := repo.SaveRate(ctx, rate)
 
 	assert.NoError(t, err)
+	assert.True(t, created)
+
+	// The same sample is stored only once
+	created, err = repo.SaveRate(ctx, rate)
+	require.NoError(t, err)
+	assert.False(t, created)
 
 	// Verify the rate was saved
 	var count int
 	err = pool.QueryRow(ctx, "SELECT COUNT(*) FROM rates").Scan(&count)
 	assert.NoError(t, err)
 	assert.Equal(t, 1, count)
+
+	saved, err := repo.GetLatestRate(ctx)
+	require.NoError(t, err)
+	assert.WithinDuration(t, rate.Timestamp, saved.Timestamp, time.Millisecond)
+	assert.WithinDuration(t, receivedAt, saved.ReceivedAt, time.Millisecond)
+	assert.Equal(t, 150*time.Millisecond, saved.Latency)
+	assert.Equal(t, exchange.DefaultMarket, saved.Market)
+	assert.Equal(t, exchange.DefaultSource, saved.Source)
+}
+
+func TestSaveRates(t *testing.T) {
+	pool, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	logger, err := sl.New("info")
+	require.NoError(t, err)
+
+	repo := &Repository{
+		pool:   pool,
+		logger: logger,
+	}
+
+	ctx := context.Background()
+	now := time.Now()
+
+	n, err := repo.SaveRates(ctx, nil)
+	require.NoError(t, err)
+	assert.Equal(t, int64(0), n)
+
+	rates := []*exchange.Rate{
+		{Market: "btcusdt", Ask: decimal.RequireFromString("100.5"), Bid: decimal.RequireFromString("100.4"),
+			Timestamp: now.Add(-2 * time.Second), ReceivedAt: now, Latency: 20 * time.Millisecond},
+		{Market: "ethusdt", Ask: decimal.RequireFromString("10.12345678"), Bid: decimal.RequireFromString("10.1"),
+			Timestamp: now.Add(-time.Second), ReceivedAt: now, Latency: 30 * time.Millisecond},
+	}
+
+	n, err = repo.SaveRates(ctx, rates)
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), n)
+
+	// Retried batches only store new samples
+	rates = append(rates, &exchange.Rate{Market: "btcusdt", Source: "backfill", Ask: decimal.NewFromInt(1),
+		Bid: decimal.NewFromInt(1), Timestamp: now.Add(-2 * time.Second), ReceivedAt: now})
+	n, err = repo.SaveRates(ctx, rates)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), n)
+
+	saved, err := repo.GetLatestRate(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, "ethusdt", saved.Market)
+	assert.True(t, decimal.RequireFromString("10.12345678").Equal(saved.Ask))
+	assert.Equal(t, 30*time.Millisecond, saved.Latency)
 }
 
 func TestGetLatestRate(t *testing.T) {
@@ -121,8 +355,8 @@ func TestGetLatestRate(t *testing.T) {
 	rate, err := repo.GetLatestRate(ctx)
 	assert.NoError(t, err)
 	assert.NotNil(t, rate)
-	assert.Equal(t, 100.50, rate.Ask)
-	assert.Equal(t, 100.40, rate.Bid)
+	assert.True(t, decimal.RequireFromString("100.50").Equal(rate.Ask))
+	assert.True(t, decimal.RequireFromString("100.40").Equal(rate.Bid))
 	assert.WithinDuration(t, now, rate.Timestamp, time.Second)
 }
 
@@ -164,8 +398,8 @@ func TestGetRatesByTimeRange(t *testing.T) {
 	assert.Len(t, rates, 2)
 
 	// Verify rates are ordered by timestamp DESC
-	assert.Equal(t, 100.60, rates[0].Ask)
-	assert.Equal(t, 100.50, rates[1].Ask)
+	assert.True(t, decimal.RequireFromString("100.60").Equal(rates[0].Ask))
+	assert.True(t, decimal.RequireFromString("100.50").Equal(rates[1].Ask))
 }
 
 func TestGetRatesCount(t *testing.T) {
@@ -200,3 +434,49 @@ func TestGetRatesCount(t *testing.T) {
 	assert.NoError(t, err)
 	assert.Equal(t, int64(1), count)
 }
+
+func TestGetRatesAround(t *testing.T) {
+	pool, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	logger, err := sl.New("info")
+	require.NoError(t, err)
+
+	repo := &Repository{
+		pool:   pool,
+		logger: logger,
+	}
+
+	ctx := context.Background()
+	now := time.Now().UTC().Truncate(time.Second)
+
+	_, _, err = repo.GetRatesAround(ctx, "btcusdt", now)
+	assert.Equal(t, sql.ErrNoRows, err)
+
+	_, err = pool.Exec(ctx, `
+		INSERT INTO rates (market, ask, bid, timestamp, created_at)
+		VALUES
+			('btcusdt', 100.50, 100.40, $1, $3),
+			('btcusdt', 100.70, 100.60, $2, $3),
+			('ethusdt', 10.00, 9.90, $3, $3)
+	`, now.Add(-time.Minute), now.Add(time.Minute), now)
+	require.NoError(t, err)
+
+	before, after, err := repo.GetRatesAround(ctx, "btcusdt", now)
+	require.NoError(t, err)
+	require.NotNil(t, before)
+	require.NotNil(t, after)
+	assert.True(t, now.Add(-time.Minute).Equal(before.Timestamp))
+	assert.True(t, now.Add(time.Minute).Equal(after.Timestamp))
+
+	// A sample exactly at the requested time counts as before
+	before, after, err = repo.GetRatesAround(ctx, "btcusdt", now.Add(time.Minute))
+	require.NoError(t, err)
+	assert.True(t, decimal.RequireFromString("100.70").Equal(before.Ask))
+	assert.Nil(t, after)
+
+	before, after, err = repo.GetRatesAround(ctx, "btcusdt", now.Add(-time.Hour))
+	require.NoError(t, err)
+	assert.Nil(t, before)
+	assert.True(t, decimal.RequireFromString("100.50").Equal(after.Ask))
+}
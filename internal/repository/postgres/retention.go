@@ -0,0 +1,187 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/cawa87/garantex-test/internal/service/retention"
+)
+
+// WithAdvisoryLock runs fn while holding the session-level advisory lock key on a dedicated connection.
+// It returns false without running fn when another session holds the lock
+func (r *Repository) WithAdvisoryLock(ctx context.Context, key int64, fn func(ctx context.Context) error) (bool, error) {
+	conn, err := r.pool.Acquire(ctx)
+	if err != nil {
+		return false, fmt.Errorf("failed to acquire connection: %w", err)
+	}
+	defer conn.Release()
+
+	var acquired bool
+	if err := conn.QueryRow(ctx, `SELECT pg_try_advisory_lock($1)`, key).Scan(&acquired); err != nil {
+		return false, fmt.Errorf("failed to acquire advisory lock: %w", err)
+	}
+	if !acquired {
+		return false, nil
+	}
+
+	defer func() {
+		// Unlock even when ctx is cancelled; closing the session releases the lock if that fails
+		if _, err := conn.Exec(context.Background(), `SELECT pg_advisory_unlock($1)`, key); err != nil {
+			r.logger.Error("Failed to release advisory lock", "key", key, "error", err)
+			_ = conn.Conn().Close(context.Background())
+		}
+	}()
+
+	return true, fn(ctx)
+}
+
+// mergeAggregate merges a bucket built from newly expired rows into an existing bucket of the same key:
+// open and close come from whichever side holds the earlier first and later last sample
+const mergeAggregate = `
+	ON CONFLICT (market, source, resolution, bucket) DO UPDATE SET
+		ask_open = CASE WHEN EXCLUDED.first_at < rate_aggregates.first_at THEN EXCLUDED.ask_open ELSE rate_aggregates.ask_open END,
+		ask_high = GREATEST(rate_aggregates.ask_high, EXCLUDED.ask_high),
+		ask_low = LEAST(rate_aggregates.ask_low, EXCLUDED.ask_low),
+		ask_close = CASE WHEN EXCLUDED.last_at > rate_aggregates.last_at THEN EXCLUDED.ask_close ELSE rate_aggregates.ask_close END,
+		bid_open = CASE WHEN EXCLUDED.first_at < rate_aggregates.first_at THEN EXCLUDED.bid_open ELSE rate_aggregates.bid_open END,
+		bid_high = GREATEST(rate_aggregates.bid_high, EXCLUDED.bid_high),
+		bid_low = LEAST(rate_aggregates.bid_low, EXCLUDED.bid_low),
+		bid_close = CASE WHEN EXCLUDED.last_at > rate_aggregates.last_at THEN EXCLUDED.bid_close ELSE rate_aggregates.bid_close END,
+		sample_count = rate_aggregates.sample_count + EXCLUDED.sample_count,
+		first_at = LEAST(rate_aggregates.first_at, EXCLUDED.first_at),
+		last_at = GREATEST(rate_aggregates.last_at, EXCLUDED.last_at)
+	RETURNING 1
+`
+
+// DownsampleRates moves raw rates before cutoff into 1-minute OHLC buckets per market and source, batchSize
+// rates at a time. Each batch is deleted and merged into its buckets in one statement, so a rate is counted
+// exactly once, including rates imported or backfilled into buckets aggregated earlier
+func (r *Repository) DownsampleRates(ctx context.Context, cutoff time.Time, batchSize int) (int64, int64, error) {
+	query := `
+		WITH expired AS (
+			DELETE FROM rates
+			WHERE (id, timestamp) IN (
+				SELECT id, timestamp FROM rates
+				WHERE timestamp < $1
+				LIMIT $3
+			)
+			RETURNING id, market, source, ask, bid, timestamp
+		), merged AS (
+			INSERT INTO rate_aggregates (market, source, resolution, bucket,
+				ask_open, ask_high, ask_low, ask_close, bid_open, bid_high, bid_low, bid_close, sample_count, first_at, last_at)
+			SELECT market, source, $2, date_trunc('minute', timestamp, 'UTC') AS bucket,
+				(array_agg(ask ORDER BY timestamp, id))[1], max(ask), min(ask), (array_agg(ask ORDER BY timestamp DESC, id DESC))[1],
+				(array_agg(bid ORDER BY timestamp, id))[1], max(bid), min(bid), (array_agg(bid ORDER BY timestamp DESC, id DESC))[1],
+				count(*), min(timestamp), max(timestamp)
+			FROM expired
+			GROUP BY market, source, bucket
+		` + mergeAggregate + `
+		)
+		SELECT (SELECT count(*) FROM merged), (SELECT count(*) FROM expired)
+	`
+
+	buckets, moved, err := r.moveInBatches(ctx, batchSize, query, cutoff, string(retention.Minute), batchSize)
+	if err != nil {
+		return buckets, moved, fmt.Errorf("failed to downsample rates: %w", err)
+	}
+	return buckets, moved, nil
+}
+
+// RollupAggregates moves 1-minute buckets before cutoff into 1-hour buckets per market and source, batchSize
+// buckets at a time, merging them into existing hours like DownsampleRates
+func (r *Repository) RollupAggregates(ctx context.Context, cutoff time.Time, batchSize int) (int64, int64, error) {
+	query := `
+		WITH expired AS (
+			DELETE FROM rate_aggregates
+			WHERE ctid IN (
+				SELECT ctid FROM rate_aggregates
+				WHERE resolution = $2 AND bucket < $1
+				LIMIT $4
+			)
+			RETURNING *
+		), merged AS (
+			INSERT INTO rate_aggregates (market, source, resolution, bucket,
+				ask_open, ask_high, ask_low, ask_close, bid_open, bid_high, bid_low, bid_close, sample_count, first_at, last_at)
+			SELECT market, source, $3, date_trunc('hour', bucket, 'UTC') AS hour,
+				(array_agg(ask_open ORDER BY first_at))[1], max(ask_high), min(ask_low), (array_agg(ask_close ORDER BY last_at DESC))[1],
+				(array_agg(bid_open ORDER BY first_at))[1], max(bid_high), min(bid_low), (array_agg(bid_close ORDER BY last_at DESC))[1],
+				sum(sample_count), min(first_at), max(last_at)
+			FROM expired
+			GROUP BY market, source, hour
+		` + mergeAggregate + `
+		)
+		SELECT (SELECT count(*) FROM merged), (SELECT count(*) FROM expired)
+	`
+
+	buckets, moved, err := r.moveInBatches(ctx, batchSize, query, cutoff, string(retention.Minute), string(retention.Hour), batchSize)
+	if err != nil {
+		return buckets, moved, fmt.Errorf("failed to roll up aggregates: %w", err)
+	}
+	return buckets, moved, nil
+}
+
+// DeleteAggregatesBefore deletes buckets of the resolution before cutoff in batches and returns the number deleted
+func (r *Repository) DeleteAggregatesBefore(ctx context.Context, resolution retention.Resolution, cutoff time.Time, batchSize int) (int64, error) {
+	query := `
+		DELETE FROM rate_aggregates
+		WHERE ctid IN (
+			SELECT ctid FROM rate_aggregates
+			WHERE resolution = $1 AND bucket < $2
+			LIMIT $3
+		)
+	`
+	return r.deleteInBatches(ctx, "rate aggregates", batchSize, query, string(resolution), cutoff, batchSize)
+}
+
+// CountRatesBefore counts raw rates before cutoff
+func (r *Repository) CountRatesBefore(ctx context.Context, cutoff time.Time) (int64, error) {
+	var count int64
+	if err := r.pool.QueryRow(ctx, `SELECT COUNT(*) FROM rates WHERE timestamp < $1`, cutoff).Scan(&count); err != nil {
+		return 0, fmt.Errorf("failed to count rates: %w", err)
+	}
+	return count, nil
+}
+
+// CountAggregatesBefore counts buckets of the resolution before cutoff
+func (r *Repository) CountAggregatesBefore(ctx context.Context, resolution retention.Resolution, cutoff time.Time) (int64, error) {
+	query := `SELECT COUNT(*) FROM rate_aggregates WHERE resolution = $1 AND bucket < $2`
+
+	var count int64
+	if err := r.pool.QueryRow(ctx, query, string(resolution), cutoff).Scan(&count); err != nil {
+		return 0, fmt.Errorf("failed to count rate aggregates: %w", err)
+	}
+	return count, nil
+}
+
+// moveInBatches repeats a batched move returning (buckets written, rows moved) until it moves fewer than
+// batchSize rows, and returns the totals
+func (r *Repository) moveInBatches(ctx context.Context, batchSize int, query string, args ...interface{}) (int64, int64, error) {
+	var buckets, moved int64
+	for {
+		var b, m int64
+		if err := r.pool.QueryRow(ctx, query, args...).Scan(&b, &m); err != nil {
+			return buckets, moved, err
+		}
+		buckets += b
+		moved += m
+		if m < int64(batchSize) {
+			return buckets, moved, nil
+		}
+	}
+}
+
+// deleteInBatches repeats a batched delete until it removes fewer than batchSize rows
+func (r *Repository) deleteInBatches(ctx context.Context, what string, batchSize int, query string, args ...interface{}) (int64, error) {
+	var total int64
+	for {
+		tag, err := r.pool.Exec(ctx, query, args...)
+		if err != nil {
+			return total, fmt.Errorf("failed to delete %s: %w", what, err)
+		}
+		total += tag.RowsAffected()
+		if tag.RowsAffected() < int64(batchSize) {
+			return total, nil
+		}
+	}
+}
@@ -0,0 +1,147 @@
+package postgres
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/cawa87/garantex-test/internal/lib/logger/sl"
+	"github.com/cawa87/garantex-test/internal/service/retention"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRetention(t *testing.T) {
+	pool, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	logger, err := sl.New("info")
+	require.NoError(t, err)
+
+	repo := &Repository{
+		pool:   pool,
+		logger: logger,
+	}
+
+	ctx := context.Background()
+	hour := time.Now().UTC().Add(-48 * time.Hour).Truncate(time.Hour)
+
+	_, err = pool.Exec(ctx, `
+		INSERT INTO rates (market, source, ask, bid, timestamp)
+		VALUES
+			('btcusdt', 'garantex', 100, 99, $1),
+			('btcusdt', 'garantex', 105, 104, $2),
+			('btcusdt', 'garantex', 102, 101, $3),
+			('btcusdt', 'garantex', 110, 109, $4),
+			('btcusdt', 'backfill', 200, 199, $2)
+	`, hour, hour.Add(10*time.Second), hour.Add(50*time.Second), hour.Add(90*time.Second))
+	require.NoError(t, err)
+
+	cutoff := hour.Add(time.Hour)
+
+	count, err := repo.CountRatesBefore(ctx, cutoff)
+	require.NoError(t, err)
+	assert.Equal(t, int64(5), count)
+
+	// Batches of 2 split the first minute; the buckets merge and the rates are gone once aggregated
+	buckets, moved, err := repo.DownsampleRates(ctx, cutoff, 2)
+	require.NoError(t, err)
+	assert.GreaterOrEqual(t, buckets, int64(3))
+	assert.Equal(t, int64(5), moved)
+
+	count, err = repo.CountRatesBefore(ctx, cutoff)
+	require.NoError(t, err)
+	assert.Zero(t, count)
+
+	bucket := func(source string, at time.Time) (open, high, low, closing decimal.Decimal, samples int64) {
+		err := pool.QueryRow(ctx, `
+			SELECT ask_open, ask_high, ask_low, ask_close, sample_count
+			FROM rate_aggregates WHERE resolution = '1m' AND source = $1 AND bucket = $2
+		`, source, at).Scan(&open, &high, &low, &closing, &samples)
+		require.NoError(t, err)
+		return open, high, low, closing, samples
+	}
+
+	// Sources are kept apart
+	open, high, low, closing, samples := bucket("garantex", hour)
+	assert.True(t, decimal.NewFromInt(100).Equal(open))
+	assert.True(t, decimal.NewFromInt(105).Equal(high))
+	assert.True(t, decimal.NewFromInt(100).Equal(low))
+	assert.True(t, decimal.NewFromInt(102).Equal(closing))
+	assert.Equal(t, int64(3), samples)
+	_, high, _, _, samples = bucket("backfill", hour)
+	assert.True(t, decimal.NewFromInt(200).Equal(high))
+	assert.Equal(t, int64(1), samples)
+
+	// Rates imported into an aggregated minute are merged into its bucket, not dropped
+	_, err = pool.Exec(ctx, `
+		INSERT INTO rates (market, source, ask, bid, timestamp)
+		VALUES ('btcusdt', 'garantex', 98, 97, $1), ('btcusdt', 'garantex', 103, 102, $2)
+	`, hour, hour.Add(55*time.Second))
+	require.NoError(t, err)
+	_, moved, err = repo.DownsampleRates(ctx, cutoff, 100)
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), moved)
+
+	open, high, low, closing, samples = bucket("garantex", hour)
+	assert.True(t, decimal.NewFromInt(100).Equal(open), "the earlier sample stays the open on a tie")
+	assert.True(t, decimal.NewFromInt(105).Equal(high))
+	assert.True(t, decimal.NewFromInt(98).Equal(low))
+	assert.True(t, decimal.NewFromInt(103).Equal(closing))
+	assert.Equal(t, int64(5), samples)
+
+	buckets, moved, err = repo.RollupAggregates(ctx, cutoff, 10)
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), buckets)
+	assert.Equal(t, int64(3), moved)
+
+	count, err = repo.CountAggregatesBefore(ctx, retention.Minute, cutoff)
+	require.NoError(t, err)
+	assert.Zero(t, count)
+
+	var hourSamples int64
+	err = pool.QueryRow(ctx, `
+		SELECT sample_count FROM rate_aggregates WHERE resolution = '1h' AND source = 'garantex' AND bucket = $1
+	`, hour).Scan(&hourSamples)
+	require.NoError(t, err)
+	assert.Equal(t, int64(6), hourSamples)
+
+	deleted, err := repo.DeleteAggregatesBefore(ctx, retention.Hour, cutoff, 10)
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), deleted)
+
+	count, err = repo.CountAggregatesBefore(ctx, retention.Hour, cutoff)
+	require.NoError(t, err)
+	assert.Zero(t, count)
+}
+
+func TestWithAdvisoryLock(t *testing.T) {
+	pool, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	logger, err := sl.New("info")
+	require.NoError(t, err)
+
+	repo := &Repository{
+		pool:   pool,
+		logger: logger,
+	}
+
+	ctx := context.Background()
+	acquired, err := repo.WithAdvisoryLock(ctx, 42, func(ctx context.Context) error {
+		// A second session cannot take the lock while it is held
+		nested, err := repo.WithAdvisoryLock(ctx, 42, func(context.Context) error {
+			t.Fatal("lock acquired twice")
+			return nil
+		})
+		assert.False(t, nested)
+		return err
+	})
+	require.NoError(t, err)
+	assert.True(t, acquired)
+
+	acquired, err = repo.WithAdvisoryLock(ctx, 42, func(context.Context) error { return nil })
+	require.NoError(t, err)
+	assert.True(t, acquired)
+}
@@ -0,0 +1,93 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/cawa87/garantex-test/internal/repository"
+	"github.com/jackc/pgx/v5"
+)
+
+// streamBatchSize is how many rows a stream fetches from its cursor at a time
+const streamBatchSize = 1000
+
+// StreamRates calls fn with each rate of a market in [from, to), ascending, reading them from a cursor
+func (r *Repository) StreamRates(ctx context.Context, market string, from, to time.Time, fn func(*repository.Rate) error) error {
+	query := `
+		SELECT ` + rateColumns + `
+		FROM rates
+		WHERE market = $1 AND timestamp >= $2 AND timestamp < $3
+		ORDER BY timestamp, id
+	`
+
+	return r.stream(ctx, "StreamRates", query, []any{market, from, to}, func(rows pgx.Rows) error {
+		rate, err := scanRate(rows)
+		if err != nil {
+			return fmt.Errorf("failed to scan rate: %w", err)
+		}
+		return fn(rate)
+	})
+}
+
+// StreamCandles calls fn with each candle of a market over the rates in [from, to), ascending,
+// reading them from a cursor
+func (r *Repository) StreamCandles(ctx context.Context, market string, interval time.Duration, from, to time.Time, fn func(*repository.Candle) error) error {
+	if err := repository.CheckCandleInterval(interval); err != nil {
+		return err
+	}
+
+	args := []any{market, from, to, int64(interval / time.Second)}
+	return r.stream(ctx, "StreamCandles", candlesQuery, args, func(rows pgx.Rows) error {
+		c := repository.Candle{Market: market}
+		err := rows.Scan(&c.Start,
+			&c.AskOpen, &c.AskHigh, &c.AskLow, &c.AskClose,
+			&c.BidOpen, &c.BidHigh, &c.BidLow, &c.BidClose,
+			&c.Samples)
+		if err != nil {
+			return fmt.Errorf("failed to scan candle: %w", err)
+		}
+		c.Start = c.Start.UTC()
+		return fn(&c)
+	})
+}
+
+// stream runs query through a server-side cursor in a read-only transaction and calls handle for every row.
+// Rows are fetched streamBatchSize at a time, so no result is held in memory and no single statement
+// runs for the whole stream, which keeps long exports clear of the statement timeout
+func (r *Repository) stream(ctx context.Context, method, query string, args []any, handle func(pgx.Rows) error) error {
+	tx, err := r.reader(ctx, method).BeginTx(ctx, pgx.TxOptions{AccessMode: pgx.ReadOnly})
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback(ctx) }()
+
+	if _, err := tx.Exec(ctx, `DECLARE stream_cursor NO SCROLL CURSOR FOR `+query, args...); err != nil {
+		return fmt.Errorf("failed to declare cursor: %w", err)
+	}
+
+	fetch := fmt.Sprintf(`FETCH %d FROM stream_cursor`, streamBatchSize)
+	for {
+		rows, err := tx.Query(ctx, fetch)
+		if err != nil {
+			return fmt.Errorf("failed to fetch rows: %w", err)
+		}
+
+		fetched := 0
+		for rows.Next() {
+			fetched++
+			if err := handle(rows); err != nil {
+				rows.Close()
+				return err
+			}
+		}
+		rows.Close()
+		if err := rows.Err(); err != nil {
+			return fmt.Errorf("error iterating over rows: %w", err)
+		}
+
+		if fetched < streamBatchSize {
+			return nil
+		}
+	}
+}
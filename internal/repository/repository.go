@@ -0,0 +1,102 @@
+// Package repository defines the storage records and interfaces shared by the storage backends
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/cawa87/garantex-test/internal/service/auth"
+	"github.com/cawa87/garantex-test/internal/service/exchange"
+	"github.com/cawa87/garantex-test/internal/service/fixing"
+	"github.com/shopspring/decimal"
+)
+
+// PriceScale is the number of decimal places prices are stored with
+const PriceScale = 8
+
+// Rate represents a stored rate record.
+// Timestamp is the exchange timestamp, ReceivedAt the local receive time and Latency the fetch round trip
+type Rate struct {
+	ID         int64           `db:"id"`
+	Market     string          `db:"market"`
+	Source     string          `db:"source"`
+	Ask        decimal.Decimal `db:"ask"`
+	Bid        decimal.Decimal `db:"bid"`
+	Timestamp  time.Time       `db:"timestamp"`
+	ReceivedAt time.Time       `db:"received_at"`
+	Latency    time.Duration   `db:"latency_us"`
+	CreatedAt  time.Time       `db:"created_at"`
+}
+
+// OrderBook represents a stored order book snapshot.
+// RateID is not a foreign key; the linked rate may since have been pruned or its partition detached
+type OrderBook struct {
+	ID        int64
+	RateID    *int64
+	Market    string
+	Timestamp time.Time
+	Depth     int
+	Asks      []exchange.PriceLevel
+	Bids      []exchange.PriceLevel
+	CreatedAt time.Time
+}
+
+// RateStore stores rates. A rate is identified by (Market, Source, Timestamp); saving a stored sample again
+// is a no-op. Lookups that find nothing return sql.ErrNoRows
+type RateStore interface {
+	// SaveRate saves a rate and reports whether it was new
+	SaveRate(ctx context.Context, rate *exchange.Rate) (bool, error)
+	// SaveRates saves rates in bulk and returns the number of new rows
+	SaveRates(ctx context.Context, rates []*exchange.Rate) (int64, error)
+	// GetLatestRate returns the rate with the latest timestamp across all markets
+	GetLatestRate(ctx context.Context) (*Rate, error)
+	// GetRatesByTimeRange returns the rates with timestamps in [from, to], newest first
+	GetRatesByTimeRange(ctx context.Context, from, to time.Time) ([]*Rate, error)
+	// GetRatesAround returns the latest rate of a market at or before at and the earliest one after it
+	GetRatesAround(ctx context.Context, market string, at time.Time) (before, after *Rate, err error)
+	// GetRatesCount returns the number of stored rates
+	GetRatesCount(ctx context.Context) (int64, error)
+	// GetCandles returns the candles of a market over the rates in [from, to), ascending; see CandleStart
+	GetCandles(ctx context.Context, market string, interval time.Duration, from, to time.Time) ([]*Candle, error)
+	// StreamRates calls fn with each rate of a market in [from, to), ascending, reading them from a cursor
+	// instead of loading them all; it stops at the first error fn returns
+	StreamRates(ctx context.Context, market string, from, to time.Time, fn func(*Rate) error) error
+	// StreamCandles calls fn with each candle of a market over the rates in [from, to), ascending, like StreamRates
+	StreamCandles(ctx context.Context, market string, interval time.Duration, from, to time.Time, fn func(*Candle) error) error
+}
+
+// OrderBookStore stores order book snapshots together with the rates derived from them
+type OrderBookStore interface {
+	// SaveRateWithOrderBook saves a rate and its snapshot atomically; nothing is stored for a known sample
+	SaveRateWithOrderBook(ctx context.Context, rate *exchange.Rate, book *exchange.OrderBookSnapshot) (bool, error)
+	// GetOrderBook returns the latest snapshot of a market taken at or before asOf; zero asOf means the latest
+	GetOrderBook(ctx context.Context, market string, asOf time.Time) (*OrderBook, error)
+	// DeleteOrderBooksBefore deletes snapshots older than cutoff and returns the number deleted
+	DeleteOrderBooksBefore(ctx context.Context, cutoff time.Time, batchSize int) (int64, error)
+}
+
+// FixingStore stores fixings and serves the ticks and snapshots they are computed from
+type FixingStore interface {
+	fixing.Store
+	// ListFixings returns the fixings matching the filter, newest date first, then by name
+	ListFixings(ctx context.Context, filter fixing.ListFilter) ([]*fixing.Fixing, error)
+}
+
+// APIKeyStore stores hashed API keys
+type APIKeyStore interface {
+	auth.KeyStore
+	// CreateAPIKey stores a new key
+	CreateAPIKey(ctx context.Context, key *auth.APIKey) error
+	// RevokeAPIKey revokes an active key and returns the revocation time; sql.ErrNoRows if there is none
+	RevokeAPIKey(ctx context.Context, id string) (time.Time, error)
+}
+
+// Store is a complete storage backend for the service
+type Store interface {
+	RateStore
+	OrderBookStore
+	FixingStore
+	APIKeyStore
+	// Close releases the backend's resources
+	Close()
+}
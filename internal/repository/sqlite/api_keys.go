@@ -0,0 +1,105 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/cawa87/garantex-test/internal/service/auth"
+)
+
+// CreateAPIKey stores a new API key
+func (r *Repository) CreateAPIKey(ctx context.Context, key *auth.APIKey) error {
+	query := `
+		INSERT INTO api_keys (id, name, key_hash, scopes, created_by, created_at)
+		VALUES (?, ?, ?, ?, ?, ?)
+	`
+
+	scopes, err := json.Marshal(key.Scopes)
+	if err != nil {
+		return fmt.Errorf("failed to encode scopes: %w", err)
+	}
+
+	_, err = r.db.ExecContext(ctx, query, key.ID, key.Name, key.Hash, string(scopes), key.CreatedBy, key.CreatedAt.UnixMicro())
+	if err != nil {
+		return fmt.Errorf("failed to create API key: %w", err)
+	}
+
+	r.logger.Info("API key created",
+		"id", key.ID,
+		"name", key.Name,
+		"scopes", key.Scopes,
+		"created_by", key.CreatedBy)
+
+	return nil
+}
+
+// GetAPIKey retrieves an API key by ID, including revoked keys
+func (r *Repository) GetAPIKey(ctx context.Context, id string) (*auth.APIKey, error) {
+	query := `
+		SELECT id, name, key_hash, scopes, created_by, created_at, revoked_at
+		FROM api_keys
+		WHERE id = ?
+	`
+
+	var (
+		key       auth.APIKey
+		scopes    string
+		createdAt int64
+		revokedAt sql.NullInt64
+	)
+	err := r.db.QueryRowContext(ctx, query, id).Scan(
+		&key.ID,
+		&key.Name,
+		&key.Hash,
+		&scopes,
+		&key.CreatedBy,
+		&createdAt,
+		&revokedAt,
+	)
+
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, sql.ErrNoRows
+		}
+		return nil, fmt.Errorf("failed to get API key: %w", err)
+	}
+
+	if err := json.Unmarshal([]byte(scopes), &key.Scopes); err != nil {
+		return nil, fmt.Errorf("failed to decode scopes: %w", err)
+	}
+	key.CreatedAt = fromMicros(createdAt)
+	if revokedAt.Valid {
+		t := fromMicros(revokedAt.Int64)
+		key.RevokedAt = &t
+	}
+
+	return &key, nil
+}
+
+// RevokeAPIKey marks an active API key as revoked and returns the revocation time.
+// It returns sql.ErrNoRows if the key does not exist or is already revoked
+func (r *Repository) RevokeAPIKey(ctx context.Context, id string) (time.Time, error) {
+	query := `
+		UPDATE api_keys
+		SET revoked_at = ?
+		WHERE id = ? AND revoked_at IS NULL
+	`
+
+	revokedAt := fromMicros(time.Now().UnixMicro())
+	result, err := r.db.ExecContext(ctx, query, revokedAt.UnixMicro(), id)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("failed to revoke API key: %w", err)
+	}
+	if n, err := result.RowsAffected(); err != nil {
+		return time.Time{}, fmt.Errorf("failed to revoke API key: %w", err)
+	} else if n == 0 {
+		return time.Time{}, sql.ErrNoRows
+	}
+
+	r.logger.Info("API key revoked", "id", id)
+
+	return revokedAt, nil
+}
@@ -0,0 +1,206 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/cawa87/garantex-test/internal/service/fixing"
+)
+
+// fixingColumns is the select list matching scanFixing
+const fixingColumns = `id, name, market, fixing_date, method, methodology, window_start, window_end,
+	ask, bid, mid, sample_count, computed_at`
+
+// GetTicks retrieves the rate in force at from (if any) followed by the rates in [from, to), ascending
+func (r *Repository) GetTicks(ctx context.Context, market string, from, to time.Time) ([]fixing.Tick, error) {
+	query := `
+		SELECT ask, bid, timestamp FROM (
+			SELECT * FROM (
+				SELECT ask, bid, timestamp
+				FROM rates
+				WHERE market = ? AND timestamp < ?
+				ORDER BY timestamp DESC
+				LIMIT 1
+			)
+			UNION ALL
+			SELECT ask, bid, timestamp
+			FROM rates
+			WHERE market = ? AND timestamp >= ? AND timestamp < ?
+		)
+		ORDER BY timestamp
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, market, from.UnixMicro(), market, from.UnixMicro(), to.UnixMicro())
+	if err != nil {
+		return nil, fmt.Errorf("failed to query ticks: %w", err)
+	}
+	defer rows.Close()
+
+	var ticks []fixing.Tick
+	for rows.Next() {
+		var (
+			tick      fixing.Tick
+			timestamp int64
+		)
+		if err := rows.Scan(&tick.Ask, &tick.Bid, &timestamp); err != nil {
+			return nil, fmt.Errorf("failed to scan tick: %w", err)
+		}
+		tick.Timestamp = fromMicros(timestamp)
+		ticks = append(ticks, tick)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating over rows: %w", err)
+	}
+
+	return ticks, nil
+}
+
+// SaveFixing inserts a fixing, replacing an earlier computation of the same name and date
+func (r *Repository) SaveFixing(ctx context.Context, f *fixing.Fixing) error {
+	query := `
+		INSERT INTO fixings (name, market, fixing_date, method, methodology, window_start, window_end,
+			ask, bid, mid, sample_count, computed_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+		ON CONFLICT (name, fixing_date) DO UPDATE SET
+			market = excluded.market,
+			method = excluded.method,
+			methodology = excluded.methodology,
+			window_start = excluded.window_start,
+			window_end = excluded.window_end,
+			ask = excluded.ask,
+			bid = excluded.bid,
+			mid = excluded.mid,
+			sample_count = excluded.sample_count,
+			computed_at = excluded.computed_at
+		RETURNING id
+	`
+
+	err := r.db.QueryRowContext(ctx, query, f.Name, f.Market, f.Date.Format(time.DateOnly), string(f.Method), f.Methodology,
+		f.WindowStart.UnixMicro(), f.WindowEnd.UnixMicro(), price(f.Ask), price(f.Bid), price(f.Mid),
+		f.SampleCount, f.ComputedAt.UnixMicro()).Scan(&f.ID)
+	if err != nil {
+		return fmt.Errorf("failed to save fixing: %w", err)
+	}
+
+	r.logger.Debug("Fixing saved to database",
+		"id", f.ID,
+		"name", f.Name,
+		"date", f.Date.Format(time.DateOnly))
+
+	return nil
+}
+
+// GetFixing retrieves the fixing of a window on a calendar date
+func (r *Repository) GetFixing(ctx context.Context, name string, date time.Time) (*fixing.Fixing, error) {
+	query := `
+		SELECT ` + fixingColumns + `
+		FROM fixings
+		WHERE name = ? AND fixing_date = ?
+	`
+
+	f, err := scanFixing(r.db.QueryRowContext(ctx, query, name, date.Format(time.DateOnly)))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, sql.ErrNoRows
+		}
+		return nil, fmt.Errorf("failed to get fixing: %w", err)
+	}
+
+	return f, nil
+}
+
+// ListFixings retrieves fixings matching the filter, newest date first
+func (r *Repository) ListFixings(ctx context.Context, filter fixing.ListFilter) ([]*fixing.Fixing, error) {
+	var (
+		conditions []string
+		args       []any
+	)
+	add := func(condition string, arg any) {
+		conditions = append(conditions, condition)
+		args = append(args, arg)
+	}
+
+	if filter.Name != "" {
+		add("name = ?", filter.Name)
+	}
+	if filter.Market != "" {
+		add("market = ?", filter.Market)
+	}
+	if !filter.From.IsZero() {
+		add("fixing_date >= ?", filter.From.Format(time.DateOnly))
+	}
+	if !filter.To.IsZero() {
+		add("fixing_date <= ?", filter.To.Format(time.DateOnly))
+	}
+
+	query := `SELECT ` + fixingColumns + ` FROM fixings`
+	if len(conditions) > 0 {
+		query += ` WHERE ` + strings.Join(conditions, " AND ")
+	}
+	query += ` ORDER BY fixing_date DESC, name`
+	if filter.Limit > 0 {
+		query += ` LIMIT ?`
+		args = append(args, filter.Limit)
+	}
+
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query fixings: %w", err)
+	}
+	defer rows.Close()
+
+	var fixings []*fixing.Fixing
+	for rows.Next() {
+		f, err := scanFixing(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan fixing: %w", err)
+		}
+		fixings = append(fixings, f)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating over rows: %w", err)
+	}
+
+	return fixings, nil
+}
+
+// scanFixing scans a row selected with fixingColumns
+func scanFixing(row scanner) (*fixing.Fixing, error) {
+	var (
+		f                                  fixing.Fixing
+		date, method                       string
+		windowStart, windowEnd, computedAt int64
+	)
+	err := row.Scan(
+		&f.ID,
+		&f.Name,
+		&f.Market,
+		&date,
+		&method,
+		&f.Methodology,
+		&windowStart,
+		&windowEnd,
+		&f.Ask,
+		&f.Bid,
+		&f.Mid,
+		&f.SampleCount,
+		&computedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if f.Date, err = time.Parse(time.DateOnly, date); err != nil {
+		return nil, fmt.Errorf("invalid fixing date %q: %w", date, err)
+	}
+	f.Method = fixing.Method(method)
+	f.WindowStart = fromMicros(windowStart)
+	f.WindowEnd = fromMicros(windowEnd)
+	f.ComputedAt = fromMicros(computedAt)
+	return &f, nil
+}
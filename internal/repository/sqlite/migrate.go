@@ -0,0 +1,92 @@
+package sqlite
+
+import (
+	"context"
+	"embed"
+	"fmt"
+	"io/fs"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// migrations holds the schema, applied in file name order. Files are named NNNN_description.sql
+//
+//go:embed migrations/*.sql
+var migrations embed.FS
+
+// migrate applies the embedded migrations that have not been applied yet, each in its own transaction
+func (r *Repository) migrate(ctx context.Context) error {
+	_, err := r.db.ExecContext(ctx, `
+		CREATE TABLE IF NOT EXISTS schema_migrations (
+			version INTEGER PRIMARY KEY,
+			applied_at INTEGER NOT NULL
+		)
+	`)
+	if err != nil {
+		return fmt.Errorf("failed to create schema_migrations: %w", err)
+	}
+
+	var current int
+	if err := r.db.QueryRowContext(ctx, `SELECT COALESCE(MAX(version), 0) FROM schema_migrations`).Scan(&current); err != nil {
+		return fmt.Errorf("failed to read schema version: %w", err)
+	}
+
+	names, err := fs.Glob(migrations, "migrations/*.sql")
+	if err != nil {
+		return err
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		version, err := migrationVersion(name)
+		if err != nil {
+			return err
+		}
+		if version <= current {
+			continue
+		}
+
+		script, err := migrations.ReadFile(name)
+		if err != nil {
+			return err
+		}
+		if err := r.applyMigration(ctx, version, string(script)); err != nil {
+			return fmt.Errorf("failed to apply migration %s: %w", name, err)
+		}
+		r.logger.Info("SQLite migration applied", "version", version, "file", name)
+	}
+	return nil
+}
+
+func (r *Repository) applyMigration(ctx context.Context, version int, script string) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	if _, err := tx.ExecContext(ctx, script); err != nil {
+		return err
+	}
+	if _, err := tx.ExecContext(ctx, `INSERT INTO schema_migrations (version, applied_at) VALUES (?, ?)`,
+		version, time.Now().UnixMicro()); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// migrationVersion parses the version prefix of a migration file name
+func migrationVersion(name string) (int, error) {
+	base := strings.TrimPrefix(name, "migrations/")
+	prefix, _, ok := strings.Cut(base, "_")
+	if !ok {
+		return 0, fmt.Errorf("migration %s is not named NNNN_description.sql", name)
+	}
+	version, err := strconv.Atoi(prefix)
+	if err != nil || version < 1 {
+		return 0, fmt.Errorf("migration %s has an invalid version", name)
+	}
+	return version, nil
+}
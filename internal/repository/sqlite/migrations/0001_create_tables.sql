@@ -0,0 +1,59 @@
+-- Times are Unix microseconds (UTC) and prices exact decimal strings rounded to 8 places,
+-- matching the TIMESTAMPTZ and DECIMAL(20, 8) columns of the Postgres schema
+CREATE TABLE rates (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    market TEXT NOT NULL DEFAULT 'btcusdt',
+    source TEXT NOT NULL DEFAULT 'garantex',
+    ask TEXT NOT NULL,
+    bid TEXT NOT NULL,
+    timestamp INTEGER NOT NULL,
+    received_at INTEGER NOT NULL,
+    latency_us INTEGER NOT NULL DEFAULT 0,
+    created_at INTEGER NOT NULL
+);
+
+CREATE UNIQUE INDEX idx_rates_sample ON rates(market, source, timestamp);
+CREATE INDEX idx_rates_timestamp ON rates(timestamp DESC);
+CREATE INDEX idx_rates_market_timestamp ON rates(market, timestamp DESC);
+
+CREATE TABLE order_book_snapshots (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    rate_id INTEGER,
+    market TEXT NOT NULL,
+    timestamp INTEGER NOT NULL,
+    depth INTEGER NOT NULL,
+    asks TEXT NOT NULL,
+    bids TEXT NOT NULL,
+    created_at INTEGER NOT NULL
+);
+
+CREATE INDEX idx_order_book_snapshots_market_timestamp ON order_book_snapshots(market, timestamp DESC);
+
+CREATE TABLE fixings (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    name TEXT NOT NULL,
+    market TEXT NOT NULL,
+    fixing_date TEXT NOT NULL,
+    method TEXT NOT NULL,
+    methodology TEXT NOT NULL,
+    window_start INTEGER NOT NULL,
+    window_end INTEGER NOT NULL,
+    ask TEXT NOT NULL,
+    bid TEXT NOT NULL,
+    mid TEXT NOT NULL,
+    sample_count INTEGER NOT NULL,
+    computed_at INTEGER NOT NULL,
+    UNIQUE (name, fixing_date)
+);
+
+CREATE INDEX idx_fixings_fixing_date ON fixings(fixing_date DESC);
+
+CREATE TABLE api_keys (
+    id TEXT PRIMARY KEY,
+    name TEXT NOT NULL,
+    key_hash TEXT NOT NULL,
+    scopes TEXT NOT NULL DEFAULT '[]',
+    created_by TEXT NOT NULL DEFAULT '',
+    created_at INTEGER NOT NULL,
+    revoked_at INTEGER
+);
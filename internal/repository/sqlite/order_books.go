@@ -0,0 +1,187 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/cawa87/garantex-test/internal/repository"
+	"github.com/cawa87/garantex-test/internal/service/exchange"
+)
+
+// SaveRateWithOrderBook saves a rate and its order book snapshot in one transaction and reports whether
+// the rate was new; nothing is stored for a sample that already exists
+func (r *Repository) SaveRateWithOrderBook(ctx context.Context, rate *exchange.Rate, book *exchange.OrderBookSnapshot) (bool, error) {
+	asks, err := repository.EncodeLevels(book.Asks)
+	if err != nil {
+		return false, fmt.Errorf("failed to encode asks: %w", err)
+	}
+	bids, err := repository.EncodeLevels(book.Bids)
+	if err != nil {
+		return false, fmt.Errorf("failed to encode bids: %w", err)
+	}
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return false, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	now := time.Now()
+	rateID, created, err := insertRate(ctx, tx, rate, now)
+	if err != nil {
+		return false, err
+	}
+	if !created {
+		r.logger.Debug("Rate already stored, skipping order book", "market", rate.Market, "timestamp", rate.Timestamp)
+		return false, nil
+	}
+
+	depth := len(book.Asks)
+	if len(book.Bids) > depth {
+		depth = len(book.Bids)
+	}
+
+	_, err = tx.ExecContext(ctx, `
+		INSERT INTO order_book_snapshots (rate_id, market, timestamp, depth, asks, bids, created_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?)
+	`, rateID, book.Market, book.Timestamp.UnixMicro(), depth, string(asks), string(bids), now.UnixMicro())
+	if err != nil {
+		return false, fmt.Errorf("failed to save order book: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return false, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	r.logger.Debug("Rate and order book saved to database",
+		"rate_id", rateID,
+		"market", book.Market,
+		"depth", depth,
+		"timestamp", rate.Timestamp)
+
+	return true, nil
+}
+
+// GetOrderBook retrieves the latest order book snapshot of a market taken at or before asOf;
+// a zero asOf returns the latest snapshot
+func (r *Repository) GetOrderBook(ctx context.Context, market string, asOf time.Time) (*repository.OrderBook, error) {
+	query := `
+		SELECT id, rate_id, market, timestamp, depth, asks, bids, created_at
+		FROM order_book_snapshots
+		WHERE market = ? AND (? IS NULL OR timestamp <= ?)
+		ORDER BY timestamp DESC, id DESC
+		LIMIT 1
+	`
+
+	var bound *int64
+	if !asOf.IsZero() {
+		us := asOf.UnixMicro()
+		bound = &us
+	}
+
+	var (
+		book                 repository.OrderBook
+		timestamp, createdAt int64
+		asks, bids           string
+	)
+	err := r.db.QueryRowContext(ctx, query, market, bound, bound).Scan(
+		&book.ID,
+		&book.RateID,
+		&book.Market,
+		&timestamp,
+		&book.Depth,
+		&asks,
+		&bids,
+		&createdAt,
+	)
+
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, sql.ErrNoRows
+		}
+		return nil, fmt.Errorf("failed to get order book: %w", err)
+	}
+
+	book.Timestamp = fromMicros(timestamp)
+	book.CreatedAt = fromMicros(createdAt)
+	if book.Asks, err = repository.DecodeLevels([]byte(asks)); err != nil {
+		return nil, fmt.Errorf("failed to decode asks: %w", err)
+	}
+	if book.Bids, err = repository.DecodeLevels([]byte(bids)); err != nil {
+		return nil, fmt.Errorf("failed to decode bids: %w", err)
+	}
+
+	return &book, nil
+}
+
+// GetOrderBooks retrieves the order book snapshots of a market taken in [from, to), ascending
+func (r *Repository) GetOrderBooks(ctx context.Context, market string, from, to time.Time) ([]*exchange.OrderBookSnapshot, error) {
+	query := `
+		SELECT market, timestamp, asks, bids
+		FROM order_book_snapshots
+		WHERE market = ? AND timestamp >= ? AND timestamp < ?
+		ORDER BY timestamp
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, market, from.UnixMicro(), to.UnixMicro())
+	if err != nil {
+		return nil, fmt.Errorf("failed to query order books: %w", err)
+	}
+	defer rows.Close()
+
+	var books []*exchange.OrderBookSnapshot
+	for rows.Next() {
+		var (
+			book       exchange.OrderBookSnapshot
+			timestamp  int64
+			asks, bids string
+		)
+		if err := rows.Scan(&book.Market, &timestamp, &asks, &bids); err != nil {
+			return nil, fmt.Errorf("failed to scan order book: %w", err)
+		}
+		book.Timestamp = fromMicros(timestamp)
+		if book.Asks, err = repository.DecodeLevels([]byte(asks)); err != nil {
+			return nil, fmt.Errorf("failed to decode asks: %w", err)
+		}
+		if book.Bids, err = repository.DecodeLevels([]byte(bids)); err != nil {
+			return nil, fmt.Errorf("failed to decode bids: %w", err)
+		}
+		books = append(books, &book)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating over rows: %w", err)
+	}
+
+	return books, nil
+}
+
+// DeleteOrderBooksBefore deletes snapshots older than cutoff in batches and returns the number deleted
+func (r *Repository) DeleteOrderBooksBefore(ctx context.Context, cutoff time.Time, batchSize int) (int64, error) {
+	query := `
+		DELETE FROM order_book_snapshots
+		WHERE id IN (
+			SELECT id FROM order_book_snapshots
+			WHERE timestamp < ?
+			LIMIT ?
+		)
+	`
+
+	var total int64
+	for {
+		result, err := r.db.ExecContext(ctx, query, cutoff.UnixMicro(), batchSize)
+		if err != nil {
+			return total, fmt.Errorf("failed to delete order books: %w", err)
+		}
+		n, err := result.RowsAffected()
+		if err != nil {
+			return total, fmt.Errorf("failed to delete order books: %w", err)
+		}
+		total += n
+		if n < int64(batchSize) {
+			return total, nil
+		}
+	}
+}
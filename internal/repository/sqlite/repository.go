@@ -0,0 +1,369 @@
+// Package sqlite implements the storage backend on an embedded SQLite database (pure Go, no cgo)
+// for edge deployments and local runs. Its query semantics match the postgres package
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"net/url"
+	"time"
+
+	"github.com/cawa87/garantex-test/internal/lib/logger/sl"
+	"github.com/cawa87/garantex-test/internal/repository"
+	"github.com/cawa87/garantex-test/internal/service/exchange"
+	"github.com/shopspring/decimal"
+
+	_ "modernc.org/sqlite" // registers the "sqlite" database/sql driver
+)
+
+var _ repository.Store = (*Repository)(nil)
+
+// rateColumns is the select list matching scanRate
+const rateColumns = "id, market, source, ask, bid, timestamp, received_at, latency_us, created_at"
+
+// Repository represents the SQLite repository for rate data operations
+type Repository struct {
+	db     *sql.DB
+	logger *sl.Logger
+}
+
+// scanner is implemented by *sql.Row and *sql.Rows
+type scanner interface {
+	Scan(dest ...any) error
+}
+
+// NewRepository opens (creating if needed) the database file at path and applies pending migrations
+func NewRepository(path string, logger *sl.Logger) (*Repository, error) {
+	dsn := "file:" + path + "?" + url.Values{
+		"_pragma": {"busy_timeout(5000)", "journal_mode(WAL)", "synchronous(NORMAL)"},
+	}.Encode()
+
+	db, err := sql.Open("sqlite", dsn)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open database: %w", err)
+	}
+	// SQLite allows a single writer; one connection serializes access instead of failing with SQLITE_BUSY
+	db.SetMaxOpenConns(1)
+
+	r := &Repository{
+		db:     db,
+		logger: logger,
+	}
+
+	ctx := context.Background()
+	if err := db.PingContext(ctx); err != nil {
+		_ = db.Close()
+		return nil, fmt.Errorf("failed to open database: %w", err)
+	}
+	if err := r.migrate(ctx); err != nil {
+		_ = db.Close()
+		return nil, err
+	}
+
+	return r, nil
+}
+
+// Close closes the database
+func (r *Repository) Close() {
+	if r.db != nil {
+		_ = r.db.Close()
+	}
+}
+
+// SaveRate saves a rate to the database and reports whether it was new;
+// a sample already stored for the same market, source and timestamp is skipped
+func (r *Repository) SaveRate(ctx context.Context, rate *exchange.Rate) (bool, error) {
+	_, created, err := insertRate(ctx, r.db, rate, time.Now())
+	if err != nil {
+		return false, err
+	}
+
+	r.logger.Debug("Rate saved to database",
+		"market", rate.Market,
+		"ask", rate.Ask,
+		"bid", rate.Bid,
+		"timestamp", rate.Timestamp,
+		"new", created)
+
+	return created, nil
+}
+
+// SaveRates saves rates in one transaction and returns the number of new rows
+func (r *Repository) SaveRates(ctx context.Context, rates []*exchange.Rate) (int64, error) {
+	if len(rates) == 0 {
+		return 0, nil
+	}
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	now := time.Now()
+	var n int64
+	for _, rate := range rates {
+		_, created, err := insertRate(ctx, tx, rate, now)
+		if err != nil {
+			return 0, err
+		}
+		if created {
+			n++
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return 0, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	r.logger.Debug("Rates saved to database", "count", len(rates), "new", n)
+
+	return n, nil
+}
+
+// execer is implemented by *sql.DB and *sql.Tx
+type execer interface {
+	ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error)
+}
+
+// insertRate inserts a rate unless its sample exists and returns the new row ID
+func insertRate(ctx context.Context, db execer, rate *exchange.Rate, now time.Time) (int64, bool, error) {
+	query := `
+		INSERT INTO rates (market, source, ask, bid, timestamp, received_at, latency_us, created_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?)
+		ON CONFLICT DO NOTHING
+	`
+
+	source := rate.Source
+	if source == "" {
+		source = exchange.DefaultSource
+	}
+
+	result, err := db.ExecContext(ctx, query, rate.Market, source, price(rate.Ask), price(rate.Bid),
+		rate.Timestamp.UnixMicro(), rate.ReceivedAt.UnixMicro(), rate.Latency.Microseconds(), now.UnixMicro())
+	if err != nil {
+		return 0, false, fmt.Errorf("failed to save rate: %w", err)
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil || affected == 0 {
+		return 0, false, err
+	}
+	id, err := result.LastInsertId()
+	if err != nil {
+		return 0, false, fmt.Errorf("failed to read rate id: %w", err)
+	}
+	return id, true, nil
+}
+
+// GetLatestRate retrieves the most recent rate from the database
+func (r *Repository) GetLatestRate(ctx context.Context) (*repository.Rate, error) {
+	query := `
+		SELECT ` + rateColumns + `
+		FROM rates
+		ORDER BY timestamp DESC, id DESC
+		LIMIT 1
+	`
+
+	rate, err := scanRate(r.db.QueryRowContext(ctx, query))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, sql.ErrNoRows
+		}
+		return nil, fmt.Errorf("failed to get latest rate: %w", err)
+	}
+
+	return rate, nil
+}
+
+// GetRatesByTimeRange retrieves rates within a time range
+func (r *Repository) GetRatesByTimeRange(ctx context.Context, from, to time.Time) ([]*repository.Rate, error) {
+	query := `
+		SELECT ` + rateColumns + `
+		FROM rates
+		WHERE timestamp BETWEEN ? AND ?
+		ORDER BY timestamp DESC
+	`
+
+	return r.queryRates(ctx, query, from.UnixMicro(), to.UnixMicro())
+}
+
+// GetRatesAround retrieves the latest rate of a market at or before at and the earliest rate after it.
+// Either may be nil; sql.ErrNoRows is returned when the market has no rates at all
+func (r *Repository) GetRatesAround(ctx context.Context, market string, at time.Time) (before, after *repository.Rate, err error) {
+	query := `
+		SELECT * FROM (
+			SELECT ` + rateColumns + `
+			FROM rates
+			WHERE market = ? AND timestamp <= ?
+			ORDER BY timestamp DESC
+			LIMIT 1
+		)
+		UNION ALL
+		SELECT * FROM (
+			SELECT ` + rateColumns + `
+			FROM rates
+			WHERE market = ? AND timestamp > ?
+			ORDER BY timestamp ASC
+			LIMIT 1
+		)
+	`
+
+	ts := at.UnixMicro()
+	rates, err := r.queryRates(ctx, query, market, ts, market, ts)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	for _, rate := range rates {
+		if rate.Timestamp.After(at) {
+			after = rate
+		} else {
+			before = rate
+		}
+	}
+
+	if before == nil && after == nil {
+		return nil, nil, sql.ErrNoRows
+	}
+
+	return before, after, nil
+}
+
+// GetRatesCount returns the total number of rates in the database
+func (r *Repository) GetRatesCount(ctx context.Context) (int64, error) {
+	var count int64
+	if err := r.db.QueryRowContext(ctx, `SELECT COUNT(*) FROM rates`).Scan(&count); err != nil {
+		return 0, fmt.Errorf("failed to get rates count: %w", err)
+	}
+	return count, nil
+}
+
+// GetCandles aggregates the rates of a market in [from, to) into candles of interval, ascending.
+// Prices are stored as text, so the rates are folded in Go to keep exact decimal comparisons
+func (r *Repository) GetCandles(ctx context.Context, market string, interval time.Duration, from, to time.Time) ([]*repository.Candle, error) {
+	if err := repository.CheckCandleInterval(interval); err != nil {
+		return nil, err
+	}
+
+	query := `
+		SELECT ` + rateColumns + `
+		FROM rates
+		WHERE market = ? AND timestamp >= ? AND timestamp < ?
+		ORDER BY timestamp, id
+	`
+
+	rates, err := r.queryRates(ctx, query, market, from.UnixMicro(), to.UnixMicro())
+	if err != nil {
+		return nil, err
+	}
+
+	return repository.BuildCandles(rates, interval), nil
+}
+
+// StreamRates calls fn with each rate of a market in [from, to), ascending, as rows are read
+func (r *Repository) StreamRates(ctx context.Context, market string, from, to time.Time, fn func(*repository.Rate) error) error {
+	query := `
+		SELECT ` + rateColumns + `
+		FROM rates
+		WHERE market = ? AND timestamp >= ? AND timestamp < ?
+		ORDER BY timestamp, id
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, market, from.UnixMicro(), to.UnixMicro())
+	if err != nil {
+		return fmt.Errorf("failed to query rates: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		rate, err := scanRate(rows)
+		if err != nil {
+			return fmt.Errorf("failed to scan rate: %w", err)
+		}
+		if err := fn(rate); err != nil {
+			return err
+		}
+	}
+
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("error iterating over rows: %w", err)
+	}
+	return nil
+}
+
+// StreamCandles calls fn with each candle of a market over the rates in [from, to), ascending,
+// folding the rates as they are read
+func (r *Repository) StreamCandles(ctx context.Context, market string, interval time.Duration, from, to time.Time, fn func(*repository.Candle) error) error {
+	if err := repository.CheckCandleInterval(interval); err != nil {
+		return err
+	}
+
+	builder := repository.CandleBuilder{Interval: interval, Emit: fn}
+	if err := r.StreamRates(ctx, market, from, to, builder.Add); err != nil {
+		return err
+	}
+	return builder.Flush()
+}
+
+func (r *Repository) queryRates(ctx context.Context, query string, args ...any) ([]*repository.Rate, error) {
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query rates: %w", err)
+	}
+	defer rows.Close()
+
+	var rates []*repository.Rate
+	for rows.Next() {
+		rate, err := scanRate(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan rate: %w", err)
+		}
+		rates = append(rates, rate)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating over rows: %w", err)
+	}
+
+	return rates, nil
+}
+
+// scanRate scans a row selected with rateColumns
+func scanRate(row scanner) (*repository.Rate, error) {
+	var (
+		rate                                      repository.Rate
+		timestamp, receivedAt, latency, createdAt int64
+	)
+	err := row.Scan(
+		&rate.ID,
+		&rate.Market,
+		&rate.Source,
+		&rate.Ask,
+		&rate.Bid,
+		&timestamp,
+		&receivedAt,
+		&latency,
+		&createdAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	rate.Timestamp = fromMicros(timestamp)
+	rate.ReceivedAt = fromMicros(receivedAt)
+	rate.Latency = time.Duration(latency) * time.Microsecond
+	rate.CreatedAt = fromMicros(createdAt)
+	return &rate, nil
+}
+
+// price rounds a price to the stored scale and returns its exact text form
+func price(d decimal.Decimal) string {
+	return d.Round(repository.PriceScale).String()
+}
+
+// fromMicros converts a stored Unix microsecond time
+func fromMicros(us int64) time.Time {
+	return time.UnixMicro(us).UTC()
+}
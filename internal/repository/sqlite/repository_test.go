@@ -0,0 +1,57 @@
+package sqlite
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/cawa87/garantex-test/internal/lib/logger/sl"
+	"github.com/cawa87/garantex-test/internal/repository"
+	"github.com/cawa87/garantex-test/internal/repository/storetest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestRepository(t *testing.T) *Repository {
+	logger, err := sl.New("info")
+	require.NoError(t, err)
+
+	repo, err := NewRepository(filepath.Join(t.TempDir(), "rates.db"), logger)
+	require.NoError(t, err)
+	t.Cleanup(repo.Close)
+	return repo
+}
+
+func TestConformance(t *testing.T) {
+	storetest.Run(t, func(t *testing.T) repository.Store {
+		return newTestRepository(t)
+	})
+}
+
+func TestMigrationsAreIdempotent(t *testing.T) {
+	logger, err := sl.New("info")
+	require.NoError(t, err)
+
+	path := filepath.Join(t.TempDir(), "rates.db")
+	repo, err := NewRepository(path, logger)
+	require.NoError(t, err)
+	repo.Close()
+
+	// Reopening applies nothing and keeps the schema
+	repo, err = NewRepository(path, logger)
+	require.NoError(t, err)
+	defer repo.Close()
+
+	var version int
+	require.NoError(t, repo.db.QueryRowContext(context.Background(), `SELECT MAX(version) FROM schema_migrations`).Scan(&version))
+	assert.Equal(t, 1, version)
+}
+
+func TestMigrationVersion(t *testing.T) {
+	version, err := migrationVersion("migrations/0012_add_index.sql")
+	require.NoError(t, err)
+	assert.Equal(t, 12, version)
+
+	_, err = migrationVersion("migrations/init.sql")
+	assert.Error(t, err)
+}
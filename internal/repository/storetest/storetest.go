@@ -0,0 +1,471 @@
+// Package storetest is a conformance suite that every repository.Store implementation must pass
+package storetest
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/cawa87/garantex-test/internal/repository"
+	"github.com/cawa87/garantex-test/internal/service/auth"
+	"github.com/cawa87/garantex-test/internal/service/exchange"
+	"github.com/cawa87/garantex-test/internal/service/fixing"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// Factory returns an empty store for one subtest; it should register its own cleanup with t.Cleanup
+type Factory func(t *testing.T) repository.Store
+
+// Run runs the conformance suite against stores created by newStore
+func Run(t *testing.T, newStore Factory) {
+	t.Run("SaveRate", func(t *testing.T) { testSaveRate(t, newStore(t)) })
+	t.Run("SaveRates", func(t *testing.T) { testSaveRates(t, newStore(t)) })
+	t.Run("LatestAndRange", func(t *testing.T) { testLatestAndRange(t, newStore(t)) })
+	t.Run("RatesAround", func(t *testing.T) { testRatesAround(t, newStore(t)) })
+	t.Run("Candles", func(t *testing.T) { testCandles(t, newStore(t)) })
+	t.Run("Streams", func(t *testing.T) { testStreams(t, newStore(t)) })
+	t.Run("OrderBooks", func(t *testing.T) { testOrderBooks(t, newStore(t)) })
+	t.Run("Ticks", func(t *testing.T) { testTicks(t, newStore(t)) })
+	t.Run("Fixings", func(t *testing.T) { testFixings(t, newStore(t)) })
+	t.Run("APIKeys", func(t *testing.T) { testAPIKeys(t, newStore(t)) })
+}
+
+// base is a fixed, microsecond-aligned instant so every backend round-trips it exactly
+var base = time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+
+func newRate(market string, ask string, ts time.Time) *exchange.Rate {
+	price := decimal.RequireFromString(ask)
+	return &exchange.Rate{
+		Market:     market,
+		Ask:        price,
+		Bid:        price.Sub(decimal.NewFromInt(1)),
+		Timestamp:  ts,
+		ReceivedAt: ts.Add(time.Second),
+		Latency:    25 * time.Millisecond,
+	}
+}
+
+func testSaveRate(t *testing.T, store repository.Store) {
+	ctx := context.Background()
+
+	_, err := store.GetLatestRate(ctx)
+	assert.Equal(t, sql.ErrNoRows, err)
+
+	rate := newRate("btcusdt", "100.123456789", base)
+	created, err := store.SaveRate(ctx, rate)
+	require.NoError(t, err)
+	assert.True(t, created)
+
+	// The same sample is stored once; another source is another sample
+	created, err = store.SaveRate(ctx, rate)
+	require.NoError(t, err)
+	assert.False(t, created)
+
+	other := *rate
+	other.Source = "backfill"
+	created, err = store.SaveRate(ctx, &other)
+	require.NoError(t, err)
+	assert.True(t, created)
+
+	count, err := store.GetRatesCount(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), count)
+
+	saved, err := store.GetLatestRate(ctx)
+	require.NoError(t, err)
+	assert.Positive(t, saved.ID)
+	assert.Equal(t, "btcusdt", saved.Market)
+	assert.True(t, base.Equal(saved.Timestamp))
+	assert.True(t, base.Add(time.Second).Equal(saved.ReceivedAt))
+	assert.Equal(t, 25*time.Millisecond, saved.Latency)
+	assert.True(t, decimal.RequireFromString("100.12345679").Equal(saved.Ask), "prices are stored with 8 places")
+	assert.False(t, saved.CreatedAt.IsZero())
+}
+
+func testSaveRates(t *testing.T, store repository.Store) {
+	ctx := context.Background()
+
+	n, err := store.SaveRates(ctx, nil)
+	require.NoError(t, err)
+	assert.Equal(t, int64(0), n)
+
+	rates := []*exchange.Rate{
+		newRate("btcusdt", "100", base),
+		newRate("ethusdt", "10", base),
+		newRate("btcusdt", "101", base.Add(time.Second)),
+	}
+	n, err = store.SaveRates(ctx, rates)
+	require.NoError(t, err)
+	assert.Equal(t, int64(3), n)
+
+	// A retried batch only stores new samples
+	rates = append(rates, newRate("btcusdt", "102", base.Add(2*time.Second)))
+	n, err = store.SaveRates(ctx, rates)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), n)
+
+	count, err := store.GetRatesCount(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, int64(4), count)
+
+	latest, err := store.GetLatestRate(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, exchange.DefaultSource, latest.Source)
+}
+
+func testLatestAndRange(t *testing.T, store repository.Store) {
+	ctx := context.Background()
+
+	for i, ask := range []string{"100", "101", "102", "103"} {
+		_, err := store.SaveRate(ctx, newRate("btcusdt", ask, base.Add(time.Duration(i)*time.Minute)))
+		require.NoError(t, err)
+	}
+	// Saved out of order; the latest is by timestamp, not insertion
+	_, err := store.SaveRate(ctx, newRate("ethusdt", "10", base.Add(-time.Hour)))
+	require.NoError(t, err)
+
+	latest, err := store.GetLatestRate(ctx)
+	require.NoError(t, err)
+	assert.True(t, decimal.NewFromInt(103).Equal(latest.Ask))
+
+	// Both bounds are inclusive, newest first
+	rates, err := store.GetRatesByTimeRange(ctx, base.Add(time.Minute), base.Add(3*time.Minute))
+	require.NoError(t, err)
+	require.Len(t, rates, 3)
+	assert.True(t, decimal.NewFromInt(103).Equal(rates[0].Ask))
+	assert.True(t, decimal.NewFromInt(101).Equal(rates[2].Ask))
+
+	rates, err = store.GetRatesByTimeRange(ctx, base.Add(time.Hour), base.Add(2*time.Hour))
+	require.NoError(t, err)
+	assert.Empty(t, rates)
+}
+
+func testRatesAround(t *testing.T, store repository.Store) {
+	ctx := context.Background()
+
+	_, _, err := store.GetRatesAround(ctx, "btcusdt", base)
+	assert.Equal(t, sql.ErrNoRows, err)
+
+	for _, rate := range []*exchange.Rate{
+		newRate("btcusdt", "100", base.Add(-time.Minute)),
+		newRate("btcusdt", "101", base),
+		newRate("btcusdt", "102", base.Add(time.Minute)),
+		newRate("ethusdt", "10", base.Add(30*time.Second)),
+	} {
+		_, err := store.SaveRate(ctx, rate)
+		require.NoError(t, err)
+	}
+
+	// A sample exactly at the instant counts as before
+	before, after, err := store.GetRatesAround(ctx, "btcusdt", base)
+	require.NoError(t, err)
+	require.NotNil(t, before)
+	require.NotNil(t, after)
+	assert.True(t, decimal.NewFromInt(101).Equal(before.Ask))
+	assert.True(t, decimal.NewFromInt(102).Equal(after.Ask))
+
+	before, after, err = store.GetRatesAround(ctx, "btcusdt", base.Add(time.Hour))
+	require.NoError(t, err)
+	assert.True(t, decimal.NewFromInt(102).Equal(before.Ask))
+	assert.Nil(t, after)
+
+	before, after, err = store.GetRatesAround(ctx, "btcusdt", base.Add(-time.Hour))
+	require.NoError(t, err)
+	assert.Nil(t, before)
+	assert.True(t, decimal.NewFromInt(100).Equal(after.Ask))
+}
+
+func testCandles(t *testing.T, store repository.Store) {
+	ctx := context.Background()
+
+	for _, rate := range []*exchange.Rate{
+		newRate("btcusdt", "100", base),
+		newRate("btcusdt", "105", base.Add(20*time.Second)),
+		newRate("btcusdt", "98", base.Add(40*time.Second)),
+		newRate("btcusdt", "101", base.Add(59*time.Second)),
+		newRate("btcusdt", "110", base.Add(3*time.Minute)),
+		newRate("btcusdt", "120", base.Add(5*time.Minute)),
+		newRate("ethusdt", "10", base.Add(time.Second)),
+	} {
+		_, err := store.SaveRate(ctx, rate)
+		require.NoError(t, err)
+	}
+
+	// Empty intervals are skipped and the end of the range is exclusive
+	candles, err := store.GetCandles(ctx, "btcusdt", time.Minute, base, base.Add(5*time.Minute))
+	require.NoError(t, err)
+	require.Len(t, candles, 2)
+
+	first := candles[0]
+	assert.Equal(t, "btcusdt", first.Market)
+	assert.True(t, base.Equal(first.Start))
+	assert.True(t, decimal.NewFromInt(100).Equal(first.AskOpen))
+	assert.True(t, decimal.NewFromInt(105).Equal(first.AskHigh))
+	assert.True(t, decimal.NewFromInt(98).Equal(first.AskLow))
+	assert.True(t, decimal.NewFromInt(101).Equal(first.AskClose))
+	assert.True(t, decimal.NewFromInt(97).Equal(first.BidLow))
+	assert.True(t, decimal.NewFromInt(100).Equal(first.BidClose))
+	assert.Equal(t, int64(4), first.Samples)
+	assert.True(t, base.Add(3*time.Minute).Equal(candles[1].Start))
+
+	// Candles are aligned to the epoch, not to from
+	candles, err = store.GetCandles(ctx, "btcusdt", time.Hour, base.Add(30*time.Second), base.Add(time.Hour))
+	require.NoError(t, err)
+	require.Len(t, candles, 1)
+	assert.True(t, base.Equal(candles[0].Start))
+	assert.True(t, decimal.NewFromInt(98).Equal(candles[0].AskOpen))
+	assert.Equal(t, int64(4), candles[0].Samples)
+
+	_, err = store.GetCandles(ctx, "btcusdt", 1500*time.Millisecond, base, base.Add(time.Hour))
+	assert.Error(t, err)
+}
+
+func testStreams(t *testing.T, store repository.Store) {
+	ctx := context.Background()
+
+	// More rates than one postgres cursor fetch, saved out of order
+	const count = 2500
+	rates := make([]*exchange.Rate, 0, count+1)
+	for i := count - 1; i >= 0; i-- {
+		rates = append(rates, newRate("btcusdt", "100", base.Add(time.Duration(i)*time.Second)))
+	}
+	rates = append(rates, newRate("ethusdt", "10", base))
+	_, err := store.SaveRates(ctx, rates)
+	require.NoError(t, err)
+
+	var (
+		streamed int
+		last     time.Time
+	)
+	err = store.StreamRates(ctx, "btcusdt", base, base.Add(count*time.Second), func(rate *repository.Rate) error {
+		assert.Equal(t, "btcusdt", rate.Market)
+		assert.False(t, rate.Timestamp.Before(last), "rates must be ascending")
+		last = rate.Timestamp
+		streamed++
+		return nil
+	})
+	require.NoError(t, err)
+	assert.Equal(t, count, streamed)
+
+	// An error from fn stops the stream
+	stop := errors.New("stop")
+	streamed = 0
+	err = store.StreamRates(ctx, "btcusdt", base, base.Add(count*time.Second), func(*repository.Rate) error {
+		streamed++
+		return stop
+	})
+	assert.ErrorIs(t, err, stop)
+	assert.Equal(t, 1, streamed)
+
+	var candles []*repository.Candle
+	err = store.StreamCandles(ctx, "btcusdt", time.Minute, base, base.Add(count*time.Second), func(c *repository.Candle) error {
+		candles = append(candles, c)
+		return nil
+	})
+	require.NoError(t, err)
+	require.Len(t, candles, (count+59)/60)
+	assert.True(t, base.Equal(candles[0].Start))
+	assert.Equal(t, int64(60), candles[0].Samples)
+	assert.Equal(t, int64(count%60), candles[len(candles)-1].Samples)
+
+	stored, err := store.GetCandles(ctx, "btcusdt", time.Minute, base, base.Add(count*time.Second))
+	require.NoError(t, err)
+	assert.Equal(t, stored, candles)
+
+	err = store.StreamCandles(ctx, "btcusdt", 0, base, base.Add(time.Hour), func(*repository.Candle) error { return nil })
+	assert.Error(t, err)
+}
+
+func testOrderBooks(t *testing.T, store repository.Store) {
+	ctx := context.Background()
+
+	_, err := store.GetOrderBook(ctx, "btcusdt", time.Time{})
+	assert.Equal(t, sql.ErrNoRows, err)
+
+	for i, ts := range []time.Time{base, base.Add(time.Minute)} {
+		book := &exchange.OrderBookSnapshot{
+			Market:    "btcusdt",
+			Timestamp: ts,
+			Asks: []exchange.PriceLevel{
+				{Price: decimal.RequireFromString("100.5"), Volume: decimal.RequireFromString("1.25")},
+				{Price: decimal.RequireFromString("100.75"), Volume: decimal.NewFromInt(int64(i + 1))},
+			},
+			Bids: []exchange.PriceLevel{
+				{Price: decimal.RequireFromString("99.5"), Volume: decimal.RequireFromString("0.00000001")},
+			},
+		}
+		created, err := store.SaveRateWithOrderBook(ctx, newRate("btcusdt", "100.5", ts), book)
+		require.NoError(t, err)
+		assert.True(t, created)
+	}
+
+	// A known sample stores neither the rate nor the snapshot
+	created, err := store.SaveRateWithOrderBook(ctx, newRate("btcusdt", "1", base),
+		&exchange.OrderBookSnapshot{Market: "btcusdt", Timestamp: base})
+	require.NoError(t, err)
+	assert.False(t, created)
+
+	latest, err := store.GetOrderBook(ctx, "btcusdt", time.Time{})
+	require.NoError(t, err)
+	assert.True(t, base.Add(time.Minute).Equal(latest.Timestamp))
+	assert.Equal(t, 2, latest.Depth)
+	require.NotNil(t, latest.RateID)
+	require.Len(t, latest.Asks, 2)
+	assert.True(t, decimal.NewFromInt(2).Equal(latest.Asks[1].Volume))
+	assert.True(t, decimal.RequireFromString("0.00000001").Equal(latest.Bids[0].Volume))
+
+	rates, err := store.GetRatesByTimeRange(ctx, latest.Timestamp, latest.Timestamp)
+	require.NoError(t, err)
+	require.Len(t, rates, 1)
+	assert.Equal(t, rates[0].ID, *latest.RateID)
+
+	asOf, err := store.GetOrderBook(ctx, "btcusdt", base.Add(30*time.Second))
+	require.NoError(t, err)
+	assert.True(t, base.Equal(asOf.Timestamp))
+
+	_, err = store.GetOrderBook(ctx, "ethusdt", time.Time{})
+	assert.Equal(t, sql.ErrNoRows, err)
+
+	books, err := store.GetOrderBooks(ctx, "btcusdt", base, base.Add(time.Minute))
+	require.NoError(t, err)
+	require.Len(t, books, 1, "the end of the range is exclusive")
+	assert.True(t, base.Equal(books[0].Timestamp))
+
+	deleted, err := store.DeleteOrderBooksBefore(ctx, base.Add(time.Minute), 1)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), deleted)
+
+	// Rates are kept when their snapshots are pruned
+	count, err := store.GetRatesCount(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), count)
+}
+
+func testTicks(t *testing.T, store repository.Store) {
+	ctx := context.Background()
+	from, to := base, base.Add(15*time.Minute)
+
+	for _, rate := range []*exchange.Rate{
+		newRate("btcusdt", "99", from.Add(-2*time.Minute)),
+		newRate("btcusdt", "100", from.Add(-time.Minute)),
+		newRate("btcusdt", "101", from.Add(5*time.Minute)),
+		newRate("btcusdt", "102", from.Add(10*time.Minute)),
+		newRate("btcusdt", "103", to),
+		newRate("ethusdt", "10", from.Add(time.Minute)),
+	} {
+		_, err := store.SaveRate(ctx, rate)
+		require.NoError(t, err)
+	}
+
+	// The tick in force at from, then the ticks in [from, to), ascending
+	ticks, err := store.GetTicks(ctx, "btcusdt", from, to)
+	require.NoError(t, err)
+	require.Len(t, ticks, 3)
+	assert.True(t, decimal.NewFromInt(100).Equal(ticks[0].Ask))
+	assert.True(t, decimal.NewFromInt(101).Equal(ticks[1].Ask))
+	assert.True(t, decimal.NewFromInt(102).Equal(ticks[2].Ask))
+
+	ticks, err = store.GetTicks(ctx, "ltcusdt", from, to)
+	require.NoError(t, err)
+	assert.Empty(t, ticks)
+}
+
+func testFixings(t *testing.T, store repository.Store) {
+	ctx := context.Background()
+	date := time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+
+	newFixing := func(name string, date time.Time, mid string) *fixing.Fixing {
+		return &fixing.Fixing{
+			Name:        name,
+			Market:      "btcusdt",
+			Date:        date,
+			Method:      fixing.MethodTWAP,
+			Methodology: "TWAP",
+			WindowStart: date.Add(8*time.Hour + 45*time.Minute),
+			WindowEnd:   date.Add(9 * time.Hour),
+			Ask:         decimal.RequireFromString(mid).Add(decimal.NewFromInt(1)),
+			Bid:         decimal.RequireFromString(mid).Sub(decimal.NewFromInt(1)),
+			Mid:         decimal.RequireFromString(mid),
+			SampleCount: 15,
+			ComputedAt:  base,
+		}
+	}
+
+	_, err := store.GetFixing(ctx, "msk-noon", date)
+	assert.Equal(t, sql.ErrNoRows, err)
+
+	first := newFixing("msk-noon", date, "100")
+	require.NoError(t, store.SaveFixing(ctx, first))
+	assert.Positive(t, first.ID)
+
+	// Recomputing replaces the stored fixing and keeps its ID
+	again := newFixing("msk-noon", date, "101")
+	require.NoError(t, store.SaveFixing(ctx, again))
+	assert.Equal(t, first.ID, again.ID)
+
+	stored, err := store.GetFixing(ctx, "msk-noon", date)
+	require.NoError(t, err)
+	assert.True(t, decimal.NewFromInt(101).Equal(stored.Mid))
+	assert.Equal(t, fixing.MethodTWAP, stored.Method)
+	assert.Equal(t, 15, stored.SampleCount)
+	assert.True(t, date.Add(9*time.Hour).Equal(stored.WindowEnd))
+
+	require.NoError(t, store.SaveFixing(ctx, newFixing("ny-close", date, "102")))
+	require.NoError(t, store.SaveFixing(ctx, newFixing("msk-noon", date.AddDate(0, 0, 1), "103")))
+
+	// Newest date first, then by name
+	all, err := store.ListFixings(ctx, fixing.ListFilter{})
+	require.NoError(t, err)
+	require.Len(t, all, 3)
+	assert.True(t, date.AddDate(0, 0, 1).Equal(all[0].Date))
+	assert.Equal(t, "msk-noon", all[1].Name)
+	assert.Equal(t, "ny-close", all[2].Name)
+
+	byName, err := store.ListFixings(ctx, fixing.ListFilter{Name: "msk-noon", To: date})
+	require.NoError(t, err)
+	require.Len(t, byName, 1)
+	assert.True(t, date.Equal(byName[0].Date))
+
+	limited, err := store.ListFixings(ctx, fixing.ListFilter{From: date, Limit: 2})
+	require.NoError(t, err)
+	assert.Len(t, limited, 2)
+
+	none, err := store.ListFixings(ctx, fixing.ListFilter{Market: "ethusdt"})
+	require.NoError(t, err)
+	assert.Empty(t, none)
+}
+
+func testAPIKeys(t *testing.T, store repository.Store) {
+	ctx := context.Background()
+
+	key, _, err := auth.GenerateAPIKey("reporting", []string{auth.ScopeRatesRead, auth.ScopeHistoryRead}, "cli")
+	require.NoError(t, err)
+	require.NoError(t, store.CreateAPIKey(ctx, key))
+	assert.Error(t, store.CreateAPIKey(ctx, key), "key IDs are unique")
+
+	stored, err := store.GetAPIKey(ctx, key.ID)
+	require.NoError(t, err)
+	assert.Equal(t, key.Name, stored.Name)
+	assert.Equal(t, key.Hash, stored.Hash)
+	assert.Equal(t, key.Scopes, stored.Scopes)
+	assert.Nil(t, stored.RevokedAt)
+
+	revokedAt, err := store.RevokeAPIKey(ctx, key.ID)
+	require.NoError(t, err)
+
+	stored, err = store.GetAPIKey(ctx, key.ID)
+	require.NoError(t, err)
+	require.NotNil(t, stored.RevokedAt)
+	assert.True(t, revokedAt.Equal(*stored.RevokedAt))
+
+	// Revoking twice reports not found
+	_, err = store.RevokeAPIKey(ctx, key.ID)
+	assert.Equal(t, sql.ErrNoRows, err)
+
+	_, err = store.GetAPIKey(ctx, "missing")
+	assert.Equal(t, sql.ErrNoRows, err)
+}
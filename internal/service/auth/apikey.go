@@ -0,0 +1,145 @@
+package auth
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"database/sql"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+)
+
+// APIKey is a stored API key. Only the SHA-256 hash of the secret is persisted
+type APIKey struct {
+	ID        string
+	Name      string
+	Hash      string
+	Scopes    []string
+	CreatedBy string
+	CreatedAt time.Time
+	RevokedAt *time.Time
+}
+
+// KeyStore loads API keys by ID
+type KeyStore interface {
+	GetAPIKey(ctx context.Context, id string) (*APIKey, error)
+}
+
+// GenerateAPIKey creates a new key record and returns it together with the plaintext key,
+// which has the form "<id>.<secret>" and is shown to the caller only once
+func GenerateAPIKey(name string, scopes []string, createdBy string) (*APIKey, string, error) {
+	idBytes := make([]byte, 6)
+	if _, err := rand.Read(idBytes); err != nil {
+		return nil, "", fmt.Errorf("failed to generate key id: %w", err)
+	}
+	secretBytes := make([]byte, 32)
+	if _, err := rand.Read(secretBytes); err != nil {
+		return nil, "", fmt.Errorf("failed to generate key secret: %w", err)
+	}
+
+	id := hex.EncodeToString(idBytes)
+	secret := base64.RawURLEncoding.EncodeToString(secretBytes)
+
+	key := &APIKey{
+		ID:        id,
+		Name:      name,
+		Hash:      hashSecret(secret),
+		Scopes:    scopes,
+		CreatedBy: createdBy,
+		CreatedAt: time.Now(),
+	}
+
+	return key, id + "." + secret, nil
+}
+
+func hashSecret(secret string) string {
+	sum := sha256.Sum256([]byte(secret))
+	return hex.EncodeToString(sum[:])
+}
+
+type cachedKey struct {
+	key      *APIKey
+	loadedAt time.Time
+}
+
+// KeyVerifier verifies presented API keys against a KeyStore with a short-lived cache
+type KeyVerifier struct {
+	store    KeyStore
+	cacheTTL time.Duration
+
+	mu    sync.Mutex
+	cache map[string]cachedKey
+}
+
+// NewKeyVerifier creates a verifier; revocations on other replicas take effect within cacheTTL
+func NewKeyVerifier(store KeyStore, cacheTTL time.Duration) *KeyVerifier {
+	return &KeyVerifier{
+		store:    store,
+		cacheTTL: cacheTTL,
+		cache:    make(map[string]cachedKey),
+	}
+}
+
+// Verify checks the plaintext key and returns the principal it belongs to
+func (v *KeyVerifier) Verify(ctx context.Context, presented string) (*Principal, error) {
+	id, secret, ok := strings.Cut(presented, ".")
+	if !ok || id == "" || secret == "" {
+		return nil, fmt.Errorf("%w: malformed API key", ErrUnauthenticated)
+	}
+
+	key, err := v.lookup(ctx, id)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, fmt.Errorf("%w: unknown API key", ErrUnauthenticated)
+		}
+		return nil, fmt.Errorf("failed to load API key: %w", err)
+	}
+
+	if subtle.ConstantTimeCompare([]byte(hashSecret(secret)), []byte(key.Hash)) != 1 {
+		return nil, fmt.Errorf("%w: invalid API key", ErrUnauthenticated)
+	}
+	if key.RevokedAt != nil {
+		return nil, fmt.Errorf("%w: API key revoked", ErrUnauthenticated)
+	}
+
+	return &Principal{
+		Kind:    KindAPIKey,
+		Subject: key.ID,
+		Scopes:  key.Scopes,
+	}, nil
+}
+
+// Invalidate drops the cached key with the given ID
+func (v *KeyVerifier) Invalidate(id string) {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+
+	delete(v.cache, id)
+}
+
+func (v *KeyVerifier) lookup(ctx context.Context, id string) (*APIKey, error) {
+	v.mu.Lock()
+	cached, ok := v.cache[id]
+	v.mu.Unlock()
+
+	if ok && time.Since(cached.loadedAt) < v.cacheTTL {
+		return cached.key, nil
+	}
+
+	key, err := v.store.GetAPIKey(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	v.mu.Lock()
+	v.cache[id] = cachedKey{key: key, loadedAt: time.Now()}
+	v.mu.Unlock()
+
+	return key, nil
+}
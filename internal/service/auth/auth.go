@@ -0,0 +1,137 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"fmt"
+)
+
+// Supported scopes
+const (
+	ScopeRatesRead   = "rates:read"
+	ScopeHistoryRead = "history:read"
+	ScopeAdmin       = "admin"
+)
+
+// Principal kinds
+const (
+	KindAPIKey     = "api_key"
+	KindJWT        = "jwt"
+	KindClientCert = "client_cert"
+)
+
+var (
+	// ErrUnauthenticated is returned when credentials are missing, malformed or invalid
+	ErrUnauthenticated = errors.New("unauthenticated")
+	// ErrPermissionDenied is returned when an authenticated caller lacks the required scope
+	ErrPermissionDenied = errors.New("permission denied")
+)
+
+// Principal is an authenticated caller
+type Principal struct {
+	Kind    string
+	Subject string
+	Scopes  []string
+}
+
+// Identity returns a stable "<kind>:<subject>" identifier used for logging and rate limiting
+func (p *Principal) Identity() string {
+	switch p.Kind {
+	case KindAPIKey:
+		return "key:" + p.Subject
+	case KindClientCert:
+		return "cn:" + p.Subject
+	default:
+		return p.Kind + ":" + p.Subject
+	}
+}
+
+// HasScope reports whether the principal holds scope; the admin scope grants every scope
+func (p *Principal) HasScope(scope string) bool {
+	for _, s := range p.Scopes {
+		if s == scope || s == ScopeAdmin {
+			return true
+		}
+	}
+	return false
+}
+
+// Credentials are the raw credentials presented by a caller.
+// ClientCertSubject is the common name of a client certificate verified during the TLS handshake
+type Credentials struct {
+	APIKey            string
+	BearerToken       string
+	ClientCertSubject string
+}
+
+// Authenticator verifies API keys, JWTs and mTLS client certificates
+type Authenticator struct {
+	keys       *KeyVerifier
+	jwt        *JWTVerifier
+	certScopes map[string][]string
+}
+
+// NewAuthenticator creates an authenticator; either verifier may be nil to disable that method.
+// certScopes maps client certificate common names to their scopes
+func NewAuthenticator(keys *KeyVerifier, jwt *JWTVerifier, certScopes map[string][]string) *Authenticator {
+	return &Authenticator{
+		keys:       keys,
+		jwt:        jwt,
+		certScopes: certScopes,
+	}
+}
+
+// Authenticate resolves credentials to a principal, preferring an API key, then a bearer token,
+// then the client certificate. It returns a nil principal when no credentials were presented
+func (a *Authenticator) Authenticate(ctx context.Context, creds Credentials) (*Principal, error) {
+	switch {
+	case creds.APIKey != "":
+		if a.keys == nil {
+			return nil, fmt.Errorf("%w: API keys are not accepted", ErrUnauthenticated)
+		}
+		return a.keys.Verify(ctx, creds.APIKey)
+	case creds.BearerToken != "":
+		if a.jwt == nil {
+			return nil, fmt.Errorf("%w: bearer tokens are not accepted", ErrUnauthenticated)
+		}
+		return a.jwt.Verify(creds.BearerToken)
+	case creds.ClientCertSubject != "":
+		return &Principal{
+			Kind:    KindClientCert,
+			Subject: creds.ClientCertSubject,
+			Scopes:  a.certScopes[creds.ClientCertSubject],
+		}, nil
+	default:
+		return nil, nil
+	}
+}
+
+// InvalidateKey drops a cached API key, e.g. after it has been revoked
+func (a *Authenticator) InvalidateKey(id string) {
+	if a.keys != nil {
+		a.keys.Invalidate(id)
+	}
+}
+
+type principalKey struct{}
+
+// WithPrincipal returns a context carrying the principal
+func WithPrincipal(ctx context.Context, p *Principal) context.Context {
+	return context.WithValue(ctx, principalKey{}, p)
+}
+
+// PrincipalFromContext returns the principal stored in ctx, if any
+func PrincipalFromContext(ctx context.Context) (*Principal, bool) {
+	p, ok := ctx.Value(principalKey{}).(*Principal)
+	return p, ok && p != nil
+}
+
+// ValidScope reports whether scope is one of the supported scopes
+func ValidScope(scope string) bool {
+	switch scope {
+	case ScopeRatesRead, ScopeHistoryRead, ScopeAdmin:
+		return true
+	default:
+		return false
+	}
+}
@@ -0,0 +1,163 @@
+package auth
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"database/sql"
+	"encoding/base64"
+	"encoding/json"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type memoryKeyStore map[string]*APIKey
+
+func (m memoryKeyStore) GetAPIKey(_ context.Context, id string) (*APIKey, error) {
+	key, ok := m[id]
+	if !ok {
+		return nil, sql.ErrNoRows
+	}
+	return key, nil
+}
+
+func TestKeyVerifier(t *testing.T) {
+	key, plaintext, err := GenerateAPIKey("reporting", []string{ScopeRatesRead}, "cli")
+	require.NoError(t, err)
+	assert.NotContains(t, key.Hash, plaintext)
+
+	store := memoryKeyStore{key.ID: key}
+	verifier := NewKeyVerifier(store, time.Minute)
+	ctx := context.Background()
+
+	principal, err := verifier.Verify(ctx, plaintext)
+	require.NoError(t, err)
+	assert.Equal(t, KindAPIKey, principal.Kind)
+	assert.Equal(t, key.ID, principal.Subject)
+	assert.True(t, principal.HasScope(ScopeRatesRead))
+	assert.False(t, principal.HasScope(ScopeHistoryRead))
+
+	_, err = verifier.Verify(ctx, key.ID+".wrong")
+	assert.ErrorIs(t, err, ErrUnauthenticated)
+
+	_, err = verifier.Verify(ctx, "malformed")
+	assert.ErrorIs(t, err, ErrUnauthenticated)
+
+	_, err = verifier.Verify(ctx, "unknown.secret")
+	assert.ErrorIs(t, err, ErrUnauthenticated)
+
+	revokedAt := time.Now()
+	store[key.ID] = &APIKey{ID: key.ID, Hash: key.Hash, Scopes: key.Scopes, RevokedAt: &revokedAt}
+	verifier.Invalidate(key.ID)
+
+	_, err = verifier.Verify(ctx, plaintext)
+	assert.ErrorIs(t, err, ErrUnauthenticated)
+}
+
+func TestAuthenticator_ClientCert(t *testing.T) {
+	authenticator := NewAuthenticator(nil, nil, map[string][]string{
+		"reporting-svc": {ScopeRatesRead},
+	})
+	ctx := context.Background()
+
+	principal, err := authenticator.Authenticate(ctx, Credentials{ClientCertSubject: "reporting-svc"})
+	require.NoError(t, err)
+	assert.Equal(t, "cn:reporting-svc", principal.Identity())
+	assert.True(t, principal.HasScope(ScopeRatesRead))
+
+	principal, err = authenticator.Authenticate(ctx, Credentials{ClientCertSubject: "unknown-svc"})
+	require.NoError(t, err)
+	assert.Empty(t, principal.Scopes)
+
+	_, err = authenticator.Authenticate(ctx, Credentials{APIKey: "id.secret", ClientCertSubject: "reporting-svc"})
+	assert.ErrorIs(t, err, ErrUnauthenticated)
+
+	principal, err = authenticator.Authenticate(ctx, Credentials{})
+	require.NoError(t, err)
+	assert.Nil(t, principal)
+}
+
+func TestPolicy_Authorize(t *testing.T) {
+	policy := NewPolicy([]Rule{
+		{Method: "/svc/Health", Public: true},
+		{Method: "/svc/GetRates", Scopes: []string{ScopeRatesRead}},
+		{Method: "/svc/*", Scopes: []string{ScopeAdmin}},
+	})
+
+	reader := &Principal{Kind: KindAPIKey, Subject: "k1", Scopes: []string{ScopeRatesRead}}
+	admin := &Principal{Kind: KindJWT, Subject: "ops", Scopes: []string{ScopeAdmin}}
+
+	assert.NoError(t, policy.Authorize("/svc/Health", nil))
+	assert.ErrorIs(t, policy.Authorize("/svc/GetRates", nil), ErrUnauthenticated)
+	assert.NoError(t, policy.Authorize("/svc/GetRates", reader))
+	assert.NoError(t, policy.Authorize("/svc/GetRates", admin))
+	assert.ErrorIs(t, policy.Authorize("/svc/CreateAPIKey", reader), ErrPermissionDenied)
+	assert.NoError(t, policy.Authorize("/svc/CreateAPIKey", admin))
+	assert.ErrorIs(t, policy.Authorize("/other/Method", reader), ErrPermissionDenied)
+}
+
+func TestJWTVerifier(t *testing.T) {
+	privateKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+
+	jwks := map[string]interface{}{
+		"keys": []map[string]string{{
+			"kty": "RSA",
+			"kid": "test-key",
+			"use": "sig",
+			"n":   base64.RawURLEncoding.EncodeToString(privateKey.N.Bytes()),
+			"e":   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(privateKey.E)).Bytes()),
+		}},
+	}
+	data, err := json.Marshal(jwks)
+	require.NoError(t, err)
+
+	path := filepath.Join(t.TempDir(), "jwks.json")
+	require.NoError(t, os.WriteFile(path, data, 0o600))
+
+	verifier, err := NewJWTVerifier(path, "https://sso.example.com", "rate-service")
+	require.NoError(t, err)
+
+	sign := func(claims jwt.MapClaims) string {
+		token := jwt.NewWithClaims(jwt.SigningMethodRS256, claims)
+		token.Header["kid"] = "test-key"
+		signed, err := token.SignedString(privateKey)
+		require.NoError(t, err)
+		return signed
+	}
+
+	principal, err := verifier.Verify(sign(jwt.MapClaims{
+		"sub":   "accounting",
+		"iss":   "https://sso.example.com",
+		"aud":   "rate-service",
+		"exp":   time.Now().Add(time.Hour).Unix(),
+		"scope": "rates:read history:read",
+	}))
+	require.NoError(t, err)
+	assert.Equal(t, KindJWT, principal.Kind)
+	assert.Equal(t, "accounting", principal.Subject)
+	assert.Equal(t, []string{ScopeRatesRead, ScopeHistoryRead}, principal.Scopes)
+
+	_, err = verifier.Verify(sign(jwt.MapClaims{
+		"sub": "accounting",
+		"iss": "https://sso.example.com",
+		"aud": "rate-service",
+		"exp": time.Now().Add(-time.Hour).Unix(),
+	}))
+	assert.ErrorIs(t, err, ErrUnauthenticated)
+
+	_, err = verifier.Verify(sign(jwt.MapClaims{
+		"sub": "accounting",
+		"iss": "https://evil.example.com",
+		"aud": "rate-service",
+		"exp": time.Now().Add(time.Hour).Unix(),
+	}))
+	assert.ErrorIs(t, err, ErrUnauthenticated)
+}
@@ -0,0 +1,261 @@
+package auth
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// jwk is a single JSON Web Key as found in a JWKS document
+type jwk struct {
+	Kty string `json:"kty"`
+	Kid string `json:"kid"`
+	Use string `json:"use"`
+	Crv string `json:"crv"`
+	N   string `json:"n"`
+	E   string `json:"e"`
+	X   string `json:"x"`
+	Y   string `json:"y"`
+}
+
+// JWTVerifier verifies JWTs against public keys loaded from a local JWKS file.
+// The file is re-read when a token references an unknown key ID and the file has changed
+type JWTVerifier struct {
+	path     string
+	issuer   string
+	audience string
+
+	mu      sync.RWMutex
+	keys    map[string]crypto.PublicKey
+	modTime time.Time
+}
+
+// NewJWTVerifier loads the JWKS file; issuer and audience are enforced when non-empty
+func NewJWTVerifier(jwksPath, issuer, audience string) (*JWTVerifier, error) {
+	v := &JWTVerifier{
+		path:     jwksPath,
+		issuer:   issuer,
+		audience: audience,
+	}
+
+	if err := v.reload(); err != nil {
+		return nil, err
+	}
+
+	return v, nil
+}
+
+// Verify validates the token signature and registered claims and returns its principal.
+// Scopes are read from the space-delimited "scope" claim or the "scopes" array claim
+func (v *JWTVerifier) Verify(token string) (*Principal, error) {
+	opts := []jwt.ParserOption{
+		jwt.WithValidMethods([]string{"RS256", "RS384", "RS512", "ES256", "ES384", "ES512", "EdDSA"}),
+		jwt.WithExpirationRequired(),
+	}
+	if v.issuer != "" {
+		opts = append(opts, jwt.WithIssuer(v.issuer))
+	}
+	if v.audience != "" {
+		opts = append(opts, jwt.WithAudience(v.audience))
+	}
+
+	claims := jwt.MapClaims{}
+	if _, err := jwt.ParseWithClaims(token, claims, v.keyFunc, opts...); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrUnauthenticated, err)
+	}
+
+	subject, err := claims.GetSubject()
+	if err != nil || subject == "" {
+		return nil, fmt.Errorf("%w: token has no subject", ErrUnauthenticated)
+	}
+
+	return &Principal{
+		Kind:    KindJWT,
+		Subject: subject,
+		Scopes:  scopesFromClaims(claims),
+	}, nil
+}
+
+func (v *JWTVerifier) keyFunc(token *jwt.Token) (interface{}, error) {
+	kid, _ := token.Header["kid"].(string)
+
+	if key, ok := v.key(kid); ok {
+		return key, nil
+	}
+
+	if err := v.reloadIfChanged(); err != nil {
+		return nil, err
+	}
+	if key, ok := v.key(kid); ok {
+		return key, nil
+	}
+
+	return nil, fmt.Errorf("unknown signing key %q", kid)
+}
+
+func (v *JWTVerifier) key(kid string) (crypto.PublicKey, bool) {
+	v.mu.RLock()
+	defer v.mu.RUnlock()
+
+	if kid == "" && len(v.keys) == 1 {
+		for _, key := range v.keys {
+			return key, true
+		}
+	}
+	key, ok := v.keys[kid]
+	return key, ok
+}
+
+func (v *JWTVerifier) reloadIfChanged() error {
+	info, err := os.Stat(v.path)
+	if err != nil {
+		return fmt.Errorf("failed to stat JWKS file: %w", err)
+	}
+
+	v.mu.RLock()
+	unchanged := info.ModTime().Equal(v.modTime)
+	v.mu.RUnlock()
+
+	if unchanged {
+		return nil
+	}
+	return v.reload()
+}
+
+func (v *JWTVerifier) reload() error {
+	info, err := os.Stat(v.path)
+	if err != nil {
+		return fmt.Errorf("failed to stat JWKS file: %w", err)
+	}
+
+	data, err := os.ReadFile(v.path)
+	if err != nil {
+		return fmt.Errorf("failed to read JWKS file: %w", err)
+	}
+
+	keys, err := parseJWKS(data)
+	if err != nil {
+		return err
+	}
+
+	v.mu.Lock()
+	v.keys = keys
+	v.modTime = info.ModTime()
+	v.mu.Unlock()
+
+	return nil
+}
+
+// parseJWKS decodes RSA, EC and Ed25519 public keys from a JWKS document
+func parseJWKS(data []byte) (map[string]crypto.PublicKey, error) {
+	var doc struct {
+		Keys []jwk `json:"keys"`
+	}
+	if err := json.Unmarshal(data, &doc); err != nil {
+		return nil, fmt.Errorf("failed to parse JWKS: %w", err)
+	}
+
+	keys := make(map[string]crypto.PublicKey, len(doc.Keys))
+	for _, k := range doc.Keys {
+		if k.Use != "" && k.Use != "sig" {
+			continue
+		}
+
+		key, err := k.publicKey()
+		if err != nil {
+			return nil, fmt.Errorf("invalid JWK %q: %w", k.Kid, err)
+		}
+		keys[k.Kid] = key
+	}
+
+	if len(keys) == 0 {
+		return nil, fmt.Errorf("JWKS contains no signing keys")
+	}
+
+	return keys, nil
+}
+
+func (k jwk) publicKey() (crypto.PublicKey, error) {
+	switch k.Kty {
+	case "RSA":
+		n, err := decodeBigInt(k.N)
+		if err != nil {
+			return nil, err
+		}
+		e, err := decodeBigInt(k.E)
+		if err != nil {
+			return nil, err
+		}
+		return &rsa.PublicKey{N: n, E: int(e.Int64())}, nil
+	case "EC":
+		var curve elliptic.Curve
+		switch k.Crv {
+		case "P-256":
+			curve = elliptic.P256()
+		case "P-384":
+			curve = elliptic.P384()
+		case "P-521":
+			curve = elliptic.P521()
+		default:
+			return nil, fmt.Errorf("unsupported curve %q", k.Crv)
+		}
+		x, err := decodeBigInt(k.X)
+		if err != nil {
+			return nil, err
+		}
+		y, err := decodeBigInt(k.Y)
+		if err != nil {
+			return nil, err
+		}
+		return &ecdsa.PublicKey{Curve: curve, X: x, Y: y}, nil
+	case "OKP":
+		if k.Crv != "Ed25519" {
+			return nil, fmt.Errorf("unsupported curve %q", k.Crv)
+		}
+		x, err := base64.RawURLEncoding.DecodeString(k.X)
+		if err != nil || len(x) != ed25519.PublicKeySize {
+			return nil, fmt.Errorf("invalid Ed25519 key")
+		}
+		return ed25519.PublicKey(x), nil
+	default:
+		return nil, fmt.Errorf("unsupported key type %q", k.Kty)
+	}
+}
+
+func decodeBigInt(s string) (*big.Int, error) {
+	b, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return nil, fmt.Errorf("invalid base64url value: %w", err)
+	}
+	return new(big.Int).SetBytes(b), nil
+}
+
+func scopesFromClaims(claims jwt.MapClaims) []string {
+	if scope, ok := claims["scope"].(string); ok {
+		return strings.Fields(scope)
+	}
+
+	raw, ok := claims["scopes"].([]interface{})
+	if !ok {
+		return nil
+	}
+	scopes := make([]string, 0, len(raw))
+	for _, s := range raw {
+		if str, ok := s.(string); ok {
+			scopes = append(scopes, str)
+		}
+	}
+	return scopes
+}
@@ -0,0 +1,71 @@
+package auth
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Rule describes who may call a method. Method is a full gRPC method name or a prefix ending in "*".
+// Public methods need no credentials; otherwise the caller needs any one of Scopes
+type Rule struct {
+	Method string
+	Public bool
+	Scopes []string
+}
+
+// Policy holds method-level authorization rules. Methods without a matching rule are denied
+type Policy struct {
+	rules []Rule
+}
+
+// NewPolicy creates a policy from rules
+func NewPolicy(rules []Rule) *Policy {
+	return &Policy{rules: rules}
+}
+
+// Authorize checks whether principal (nil for anonymous callers) may call method
+func (p *Policy) Authorize(method string, principal *Principal) error {
+	rule, ok := p.match(method)
+	if !ok {
+		if principal == nil {
+			return fmt.Errorf("%w: credentials required", ErrUnauthenticated)
+		}
+		return fmt.Errorf("%w: no rule allows %s", ErrPermissionDenied, method)
+	}
+
+	if rule.Public {
+		return nil
+	}
+	if principal == nil {
+		return fmt.Errorf("%w: credentials required", ErrUnauthenticated)
+	}
+
+	for _, scope := range rule.Scopes {
+		if principal.HasScope(scope) {
+			return nil
+		}
+	}
+
+	return fmt.Errorf("%w: %s requires one of scopes %v", ErrPermissionDenied, method, rule.Scopes)
+}
+
+// match returns the exact rule for method or, failing that, the wildcard rule with the longest prefix
+func (p *Policy) match(method string) (Rule, bool) {
+	var (
+		best    Rule
+		bestLen = -1
+	)
+
+	for _, rule := range p.rules {
+		if rule.Method == method {
+			return rule, true
+		}
+
+		prefix, wildcard := strings.CutSuffix(rule.Method, "*")
+		if wildcard && strings.HasPrefix(method, prefix) && len(prefix) > bestLen {
+			best, bestLen = rule, len(prefix)
+		}
+	}
+
+	return best, bestLen >= 0
+}
@@ -0,0 +1,325 @@
+package backfill
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"hash/fnv"
+	"time"
+
+	"github.com/cawa87/garantex-test/internal/lib/logger/sl"
+	"github.com/cawa87/garantex-test/internal/service/exchange"
+	"github.com/shopspring/decimal"
+)
+
+// Source tags the rates inserted by a backfill
+const Source = "backfill"
+
+// Mode is the exchange history a backfill reads
+type Mode string
+
+const (
+	// ModeTrades stores one rate per executed trade at its price
+	ModeTrades Mode = "trades"
+	// ModeKLine stores one rate per candle at its close price, stamped with the candle end
+	ModeKLine Mode = "kline"
+)
+
+// Exchange serves the exchange history
+type Exchange interface {
+	GetTrades(ctx context.Context, market string, fromID int64, limit int) ([]exchange.Trade, error)
+	GetLastTradeBefore(ctx context.Context, market string, t time.Time) (*exchange.Trade, error)
+	GetKLine(ctx context.Context, market string, period time.Duration, from, to time.Time, limit int) ([]exchange.KLine, error)
+}
+
+// Store persists backfilled rates and the job checkpoints
+type Store interface {
+	// SaveBackfilledRates saves rates without announcing them to live rate subscribers
+	SaveBackfilledRates(ctx context.Context, rates []*exchange.Rate) (int64, error)
+	// GetBackfillCheckpoint returns the checkpoint of a job, sql.ErrNoRows if it never ran
+	GetBackfillCheckpoint(ctx context.Context, name string) (*Checkpoint, error)
+	SaveBackfillCheckpoint(ctx context.Context, cp *Checkpoint) error
+}
+
+// Job is a window of history to fill. Name identifies its checkpoint; rerunning a job resumes it,
+// and rerunning it with a later end extends it
+type Job struct {
+	Name   string
+	Market string
+	Mode   Mode
+	Period time.Duration
+	From   time.Time
+	To     time.Time
+}
+
+// Validate checks the job settings
+func (j Job) Validate() error {
+	if j.Name == "" {
+		return fmt.Errorf("backfill name is required")
+	}
+	if j.Market == "" {
+		return fmt.Errorf("backfill market is required")
+	}
+	if !j.From.Before(j.To) {
+		return fmt.Errorf("backfill window start %s is not before its end %s", j.From, j.To)
+	}
+	switch j.Mode {
+	case ModeTrades:
+	case ModeKLine:
+		if j.Period < time.Minute || j.Period%time.Minute != 0 {
+			return fmt.Errorf("kline period %s must be a whole number of minutes", j.Period)
+		}
+	default:
+		return fmt.Errorf("unknown backfill mode %q", j.Mode)
+	}
+	return nil
+}
+
+// LockKey identifies the job among Postgres advisory locks, so that one runner at a time fills it
+func (j Job) LockKey() int64 {
+	h := fnv.New64a()
+	_, _ = h.Write([]byte("backfill:" + j.Name))
+	return int64(h.Sum64())
+}
+
+// Checkpoint is the progress of a job: [From, Cursor) is filled
+type Checkpoint struct {
+	Name        string
+	Market      string
+	Mode        Mode
+	Period      time.Duration
+	From        time.Time
+	To          time.Time
+	Cursor      time.Time
+	LastTradeID int64
+	Inserted    int64
+	CompletedAt *time.Time
+	UpdatedAt   time.Time
+}
+
+// Report summarises a run
+type Report struct {
+	Resumed  bool
+	Fetched  int64
+	Inserted int64
+	Cursor   time.Time
+}
+
+// Backfiller fills holes in the rates history from the exchange trades or candles.
+// Requests go through the client's outbound rate limiter
+type Backfiller struct {
+	exchange Exchange
+	store    Store
+	logger   *sl.Logger
+}
+
+// New creates a backfiller
+func New(exchange Exchange, store Store, logger *sl.Logger) *Backfiller {
+	return &Backfiller{
+		exchange: exchange,
+		store:    store,
+		logger:   logger,
+	}
+}
+
+// Run fills the job's window, resuming from its checkpoint. Progress is checkpointed after every page,
+// so an interrupted run loses at most one page, which the next run fetches again
+func (b *Backfiller) Run(ctx context.Context, job Job) (Report, error) {
+	if err := job.Validate(); err != nil {
+		return Report{}, err
+	}
+
+	cp, resumed, err := b.checkpoint(ctx, job)
+	if err != nil {
+		return Report{}, err
+	}
+
+	report := Report{Resumed: resumed}
+	if cp.Cursor.Before(cp.To) {
+		b.logger.Info("Backfill started",
+			"name", job.Name,
+			"market", job.Market,
+			"mode", job.Mode,
+			"from", cp.Cursor,
+			"to", cp.To,
+			"resumed", resumed)
+
+		switch job.Mode {
+		case ModeTrades:
+			err = b.fillTrades(ctx, cp, &report)
+		case ModeKLine:
+			err = b.fillKLine(ctx, cp, &report)
+		}
+		if err != nil {
+			return report, err
+		}
+	}
+
+	now := time.Now()
+	cp.Cursor = cp.To
+	cp.CompletedAt = &now
+	if err := b.save(ctx, cp); err != nil {
+		return report, err
+	}
+	report.Cursor = cp.Cursor
+
+	b.logger.Info("Backfill completed",
+		"name", job.Name,
+		"fetched", report.Fetched,
+		"inserted", report.Inserted)
+	return report, nil
+}
+
+// checkpoint loads the job's checkpoint, or starts a new one at the window start
+func (b *Backfiller) checkpoint(ctx context.Context, job Job) (*Checkpoint, bool, error) {
+	cp, err := b.store.GetBackfillCheckpoint(ctx, job.Name)
+	if errors.Is(err, sql.ErrNoRows) {
+		return &Checkpoint{
+			Name:   job.Name,
+			Market: job.Market,
+			Mode:   job.Mode,
+			Period: job.Period,
+			From:   job.From,
+			To:     job.To,
+			Cursor: job.From,
+		}, false, nil
+	}
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to load checkpoint: %w", err)
+	}
+
+	if cp.Market != job.Market || cp.Mode != job.Mode || cp.Period != job.Period {
+		return nil, false, fmt.Errorf("checkpoint %q is for %s %s backfill, not %s %s; pick another name",
+			job.Name, cp.Market, cp.Mode, job.Market, job.Mode)
+	}
+
+	if !job.From.Equal(cp.From) {
+		return nil, false, fmt.Errorf("checkpoint %q covers a window starting at %s; pick another name", job.Name, cp.From)
+	}
+	if job.To.After(cp.To) {
+		cp.To = job.To
+		cp.CompletedAt = nil
+	}
+	return cp, true, nil
+}
+
+// fillTrades walks trades forward from the last trade before the cursor
+func (b *Backfiller) fillTrades(ctx context.Context, cp *Checkpoint, report *Report) error {
+	if cp.LastTradeID == 0 {
+		last, err := b.exchange.GetLastTradeBefore(ctx, cp.Market, cp.Cursor)
+		if err != nil {
+			return fmt.Errorf("failed to find the first trade: %w", err)
+		}
+		if last != nil {
+			cp.LastTradeID = last.ID
+		}
+	}
+
+	for {
+		trades, err := b.exchange.GetTrades(ctx, cp.Market, cp.LastTradeID, exchange.MaxHistoryLimit)
+		if err != nil {
+			return fmt.Errorf("failed to fetch trades after %d: %w", cp.LastTradeID, err)
+		}
+		report.Fetched += int64(len(trades))
+
+		rates := make([]*exchange.Rate, 0, len(trades))
+		done := len(trades) < exchange.MaxHistoryLimit
+		for _, trade := range trades {
+			if !trade.CreatedAt.Before(cp.To) {
+				done = true
+				break
+			}
+			if !trade.CreatedAt.Before(cp.Cursor) {
+				rates = append(rates, rateAt(cp.Market, trade.Price, trade.CreatedAt))
+			}
+			cp.LastTradeID = trade.ID
+		}
+
+		if len(rates) > 0 {
+			cp.Cursor = rates[len(rates)-1].Timestamp
+		}
+		if err := b.savePage(ctx, cp, rates, report); err != nil {
+			return err
+		}
+		if done {
+			return nil
+		}
+	}
+}
+
+// fillKLine walks candles forward from the cursor, one page of candles per request
+func (b *Backfiller) fillKLine(ctx context.Context, cp *Checkpoint, report *Report) error {
+	period := int64(cp.Period / time.Second)
+	start := time.Unix(cp.Cursor.Unix()/period*period, 0).UTC()
+
+	for start.Before(cp.To) {
+		end := start.Add(cp.Period * exchange.MaxHistoryLimit)
+		if end.After(cp.To) {
+			end = cp.To
+		}
+
+		candles, err := b.exchange.GetKLine(ctx, cp.Market, cp.Period, start, end, exchange.MaxHistoryLimit)
+		if err != nil {
+			return fmt.Errorf("failed to fetch candles from %s: %w", start, err)
+		}
+		report.Fetched += int64(len(candles))
+
+		rates := make([]*exchange.Rate, 0, len(candles))
+		for _, candle := range candles {
+			closeTime := candle.Start.Add(cp.Period)
+			// Candles still open at the window end are skipped, as are ones already filled
+			if closeTime.After(cp.To) || candle.Start.Before(start) || !closeTime.After(cp.Cursor) {
+				continue
+			}
+			rates = append(rates, rateAt(cp.Market, candle.Close, closeTime))
+		}
+
+		cp.Cursor = end
+		if err := b.savePage(ctx, cp, rates, report); err != nil {
+			return err
+		}
+		start = end
+	}
+	return nil
+}
+
+// savePage stores a page of rates and then the checkpoint that covers it
+func (b *Backfiller) savePage(ctx context.Context, cp *Checkpoint, rates []*exchange.Rate, report *Report) error {
+	if len(rates) > 0 {
+		inserted, err := b.store.SaveBackfilledRates(ctx, rates)
+		if err != nil {
+			return fmt.Errorf("failed to save backfilled rates: %w", err)
+		}
+		cp.Inserted += inserted
+		report.Inserted += inserted
+	}
+
+	if err := b.save(ctx, cp); err != nil {
+		return err
+	}
+	report.Cursor = cp.Cursor
+
+	b.logger.Debug("Backfill page stored", "name", cp.Name, "rates", len(rates), "cursor", cp.Cursor)
+	return nil
+}
+
+func (b *Backfiller) save(ctx context.Context, cp *Checkpoint) error {
+	cp.UpdatedAt = time.Now()
+	if err := b.store.SaveBackfillCheckpoint(ctx, cp); err != nil {
+		return fmt.Errorf("failed to save checkpoint: %w", err)
+	}
+	return nil
+}
+
+// rateAt builds a backfilled rate; history has a single price, used for both sides
+func rateAt(market string, price decimal.Decimal, at time.Time) *exchange.Rate {
+	return &exchange.Rate{
+		Market:     market,
+		Source:     Source,
+		Ask:        price,
+		Bid:        price,
+		Timestamp:  at,
+		ReceivedAt: time.Now(),
+	}
+}
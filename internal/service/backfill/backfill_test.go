@@ -0,0 +1,222 @@
+package backfill
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/cawa87/garantex-test/internal/lib/logger/sl"
+	"github.com/cawa87/garantex-test/internal/service/exchange"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var base = time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+// fakeExchange serves one trade per second from base with ids starting at 1, and 1-minute candles
+type fakeExchange struct {
+	trades   []exchange.Trade
+	requests int
+}
+
+func newFakeExchange(n int) *fakeExchange {
+	e := &fakeExchange{}
+	for i := 0; i < n; i++ {
+		e.trades = append(e.trades, exchange.Trade{
+			ID:        int64(i + 1),
+			Market:    "btcusdt",
+			Price:     decimal.NewFromInt(int64(100 + i)),
+			CreatedAt: base.Add(time.Duration(i) * time.Second),
+		})
+	}
+	return e
+}
+
+func (e *fakeExchange) GetTrades(_ context.Context, _ string, fromID int64, limit int) ([]exchange.Trade, error) {
+	e.requests++
+	var out []exchange.Trade
+	for _, trade := range e.trades {
+		if trade.ID > fromID && len(out) < limit {
+			out = append(out, trade)
+		}
+	}
+	return out, nil
+}
+
+func (e *fakeExchange) GetLastTradeBefore(_ context.Context, _ string, t time.Time) (*exchange.Trade, error) {
+	var last *exchange.Trade
+	for i := range e.trades {
+		if e.trades[i].CreatedAt.Before(t) {
+			last = &e.trades[i]
+		}
+	}
+	return last, nil
+}
+
+func (e *fakeExchange) GetKLine(_ context.Context, _ string, period time.Duration, from, to time.Time, limit int) ([]exchange.KLine, error) {
+	e.requests++
+	var out []exchange.KLine
+	for start := from; !start.After(to) && len(out) < limit; start = start.Add(period) {
+		out = append(out, exchange.KLine{Start: start, Close: decimal.NewFromInt(start.Unix())})
+	}
+	return out, nil
+}
+
+// fakeStore keeps rates by timestamp and fails SaveBackfilledRates once failAt calls have succeeded
+type fakeStore struct {
+	rates       map[time.Time]*exchange.Rate
+	checkpoints map[string]Checkpoint
+	saves       int
+	failAt      int
+}
+
+func newFakeStore() *fakeStore {
+	return &fakeStore{
+		rates:       make(map[time.Time]*exchange.Rate),
+		checkpoints: make(map[string]Checkpoint),
+		failAt:      -1,
+	}
+}
+
+func (s *fakeStore) SaveBackfilledRates(_ context.Context, rates []*exchange.Rate) (int64, error) {
+	if s.saves == s.failAt {
+		return 0, errors.New("database unavailable")
+	}
+	s.saves++
+
+	var inserted int64
+	for _, rate := range rates {
+		if _, ok := s.rates[rate.Timestamp]; !ok {
+			s.rates[rate.Timestamp] = rate
+			inserted++
+		}
+	}
+	return inserted, nil
+}
+
+func (s *fakeStore) GetBackfillCheckpoint(_ context.Context, name string) (*Checkpoint, error) {
+	cp, ok := s.checkpoints[name]
+	if !ok {
+		return nil, sql.ErrNoRows
+	}
+	return &cp, nil
+}
+
+func (s *fakeStore) SaveBackfillCheckpoint(_ context.Context, cp *Checkpoint) error {
+	s.checkpoints[cp.Name] = *cp
+	return nil
+}
+
+func TestBackfill_Trades(t *testing.T) {
+	logger, err := sl.New("info")
+	require.NoError(t, err)
+
+	ex := newFakeExchange(3000)
+	store := newFakeStore()
+	b := New(ex, store, logger)
+
+	job := Job{Name: "jan", Market: "btcusdt", Mode: ModeTrades, From: base.Add(10 * time.Second), To: base.Add(2500 * time.Second)}
+	report, err := b.Run(context.Background(), job)
+	require.NoError(t, err)
+
+	// Trades at 10s..2499s, fetched in pages of 1000 starting after the last trade before the window
+	assert.Equal(t, int64(2490), report.Inserted)
+	assert.Len(t, store.rates, 2490)
+	assert.Equal(t, 3, ex.requests)
+	assert.False(t, report.Resumed)
+
+	rate := store.rates[base.Add(10*time.Second)]
+	require.NotNil(t, rate)
+	assert.Equal(t, Source, rate.Source)
+	assert.True(t, decimal.NewFromInt(110).Equal(rate.Ask))
+	assert.True(t, rate.Ask.Equal(rate.Bid))
+
+	cp := store.checkpoints["jan"]
+	assert.NotNil(t, cp.CompletedAt)
+	assert.Equal(t, job.To, cp.Cursor)
+	assert.Equal(t, int64(2490), cp.Inserted)
+}
+
+func TestBackfill_Resume(t *testing.T) {
+	logger, err := sl.New("info")
+	require.NoError(t, err)
+
+	ex := newFakeExchange(3000)
+	store := newFakeStore()
+	store.failAt = 1
+	b := New(ex, store, logger)
+
+	job := Job{Name: "jan", Market: "btcusdt", Mode: ModeTrades, From: base, To: base.Add(3000 * time.Second)}
+	_, err = b.Run(context.Background(), job)
+	require.ErrorContains(t, err, "database unavailable")
+
+	cp := store.checkpoints["jan"]
+	assert.Nil(t, cp.CompletedAt)
+	assert.Equal(t, int64(1000), cp.LastTradeID)
+	assert.Len(t, store.rates, 1000)
+
+	// The rerun continues after the last stored trade
+	store.failAt = -1
+	ex.requests = 0
+	report, err := b.Run(context.Background(), job)
+	require.NoError(t, err)
+	assert.True(t, report.Resumed)
+	assert.Equal(t, int64(2000), report.Inserted)
+	assert.Len(t, store.rates, 3000)
+	assert.Equal(t, 3, ex.requests)
+
+	// A different job under the same name is refused
+	_, err = b.Run(context.Background(), Job{Name: "jan", Market: "ethusdt", Mode: ModeTrades, From: base, To: job.To})
+	assert.ErrorContains(t, err, "pick another name")
+}
+
+func TestBackfill_KLine(t *testing.T) {
+	logger, err := sl.New("info")
+	require.NoError(t, err)
+
+	ex := newFakeExchange(0)
+	store := newFakeStore()
+	b := New(ex, store, logger)
+
+	// 1500 minutes plus 30 seconds: the candle still open at the end is skipped
+	job := Job{Name: "k", Market: "btcusdt", Mode: ModeKLine, Period: time.Minute, From: base, To: base.Add(1500*time.Minute + 30*time.Second)}
+	report, err := b.Run(context.Background(), job)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1500), report.Inserted)
+	assert.Equal(t, 2, ex.requests)
+
+	// Each candle becomes a rate at its close price and time
+	first := store.rates[base.Add(time.Minute)]
+	require.NotNil(t, first)
+	assert.True(t, decimal.NewFromInt(base.Unix()).Equal(first.Ask))
+	assert.Nil(t, store.rates[base])
+
+	// Extending the window fetches only the new candles
+	job.To = base.Add(1502 * time.Minute)
+	report, err = b.Run(context.Background(), job)
+	require.NoError(t, err)
+	assert.True(t, report.Resumed)
+	assert.Equal(t, int64(2), report.Inserted)
+}
+
+func TestJob_Validate(t *testing.T) {
+	valid := Job{Name: "n", Market: "btcusdt", Mode: ModeKLine, Period: 5 * time.Minute, From: base, To: base.Add(time.Hour)}
+	assert.NoError(t, valid.Validate())
+
+	invalid := valid
+	invalid.Period = 90 * time.Second
+	assert.Error(t, invalid.Validate())
+
+	invalid = valid
+	invalid.To = valid.From
+	assert.Error(t, invalid.Validate())
+
+	invalid = valid
+	invalid.Mode = "ticks"
+	assert.Error(t, invalid.Validate())
+
+	assert.NotEqual(t, valid.LockKey(), Job{Name: "other"}.LockKey())
+}
@@ -9,13 +9,26 @@ import (
 	"time"
 
 	"github.com/cawa87/garantex-test/internal/lib/logger/sl"
+	"github.com/shopspring/decimal"
 )
 
-// Rate represents a currency exchange rate with ask/bid prices and timestamp
+// DefaultSource is the provider rates are attributed to when none is configured
+const DefaultSource = "garantex"
+
+// Rate represents a currency exchange rate of a market with ask/bid prices and timestamp.
+// Source is the provider the rate was fetched from; (Market, Source, Timestamp) identifies a sample.
+// Timestamp is the exchange timestamp of the quote (ReceivedAt when the exchange sends none),
+// ReceivedAt the local time the response arrived and Latency the request round trip.
+// Book holds the full order book the rate was derived from, if it could be parsed
 type Rate struct {
-	Ask       float64   `json:"ask"`
-	Bid       float64   `json:"bid"`
-	Timestamp time.Time `json:"timestamp"`
+	Market     string             `json:"market"`
+	Source     string             `json:"source"`
+	Ask        decimal.Decimal    `json:"ask"`
+	Bid        decimal.Decimal    `json:"bid"`
+	Timestamp  time.Time          `json:"timestamp"`
+	ReceivedAt time.Time          `json:"received_at"`
+	Latency    time.Duration      `json:"latency"`
+	Book       *OrderBookSnapshot `json:"-"`
 }
 
 // DepthResponse represents the response from Garantex depth API
@@ -36,33 +49,75 @@ type OrderBook struct {
 
 // Client represents the exchange API client for fetching rates
 type Client struct {
-	baseURL    string
-	httpClient *http.Client
-	logger     *sl.Logger
+	baseURL      string
+	source       string
+	httpClient   *http.Client
+	limiter      *Limiter
+	maxClockSkew time.Duration
+	clock        *clockMonitor
+	logger       *sl.Logger
+}
+
+// Option configures optional client behaviour
+type Option func(*Client)
+
+// WithRateLimiter throttles outbound requests through the given limiter
+func WithRateLimiter(limiter *Limiter) Option {
+	return func(c *Client) {
+		c.limiter = limiter
+	}
+}
+
+// WithMaxClockSkew logs a warning whenever the exchange clock drifts from the local clock by more than d;
+// zero disables the warning. The exchange reports whole seconds, so d should be well above one second
+func WithMaxClockSkew(d time.Duration) Option {
+	return func(c *Client) {
+		c.maxClockSkew = d
+	}
+}
+
+// WithSource attributes fetched rates to the named provider instead of DefaultSource
+func WithSource(source string) Option {
+	return func(c *Client) {
+		if source != "" {
+			c.source = source
+		}
+	}
 }
 
 // NewClient creates a new exchange client with the specified base URL and timeout
-func NewClient(baseURL string, timeout time.Duration, logger *sl.Logger) *Client {
-	return &Client{
+func NewClient(baseURL string, timeout time.Duration, logger *sl.Logger, opts ...Option) *Client {
+	c := &Client{
 		baseURL: baseURL,
+		source:  DefaultSource,
 		httpClient: &http.Client{
 			Timeout: timeout,
 		},
+		clock:  newClockMonitor(),
 		logger: logger,
 	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
 }
 
 // GetRates fetches current USDT rates from Garantex exchange
 func (c *Client) GetRates(ctx context.Context) (*Rate, error) {
-	url := fmt.Sprintf("%s/api/v2/depth?market=btcusdt", c.baseURL)
+	url := fmt.Sprintf("%s/api/v2/depth?market=%s", c.baseURL, DefaultMarket)
 
 	c.logger.Debug("Fetching rates from exchange", "url", url)
 
+	if err := c.limiter.Wait(ctx, "depth"); err != nil {
+		return nil, err
+	}
+
 	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create request: %w", err)
 	}
 
+	sentAt := time.Now()
 	resp, err := c.httpClient.Do(req)
 	if err != nil {
 		return nil, fmt.Errorf("failed to make request: %w", err)
@@ -78,6 +133,8 @@ func (c *Client) GetRates(ctx context.Context) (*Rate, error) {
 	if err != nil {
 		return nil, fmt.Errorf("failed to read response body: %w", err)
 	}
+	receivedAt := time.Now()
+	latency := receivedAt.Sub(sentAt)
 
 	var depthResp DepthResponse
 	if err := json.Unmarshal(body, &depthResp); err != nil {
@@ -87,34 +144,60 @@ func (c *Client) GetRates(ctx context.Context) (*Rate, error) {
 	if len(depthResp.Bids) == 0 {
 		return nil, fmt.Errorf("no bid prices available")
 	}
+	if len(depthResp.Asks) == 0 {
+		return nil, fmt.Errorf("no ask prices available")
+	}
 
 	bid, err := parsePrice(depthResp.Bids[0].Price)
 	if err != nil {
 		return nil, fmt.Errorf("failed to parse bid price: %w", err)
 	}
 
-	ask := bid
+	// The book is taken as served: a crossed or stale top of book is kept for the data quality checks
+	ask, err := parsePrice(depthResp.Asks[0].Price)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse ask price: %w", err)
+	}
 
 	rate := &Rate{
-		Ask:       ask,
-		Bid:       bid,
-		Timestamp: time.Now(),
+		Market:     DefaultMarket,
+		Source:     c.source,
+		Ask:        ask,
+		Bid:        bid,
+		Timestamp:  receivedAt,
+		ReceivedAt: receivedAt,
+		Latency:    latency,
+	}
+	c.clock.recordLatency(ctx, latency)
+
+	if exchangeTime, ok := parseTimestamp(depthResp.Timestamp); ok {
+		rate.Timestamp = exchangeTime
+		c.checkClockSkew(exchangeTime, sentAt, latency)
+	} else {
+		c.logger.Warn("Exchange response has no timestamp, using receive time")
+	}
+
+	book, err := parseOrderBook(DefaultMarket, rate.Timestamp, &depthResp)
+	if err != nil {
+		c.logger.Warn("Failed to parse order book, keeping top of book only", "error", err)
+	} else {
+		rate.Book = book
 	}
 
 	c.logger.Info("Successfully fetched rates",
 		"ask", rate.Ask,
 		"bid", rate.Bid,
-		"timestamp", rate.Timestamp)
+		"timestamp", rate.Timestamp,
+		"latency", rate.Latency)
 
 	return rate, nil
 }
 
-// parsePrice converts string price to float64
-func parsePrice(priceStr string) (float64, error) {
-	var price float64
-	_, err := fmt.Sscanf(priceStr, "%f", &price)
+// parsePrice converts string price to an exact decimal
+func parsePrice(priceStr string) (decimal.Decimal, error) {
+	price, err := decimal.NewFromString(priceStr)
 	if err != nil {
-		return 0, fmt.Errorf("invalid price format: %s", priceStr)
+		return decimal.Zero, fmt.Errorf("invalid price format: %s", priceStr)
 	}
 	return price, nil
 }
@@ -8,6 +8,7 @@ import (
 	"time"
 
 	"github.com/cawa87/garantex-test/internal/lib/logger/sl"
+	"github.com/shopspring/decimal"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -32,7 +33,15 @@ func TestGetRates_Success(t *testing.T) {
 		// Return mock response
 		response := `{
 			"timestamp": 1755631475,
-			"asks": [],
+			"asks": [
+				{
+					"price": "100.55",
+					"volume": "2",
+					"amount": "201.10",
+					"factor": "0.226",
+					"type": "limit"
+				}
+			],
 			"bids": [
 				{
 					"price": "100.40",
@@ -59,9 +68,12 @@ func TestGetRates_Success(t *testing.T) {
 
 	require.NoError(t, err)
 	assert.NotNil(t, rate)
-	assert.Equal(t, 100.40, rate.Ask)
-	assert.Equal(t, 100.40, rate.Bid)
-	assert.WithinDuration(t, time.Now(), rate.Timestamp, 2*time.Second)
+	assert.True(t, decimal.RequireFromString("100.55").Equal(rate.Ask))
+	assert.True(t, decimal.RequireFromString("100.40").Equal(rate.Bid))
+	assert.Equal(t, time.Unix(1755631475, 0), rate.Timestamp)
+	assert.WithinDuration(t, time.Now(), rate.ReceivedAt, 2*time.Second)
+	assert.Positive(t, rate.Latency)
+	assert.Equal(t, DefaultSource, rate.Source)
 }
 
 func TestGetRates_EmptyResponse(t *testing.T) {
@@ -85,6 +97,26 @@ func TestGetRates_EmptyResponse(t *testing.T) {
 	assert.Contains(t, err.Error(), "no bid prices available")
 }
 
+func TestGetRates_NoAsks(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		response := `{"timestamp": 1755631475, "asks": [], "bids": [{"price": "100.40", "volume": "1.5"}]}`
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(response))
+	}))
+	defer server.Close()
+
+	logger, err := sl.New("info")
+	require.NoError(t, err)
+
+	client := NewClient(server.URL, 10*time.Second, logger)
+
+	_, err = client.GetRates(context.Background())
+
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "no ask prices available")
+}
+
 func TestGetRates_InvalidJSON(t *testing.T) {
 	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		w.Header().Set("Content-Type", "application/json")
@@ -128,7 +160,7 @@ func TestGetRates_InvalidPrice(t *testing.T) {
 	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		response := `{
 			"timestamp": 1755631475,
-			"asks": [],
+			"asks": [{"price": "100.55", "volume": "2"}],
 			"bids": [
 				{
 					"price": "invalid",
@@ -161,14 +193,15 @@ func TestParsePrice(t *testing.T) {
 	tests := []struct {
 		name     string
 		priceStr string
-		expected float64
+		expected string
 		hasError bool
 	}{
-		{"valid price", "100.50", 100.50, false},
-		{"integer price", "100", 100.0, false},
-		{"zero price", "0", 0.0, false},
-		{"invalid price", "invalid", 0.0, true},
-		{"empty string", "", 0.0, true},
+		{"valid price", "100.50", "100.5", false},
+		{"integer price", "100", "100", false},
+		{"zero price", "0", "0", false},
+		{"exact price", "64250.12345678", "64250.12345678", false},
+		{"invalid price", "invalid", "", true},
+		{"empty string", "", "", true},
 	}
 
 	for _, tt := range tests {
@@ -179,7 +212,7 @@ func TestParsePrice(t *testing.T) {
 				assert.Error(t, err)
 			} else {
 				assert.NoError(t, err)
-				assert.Equal(t, tt.expected, price)
+				assert.Equal(t, tt.expected, price.String())
 			}
 		})
 	}
@@ -0,0 +1,75 @@
+package exchange
+
+import (
+	"context"
+	"sync/atomic"
+	"time"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/metric"
+)
+
+// millisecondTimestamps is the smallest Unix timestamp treated as milliseconds rather than seconds
+const millisecondTimestamps = 1e12
+
+// clockMonitor tracks fetch latency and the offset between the exchange and local clocks
+type clockMonitor struct {
+	skew    atomic.Int64
+	latency metric.Float64Histogram
+}
+
+func newClockMonitor() *clockMonitor {
+	m := &clockMonitor{}
+	meter := otel.Meter("exchange")
+
+	m.latency, _ = meter.Float64Histogram("exchange_fetch_latency",
+		metric.WithUnit("s"),
+		metric.WithDescription("Round-trip time of exchange requests"))
+
+	_, _ = meter.Float64ObservableGauge("exchange_clock_skew",
+		metric.WithUnit("s"),
+		metric.WithDescription("Exchange clock minus local clock at the last fetch; positive when the exchange is ahead"),
+		metric.WithFloat64Callback(func(_ context.Context, o metric.Float64Observer) error {
+			o.Observe(time.Duration(m.skew.Load()).Seconds())
+			return nil
+		}))
+
+	return m
+}
+
+func (m *clockMonitor) recordLatency(ctx context.Context, latency time.Duration) {
+	m.latency.Record(ctx, latency.Seconds())
+}
+
+// clockSkew estimates how far the exchange clock is ahead of the local clock, assuming the
+// exchange stamped the response halfway through the round trip
+func clockSkew(exchangeTime, sentAt time.Time, latency time.Duration) time.Duration {
+	return exchangeTime.Sub(sentAt.Add(latency / 2))
+}
+
+// checkClockSkew records the skew of this fetch and warns when it exceeds the configured maximum
+func (c *Client) checkClockSkew(exchangeTime, sentAt time.Time, latency time.Duration) {
+	skew := clockSkew(exchangeTime, sentAt, latency)
+	c.clock.skew.Store(int64(skew))
+
+	if c.maxClockSkew > 0 && (skew > c.maxClockSkew || skew < -c.maxClockSkew) {
+		c.logger.Warn("Exchange clock skew exceeds threshold",
+			"skew", skew,
+			"max_skew", c.maxClockSkew,
+			"exchange_timestamp", exchangeTime,
+			"latency", latency)
+	}
+}
+
+// parseTimestamp converts the exchange Unix timestamp (seconds or milliseconds) to a time;
+// ok is false when the exchange sent none
+func parseTimestamp(ts int64) (time.Time, bool) {
+	switch {
+	case ts <= 0:
+		return time.Time{}, false
+	case ts >= millisecondTimestamps:
+		return time.UnixMilli(ts), true
+	default:
+		return time.Unix(ts, 0), true
+	}
+}
@@ -0,0 +1,30 @@
+package exchange
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseTimestamp(t *testing.T) {
+	_, ok := parseTimestamp(0)
+	assert.False(t, ok)
+
+	ts, ok := parseTimestamp(1755631475)
+	assert.True(t, ok)
+	assert.Equal(t, time.Unix(1755631475, 0), ts)
+
+	ts, ok = parseTimestamp(1755631475123)
+	assert.True(t, ok)
+	assert.Equal(t, time.UnixMilli(1755631475123), ts)
+}
+
+func TestClockSkew(t *testing.T) {
+	sentAt := time.Unix(1000, 0)
+
+	// Exchange stamped the response halfway through a 200ms round trip
+	assert.Zero(t, clockSkew(sentAt.Add(100*time.Millisecond), sentAt, 200*time.Millisecond))
+	assert.Equal(t, 3*time.Second, clockSkew(sentAt.Add(3100*time.Millisecond), sentAt, 200*time.Millisecond))
+	assert.Equal(t, -2*time.Second, clockSkew(sentAt.Add(-1900*time.Millisecond), sentAt, 200*time.Millisecond))
+}
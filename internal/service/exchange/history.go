@@ -0,0 +1,192 @@
+package exchange
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+// MaxHistoryLimit is the most trades or candles the exchange returns per request
+const MaxHistoryLimit = 1000
+
+// Trade is an executed trade
+type Trade struct {
+	ID        int64
+	Market    string
+	Price     decimal.Decimal
+	Volume    decimal.Decimal
+	CreatedAt time.Time
+}
+
+// KLine is an OHLC candle of the exchange starting at Start
+type KLine struct {
+	Start  time.Time
+	Open   decimal.Decimal
+	High   decimal.Decimal
+	Low    decimal.Decimal
+	Close  decimal.Decimal
+	Volume decimal.Decimal
+}
+
+// tradeResponse represents a trade returned by the Garantex trades API
+type tradeResponse struct {
+	ID        int64     `json:"id"`
+	Price     string    `json:"price"`
+	Volume    string    `json:"volume"`
+	Market    string    `json:"market"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// GetTrades returns up to limit trades of market executed after the trade fromID, oldest first.
+// A zero fromID starts at the oldest trade
+func (c *Client) GetTrades(ctx context.Context, market string, fromID int64, limit int) ([]Trade, error) {
+	query := url.Values{
+		"market":   {market},
+		"limit":    {strconv.Itoa(limit)},
+		"order_by": {"asc"},
+	}
+	if fromID > 0 {
+		query.Set("from", strconv.FormatInt(fromID, 10))
+	}
+	return c.getTrades(ctx, query)
+}
+
+// GetLastTradeBefore returns the last trade of market executed before t, or nil if there is none
+func (c *Client) GetLastTradeBefore(ctx context.Context, market string, t time.Time) (*Trade, error) {
+	trades, err := c.getTrades(ctx, url.Values{
+		"market":    {market},
+		"limit":     {"1"},
+		"order_by":  {"desc"},
+		"timestamp": {strconv.FormatInt(t.Unix(), 10)},
+	})
+	if err != nil || len(trades) == 0 {
+		return nil, err
+	}
+	return &trades[0], nil
+}
+
+func (c *Client) getTrades(ctx context.Context, query url.Values) ([]Trade, error) {
+	var resp []tradeResponse
+	if err := c.getJSON(ctx, "trades", "/api/v2/trades?"+query.Encode(), &resp); err != nil {
+		return nil, err
+	}
+
+	trades := make([]Trade, 0, len(resp))
+	for _, tr := range resp {
+		price, err := parsePrice(tr.Price)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse price of trade %d: %w", tr.ID, err)
+		}
+		volume, err := parsePrice(tr.Volume)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse volume of trade %d: %w", tr.ID, err)
+		}
+		trades = append(trades, Trade{
+			ID:        tr.ID,
+			Market:    tr.Market,
+			Price:     price,
+			Volume:    volume,
+			CreatedAt: tr.CreatedAt,
+		})
+	}
+	return trades, nil
+}
+
+// GetKLine returns up to limit candles of market with the given period starting in [from, to], oldest first.
+// The exchange supports periods of whole minutes (1m, 5m, 15m, 30m, 1h, 2h, 4h, 6h, 12h, 1d, 3d, 1w)
+func (c *Client) GetKLine(ctx context.Context, market string, period time.Duration, from, to time.Time, limit int) ([]KLine, error) {
+	query := url.Values{
+		"market":    {market},
+		"period":    {strconv.FormatInt(int64(period/time.Minute), 10)},
+		"time_from": {strconv.FormatInt(from.Unix(), 10)},
+		"time_to":   {strconv.FormatInt(to.Unix(), 10)},
+		"limit":     {strconv.Itoa(limit)},
+	}
+
+	var resp [][]json.Number
+	if err := c.getJSON(ctx, "k", "/api/v2/k?"+query.Encode(), &resp); err != nil {
+		return nil, err
+	}
+
+	candles := make([]KLine, 0, len(resp))
+	for _, row := range resp {
+		candle, err := parseKLine(row)
+		if err != nil {
+			return nil, err
+		}
+		candles = append(candles, candle)
+	}
+	return candles, nil
+}
+
+// parseKLine converts a [timestamp, open, high, low, close, volume] row into a candle
+func parseKLine(row []json.Number) (KLine, error) {
+	if len(row) != 6 {
+		return KLine{}, fmt.Errorf("invalid candle %v: want 6 fields", row)
+	}
+
+	start, err := row[0].Int64()
+	if err != nil {
+		return KLine{}, fmt.Errorf("invalid candle timestamp %q: %w", row[0], err)
+	}
+
+	values := make([]decimal.Decimal, 5)
+	for i := range values {
+		if values[i], err = parsePrice(row[i+1].String()); err != nil {
+			return KLine{}, fmt.Errorf("failed to parse candle at %d: %w", start, err)
+		}
+	}
+
+	return KLine{
+		Start:  time.Unix(start, 0).UTC(),
+		Open:   values[0],
+		High:   values[1],
+		Low:    values[2],
+		Close:  values[3],
+		Volume: values[4],
+	}, nil
+}
+
+// getJSON fetches path after waiting for the endpoint's rate limit and decodes the JSON response into out
+func (c *Client) getJSON(ctx context.Context, endpoint, path string, out any) error {
+	if err := c.limiter.Wait(ctx, endpoint); err != nil {
+		return err
+	}
+
+	target := c.baseURL + path
+	c.logger.Debug("Fetching from exchange", "url", target)
+
+	req, err := http.NewRequestWithContext(ctx, "GET", target, nil)
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to make request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return fmt.Errorf("failed to read response body: %w", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("unexpected status code: %d, body: %s", resp.StatusCode, string(body))
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(body))
+	decoder.UseNumber()
+	if err := decoder.Decode(out); err != nil {
+		return fmt.Errorf("failed to unmarshal response: %w", err)
+	}
+	return nil
+}
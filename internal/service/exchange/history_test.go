@@ -0,0 +1,93 @@
+package exchange
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/cawa87/garantex-test/internal/lib/logger/sl"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetTrades(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/api/v2/trades", r.URL.Path)
+		assert.Equal(t, "btcusdt", r.URL.Query().Get("market"))
+		assert.Equal(t, "41", r.URL.Query().Get("from"))
+		assert.Equal(t, "asc", r.URL.Query().Get("order_by"))
+		_, _ = w.Write([]byte(`[
+			{"id": 42, "price": "100.5", "volume": "0.1", "funds": "10.05", "market": "btcusdt", "created_at": "2024-01-01T03:00:00+03:00", "side": "buy"},
+			{"id": 43, "price": "101", "volume": "0.2", "funds": "20.2", "market": "btcusdt", "created_at": "2024-01-01T03:00:05+03:00", "side": "sell"}
+		]`))
+	}))
+	defer server.Close()
+
+	logger, err := sl.New("info")
+	require.NoError(t, err)
+	client := NewClient(server.URL, 10*time.Second, logger)
+
+	trades, err := client.GetTrades(context.Background(), "btcusdt", 41, 100)
+	require.NoError(t, err)
+	require.Len(t, trades, 2)
+	assert.Equal(t, int64(42), trades[0].ID)
+	assert.True(t, decimal.RequireFromString("100.5").Equal(trades[0].Price))
+	assert.True(t, time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC).Equal(trades[0].CreatedAt))
+	assert.True(t, decimal.RequireFromString("0.2").Equal(trades[1].Volume))
+}
+
+func TestGetLastTradeBefore(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "1704067200", r.URL.Query().Get("timestamp"))
+		assert.Equal(t, "desc", r.URL.Query().Get("order_by"))
+		_, _ = w.Write([]byte(`[]`))
+	}))
+	defer server.Close()
+
+	logger, err := sl.New("info")
+	require.NoError(t, err)
+	client := NewClient(server.URL, 10*time.Second, logger)
+
+	trade, err := client.GetLastTradeBefore(context.Background(), "btcusdt", time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+	require.NoError(t, err)
+	assert.Nil(t, trade)
+}
+
+func TestGetKLine(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/api/v2/k", r.URL.Path)
+		assert.Equal(t, "5", r.URL.Query().Get("period"))
+		assert.Equal(t, "1704067200", r.URL.Query().Get("time_from"))
+		_, _ = w.Write([]byte(`[[1704067200, 100.1, 102, 99.5, 101.25, 3.5], [1704067500, 101.25, 101.3, 100, 100.00000001, 1]]`))
+	}))
+	defer server.Close()
+
+	logger, err := sl.New("info")
+	require.NoError(t, err)
+	client := NewClient(server.URL, 10*time.Second, logger)
+
+	from := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	candles, err := client.GetKLine(context.Background(), "btcusdt", 5*time.Minute, from, from.Add(time.Hour), 100)
+	require.NoError(t, err)
+	require.Len(t, candles, 2)
+	assert.Equal(t, from, candles[0].Start)
+	assert.True(t, decimal.RequireFromString("101.25").Equal(candles[0].Close))
+	assert.True(t, decimal.RequireFromString("100.00000001").Equal(candles[1].Close))
+}
+
+func TestParseKLine_Invalid(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`[[1704067200, 100.1]]`))
+	}))
+	defer server.Close()
+
+	logger, err := sl.New("info")
+	require.NoError(t, err)
+	client := NewClient(server.URL, 10*time.Second, logger)
+
+	_, err = client.GetKLine(context.Background(), "btcusdt", time.Minute, time.Now(), time.Now(), 10)
+	assert.ErrorContains(t, err, "want 6 fields")
+}
@@ -0,0 +1,182 @@
+package exchange
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+	"golang.org/x/time/rate"
+)
+
+// ErrRateLimited is returned when an outbound request cannot be admitted by the limiter before its context deadline
+var ErrRateLimited = errors.New("exchange rate limit exceeded")
+
+// providerBucket is the endpoint label used for the provider-wide bucket
+const providerBucket = "*"
+
+// RateLimit describes a token bucket with a sustained rate and a burst size
+type RateLimit struct {
+	RPS   float64
+	Burst int
+}
+
+// RateLimitError describes a request rejected by the outbound rate limiter
+type RateLimitError struct {
+	Provider string
+	Endpoint string
+	Wait     time.Duration
+}
+
+func (e *RateLimitError) Error() string {
+	return fmt.Sprintf("%s: provider %s, endpoint %s, required wait %s", ErrRateLimited, e.Provider, e.Endpoint, e.Wait)
+}
+
+// Unwrap allows errors.Is(err, ErrRateLimited)
+func (e *RateLimitError) Unwrap() error {
+	return ErrRateLimited
+}
+
+// Limiter is a token-bucket limiter for outbound exchange calls with a provider-wide bucket and optional per-endpoint buckets
+type Limiter struct {
+	provider  string
+	global    *rate.Limiter
+	endpoints map[string]*rate.Limiter
+
+	waitDuration metric.Float64Histogram
+	rejected     metric.Int64Counter
+}
+
+// NewLimiter creates a limiter for the provider; a zero RPS means the bucket is unlimited
+func NewLimiter(provider string, limit RateLimit, endpoints map[string]RateLimit) *Limiter {
+	l := &Limiter{
+		provider:  provider,
+		global:    newBucket(limit),
+		endpoints: make(map[string]*rate.Limiter, len(endpoints)),
+	}
+	for endpoint, endpointLimit := range endpoints {
+		l.endpoints[endpoint] = newBucket(endpointLimit)
+	}
+
+	l.initMetrics()
+	return l
+}
+
+// Wait blocks until the request to endpoint is admitted. It returns a *RateLimitError up front if admission
+// would exceed the context deadline, and ctx.Err() if the context ends while waiting
+func (l *Limiter) Wait(ctx context.Context, endpoint string) error {
+	if l == nil {
+		return nil
+	}
+
+	now := time.Now()
+	buckets := []*rate.Limiter{l.global}
+	if bucket, ok := l.endpoints[endpoint]; ok {
+		buckets = append(buckets, bucket)
+	}
+
+	reservations := make([]*rate.Reservation, 0, len(buckets))
+	cancelAll := func() {
+		for _, r := range reservations {
+			r.CancelAt(time.Now())
+		}
+	}
+
+	var delay time.Duration
+	for _, bucket := range buckets {
+		r := bucket.ReserveN(now, 1)
+		if !r.OK() {
+			cancelAll()
+			return l.reject(ctx, endpoint, rate.InfDuration)
+		}
+		reservations = append(reservations, r)
+		if d := r.DelayFrom(now); d > delay {
+			delay = d
+		}
+	}
+
+	if deadline, ok := ctx.Deadline(); ok && now.Add(delay).After(deadline) {
+		cancelAll()
+		return l.reject(ctx, endpoint, delay)
+	}
+
+	if delay > 0 {
+		timer := time.NewTimer(delay)
+		defer timer.Stop()
+
+		select {
+		case <-timer.C:
+		case <-ctx.Done():
+			// The caller gave up, e.g. the client cancelled; the limit did not reject the request
+			cancelAll()
+			return ctx.Err()
+		}
+	}
+
+	l.waitDuration.Record(ctx, delay.Seconds(), metric.WithAttributes(l.attributes(endpoint)...))
+	return nil
+}
+
+func (l *Limiter) reject(ctx context.Context, endpoint string, wait time.Duration) error {
+	l.rejected.Add(ctx, 1, metric.WithAttributes(l.attributes(endpoint)...))
+	return &RateLimitError{Provider: l.provider, Endpoint: endpoint, Wait: wait}
+}
+
+func (l *Limiter) attributes(endpoint string) []attribute.KeyValue {
+	return []attribute.KeyValue{
+		attribute.String("provider", l.provider),
+		attribute.String("endpoint", endpoint),
+	}
+}
+
+// initMetrics registers wait, rejection and saturation instruments on the global meter provider
+func (l *Limiter) initMetrics() {
+	meter := otel.Meter("exchange")
+
+	l.waitDuration, _ = meter.Float64Histogram("exchange_rate_limiter_wait",
+		metric.WithUnit("s"),
+		metric.WithDescription("Time outbound requests spent queued in the rate limiter"))
+	l.rejected, _ = meter.Int64Counter("exchange_rate_limiter_rejected",
+		metric.WithDescription("Outbound requests rejected by the rate limiter"))
+
+	_, _ = meter.Float64ObservableGauge("exchange_rate_limiter_saturation",
+		metric.WithDescription("Fraction of the token bucket in use (0 = idle, 1 = exhausted)"),
+		metric.WithFloat64Callback(func(_ context.Context, o metric.Float64Observer) error {
+			o.Observe(saturation(l.global), metric.WithAttributes(l.attributes(providerBucket)...))
+			for endpoint, bucket := range l.endpoints {
+				o.Observe(saturation(bucket), metric.WithAttributes(l.attributes(endpoint)...))
+			}
+			return nil
+		}))
+}
+
+func newBucket(limit RateLimit) *rate.Limiter {
+	if limit.RPS <= 0 {
+		return rate.NewLimiter(rate.Inf, 0)
+	}
+
+	burst := limit.Burst
+	if burst < 1 {
+		burst = 1
+	}
+	return rate.NewLimiter(rate.Limit(limit.RPS), burst)
+}
+
+// saturation returns the used fraction of the bucket, clamped to [0, 1]
+func saturation(bucket *rate.Limiter) float64 {
+	if bucket.Limit() == rate.Inf || bucket.Burst() == 0 {
+		return 0
+	}
+
+	used := 1 - bucket.Tokens()/float64(bucket.Burst())
+	if used < 0 {
+		return 0
+	}
+	if used > 1 {
+		return 1
+	}
+	return used
+}
@@ -0,0 +1,120 @@
+package exchange
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/cawa87/garantex-test/internal/lib/logger/sl"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLimiter_Unlimited(t *testing.T) {
+	limiter := NewLimiter("garantex", RateLimit{}, nil)
+
+	ctx := context.Background()
+	for i := 0; i < 100; i++ {
+		require.NoError(t, limiter.Wait(ctx, "depth"))
+	}
+}
+
+func TestLimiter_NilIsNoop(t *testing.T) {
+	var limiter *Limiter
+	assert.NoError(t, limiter.Wait(context.Background(), "depth"))
+}
+
+func TestLimiter_QueuesWithinDeadline(t *testing.T) {
+	limiter := NewLimiter("garantex", RateLimit{RPS: 20, Burst: 1}, nil)
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	start := time.Now()
+	require.NoError(t, limiter.Wait(ctx, "depth"))
+	require.NoError(t, limiter.Wait(ctx, "depth"))
+
+	assert.GreaterOrEqual(t, time.Since(start), 40*time.Millisecond)
+}
+
+func TestLimiter_RejectsPastDeadline(t *testing.T) {
+	limiter := NewLimiter("garantex", RateLimit{RPS: 1, Burst: 1}, nil)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+
+	require.NoError(t, limiter.Wait(ctx, "depth"))
+
+	start := time.Now()
+	err := limiter.Wait(ctx, "depth")
+	require.Error(t, err)
+	assert.True(t, errors.Is(err, ErrRateLimited))
+	assert.Less(t, time.Since(start), 50*time.Millisecond, "should reject without waiting")
+
+	var rlErr *RateLimitError
+	require.True(t, errors.As(err, &rlErr))
+	assert.Equal(t, "garantex", rlErr.Provider)
+	assert.Equal(t, "depth", rlErr.Endpoint)
+}
+
+func TestLimiter_CancelledWhileWaiting(t *testing.T) {
+	limiter := NewLimiter("garantex", RateLimit{RPS: 5, Burst: 1}, nil)
+	require.NoError(t, limiter.Wait(context.Background(), "depth"))
+
+	// No deadline, so the request queues; cancelling it is not a rate limit rejection
+	ctx, cancel := context.WithCancel(context.Background())
+	time.AfterFunc(20*time.Millisecond, cancel)
+
+	err := limiter.Wait(ctx, "depth")
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.NotErrorIs(t, err, ErrRateLimited)
+
+	// The cancelled reservation is returned, so the next request is admitted on schedule
+	start := time.Now()
+	require.NoError(t, limiter.Wait(context.Background(), "depth"))
+	assert.Less(t, time.Since(start), 250*time.Millisecond)
+}
+
+func TestLimiter_PerEndpoint(t *testing.T) {
+	limiter := NewLimiter("garantex", RateLimit{}, map[string]RateLimit{
+		"depth": {RPS: 1, Burst: 1},
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+
+	require.NoError(t, limiter.Wait(ctx, "depth"))
+	assert.ErrorIs(t, limiter.Wait(ctx, "depth"), ErrRateLimited)
+
+	// Other endpoints only share the unlimited provider bucket
+	assert.NoError(t, limiter.Wait(ctx, "trades"))
+}
+
+func TestGetRates_RateLimited(t *testing.T) {
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"timestamp": 1755631475, "asks": [{"price": "100.55"}], "bids": [{"price": "100.40"}]}`))
+	}))
+	defer server.Close()
+
+	logger, err := sl.New("info")
+	require.NoError(t, err)
+
+	limiter := NewLimiter("garantex", RateLimit{RPS: 0.1, Burst: 1}, nil)
+	client := NewClient(server.URL, 10*time.Second, logger, WithRateLimiter(limiter))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+
+	_, err = client.GetRates(ctx)
+	require.NoError(t, err)
+
+	_, err = client.GetRates(ctx)
+	assert.ErrorIs(t, err, ErrRateLimited)
+	assert.Equal(t, 1, requests)
+}
@@ -0,0 +1,75 @@
+package exchange
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+// DefaultMarket is the market the service tracks when none is specified
+const DefaultMarket = "btcusdt"
+
+// PriceLevel is a single aggregated order book level
+type PriceLevel struct {
+	Price  decimal.Decimal
+	Volume decimal.Decimal
+}
+
+// OrderBookSnapshot is the full order book of a market at a point in time.
+// Asks are sorted by ascending price and bids by descending price, as returned by the exchange
+type OrderBookSnapshot struct {
+	Market    string
+	Timestamp time.Time
+	Asks      []PriceLevel
+	Bids      []PriceLevel
+}
+
+// Truncate returns a copy of the snapshot limited to the best depth levels per side; depth <= 0 keeps all levels
+func (s *OrderBookSnapshot) Truncate(depth int) *OrderBookSnapshot {
+	out := *s
+	if depth > 0 {
+		if len(out.Asks) > depth {
+			out.Asks = out.Asks[:depth]
+		}
+		if len(out.Bids) > depth {
+			out.Bids = out.Bids[:depth]
+		}
+	}
+	return &out
+}
+
+// parseOrderBook converts raw depth entries into a snapshot
+func parseOrderBook(market string, timestamp time.Time, depth *DepthResponse) (*OrderBookSnapshot, error) {
+	asks, err := parseLevels(depth.Asks)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse asks: %w", err)
+	}
+	bids, err := parseLevels(depth.Bids)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse bids: %w", err)
+	}
+
+	return &OrderBookSnapshot{
+		Market:    market,
+		Timestamp: timestamp,
+		Asks:      asks,
+		Bids:      bids,
+	}, nil
+}
+
+func parseLevels(entries []OrderBook) ([]PriceLevel, error) {
+	levels := make([]PriceLevel, 0, len(entries))
+	for i, entry := range entries {
+		price, err := parsePrice(entry.Price)
+		if err != nil {
+			return nil, fmt.Errorf("level %d: %w", i, err)
+		}
+		volume, err := decimal.NewFromString(entry.Volume)
+		if err != nil {
+			return nil, fmt.Errorf("level %d: invalid volume format: %s", i, entry.Volume)
+		}
+		levels = append(levels, PriceLevel{Price: price, Volume: volume})
+	}
+	return levels, nil
+}
@@ -0,0 +1,46 @@
+package exchange
+
+import (
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseOrderBook(t *testing.T) {
+	now := time.Now()
+	book, err := parseOrderBook(DefaultMarket, now, &DepthResponse{
+		Asks: []OrderBook{{Price: "101.5", Volume: "2"}, {Price: "102", Volume: "0.5"}},
+		Bids: []OrderBook{{Price: "100.25", Volume: "1.125"}},
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, DefaultMarket, book.Market)
+	assert.Equal(t, now, book.Timestamp)
+	require.Len(t, book.Asks, 2)
+	assert.True(t, decimal.RequireFromString("102").Equal(book.Asks[1].Price))
+	require.Len(t, book.Bids, 1)
+	assert.True(t, decimal.RequireFromString("1.125").Equal(book.Bids[0].Volume))
+
+	_, err = parseOrderBook(DefaultMarket, now, &DepthResponse{
+		Bids: []OrderBook{{Price: "100", Volume: "lots"}},
+	})
+	assert.ErrorContains(t, err, "failed to parse bids")
+}
+
+func TestOrderBookSnapshotTruncate(t *testing.T) {
+	level := PriceLevel{Price: decimal.NewFromInt(1), Volume: decimal.NewFromInt(1)}
+	book := &OrderBookSnapshot{
+		Asks: []PriceLevel{level, level, level},
+		Bids: []PriceLevel{level},
+	}
+
+	truncated := book.Truncate(2)
+	assert.Len(t, truncated.Asks, 2)
+	assert.Len(t, truncated.Bids, 1)
+	assert.Len(t, book.Asks, 3)
+
+	assert.Len(t, book.Truncate(0).Asks, 3)
+}
@@ -0,0 +1,113 @@
+package export
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"github.com/parquet-go/parquet-go"
+)
+
+// parquetBatchSize is the number of rows buffered before they are handed to the parquet writer
+const parquetBatchSize = 1024
+
+// parquetRowGroupSize bounds the rows held in memory for one parquet row group
+const parquetRowGroupSize = 64 * 1024
+
+type encoder[T row] interface {
+	Encode(r T) error
+	Close() error
+}
+
+func newEncoder[T row](w io.Writer, format Format) (encoder[T], error) {
+	switch format {
+	case FormatCSV:
+		return &csvEncoder[T]{w: csv.NewWriter(w)}, nil
+	case FormatNDJSON:
+		return &jsonEncoder[T]{enc: json.NewEncoder(w)}, nil
+	case FormatParquet:
+		return &parquetEncoder[T]{
+			w: parquet.NewGenericWriter[T](w,
+				parquet.MaxRowsPerRowGroup(parquetRowGroupSize),
+				parquet.Compression(&parquet.Snappy)),
+			batch: make([]T, 0, parquetBatchSize),
+		}, nil
+	default:
+		return nil, fmt.Errorf("unknown export format %q", format)
+	}
+}
+
+// csvEncoder writes a header row followed by one record per row; the header is written even when
+// there are no rows
+type csvEncoder[T row] struct {
+	w      *csv.Writer
+	header bool
+}
+
+func (e *csvEncoder[T]) writeHeader() error {
+	if e.header {
+		return nil
+	}
+	e.header = true
+	var zero T
+	return e.w.Write(zero.header())
+}
+
+func (e *csvEncoder[T]) Encode(r T) error {
+	if err := e.writeHeader(); err != nil {
+		return err
+	}
+	return e.w.Write(r.record())
+}
+
+func (e *csvEncoder[T]) Close() error {
+	if err := e.writeHeader(); err != nil {
+		return err
+	}
+	e.w.Flush()
+	return e.w.Error()
+}
+
+type jsonEncoder[T row] struct {
+	enc *json.Encoder
+}
+
+func (e *jsonEncoder[T]) Encode(r T) error {
+	return e.enc.Encode(r)
+}
+
+func (e *jsonEncoder[T]) Close() error {
+	return nil
+}
+
+type parquetEncoder[T row] struct {
+	w     *parquet.GenericWriter[T]
+	batch []T
+}
+
+func (e *parquetEncoder[T]) Encode(r T) error {
+	e.batch = append(e.batch, r)
+	if len(e.batch) == cap(e.batch) {
+		return e.flush()
+	}
+	return nil
+}
+
+func (e *parquetEncoder[T]) flush() error {
+	if len(e.batch) == 0 {
+		return nil
+	}
+	if _, err := e.w.Write(e.batch); err != nil {
+		return err
+	}
+	e.batch = e.batch[:0]
+	return nil
+}
+
+func (e *parquetEncoder[T]) Close() error {
+	if err := e.flush(); err != nil {
+		return err
+	}
+	return e.w.Close()
+}
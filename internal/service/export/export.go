@@ -0,0 +1,113 @@
+package export
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/cawa87/garantex-test/internal/repository"
+)
+
+// Format is the file format of an export
+type Format string
+
+const (
+	// FormatCSV writes comma-separated values with a header row
+	FormatCSV Format = "csv"
+	// FormatNDJSON writes one JSON object per line
+	FormatNDJSON Format = "ndjson"
+	// FormatParquet writes an Apache Parquet file
+	FormatParquet Format = "parquet"
+)
+
+// ParseFormat parses an export format
+func ParseFormat(s string) (Format, error) {
+	switch f := Format(s); f {
+	case FormatCSV, FormatNDJSON, FormatParquet:
+		return f, nil
+	default:
+		return "", fmt.Errorf("unknown export format %q", s)
+	}
+}
+
+// Store streams stored history
+type Store interface {
+	StreamRates(ctx context.Context, market string, from, to time.Time, fn func(*repository.Rate) error) error
+	StreamCandles(ctx context.Context, market string, interval time.Duration, from, to time.Time, fn func(*repository.Candle) error) error
+}
+
+// Query selects the history to export: the rates of Market in [From, To), raw when Interval is zero and
+// as candles of Interval otherwise
+type Query struct {
+	Market   string
+	From     time.Time
+	To       time.Time
+	Interval time.Duration
+	Format   Format
+}
+
+// Validate checks the query
+func (q Query) Validate() error {
+	if q.Market == "" {
+		return fmt.Errorf("export market is required")
+	}
+	if !q.From.Before(q.To) {
+		return fmt.Errorf("export window start %s is not before its end %s", q.From, q.To)
+	}
+	if q.Interval != 0 {
+		if err := repository.CheckCandleInterval(q.Interval); err != nil {
+			return err
+		}
+	}
+	if _, err := ParseFormat(string(q.Format)); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Write streams the history selected by q to w and returns the number of rows written. Rows are encoded
+// as they are read, so memory use does not grow with the size of the export
+func Write(ctx context.Context, store Store, w io.Writer, q Query) (int64, error) {
+	if err := q.Validate(); err != nil {
+		return 0, err
+	}
+
+	if q.Interval == 0 {
+		return write(w, q.Format, func(emit func(rateRow) error) error {
+			return store.StreamRates(ctx, q.Market, q.From, q.To, func(rate *repository.Rate) error {
+				return emit(newRateRow(rate))
+			})
+		})
+	}
+	return write(w, q.Format, func(emit func(candleRow) error) error {
+		return store.StreamCandles(ctx, q.Market, q.Interval, q.From, q.To, func(c *repository.Candle) error {
+			return emit(newCandleRow(c, q.Interval))
+		})
+	})
+}
+
+// write encodes the rows produced by stream in the format
+func write[T row](w io.Writer, format Format, stream func(emit func(T) error) error) (int64, error) {
+	enc, err := newEncoder[T](w, format)
+	if err != nil {
+		return 0, err
+	}
+
+	var written int64
+	err = stream(func(r T) error {
+		if err := enc.Encode(r); err != nil {
+			return fmt.Errorf("failed to encode row %d: %w", written+1, err)
+		}
+		written++
+		return nil
+	})
+	if err != nil {
+		return written, err
+	}
+
+	if err := enc.Close(); err != nil {
+		return written, fmt.Errorf("failed to finish %s export: %w", format, err)
+	}
+	return written, nil
+}
@@ -0,0 +1,180 @@
+package export
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/cawa87/garantex-test/internal/repository"
+	"github.com/parquet-go/parquet-go"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeStore struct {
+	rates []*repository.Rate
+	err   error
+}
+
+func (s *fakeStore) StreamRates(_ context.Context, market string, from, to time.Time, fn func(*repository.Rate) error) error {
+	for _, rate := range s.rates {
+		if rate.Market != market || rate.Timestamp.Before(from) || !rate.Timestamp.Before(to) {
+			continue
+		}
+		if err := fn(rate); err != nil {
+			return err
+		}
+	}
+	return s.err
+}
+
+func (s *fakeStore) StreamCandles(ctx context.Context, market string, interval time.Duration, from, to time.Time, fn func(*repository.Candle) error) error {
+	builder := repository.CandleBuilder{Interval: interval, Emit: fn}
+	if err := s.StreamRates(ctx, market, from, to, builder.Add); err != nil {
+		return err
+	}
+	return builder.Flush()
+}
+
+var start = time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+
+func newStore() *fakeStore {
+	store := &fakeStore{}
+	for i := 0; i < 120; i++ {
+		store.rates = append(store.rates, &repository.Rate{
+			Market:     "btcusdt",
+			Source:     "garantex",
+			Ask:        decimal.RequireFromString("65000.10").Add(decimal.NewFromInt(int64(i))),
+			Bid:        decimal.RequireFromString("64999.90").Add(decimal.NewFromInt(int64(i))),
+			Timestamp:  start.Add(time.Duration(i) * time.Second),
+			ReceivedAt: start.Add(time.Duration(i)*time.Second + 150*time.Millisecond),
+			Latency:    150 * time.Millisecond,
+		})
+	}
+	store.rates = append(store.rates, &repository.Rate{
+		Market:    "ethusdt",
+		Ask:       decimal.NewFromInt(3000),
+		Bid:       decimal.NewFromInt(2999),
+		Timestamp: start,
+	})
+	return store
+}
+
+func TestWrite_CSV(t *testing.T) {
+	var buf bytes.Buffer
+	n, err := Write(context.Background(), newStore(), &buf, Query{
+		Market: "btcusdt",
+		From:   start,
+		To:     start.Add(time.Minute),
+		Format: FormatCSV,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, int64(60), n)
+
+	records, err := csv.NewReader(&buf).ReadAll()
+	require.NoError(t, err)
+	require.Len(t, records, 61)
+	assert.Equal(t, []string{"market", "source", "timestamp", "ask", "bid", "received_at", "latency_us"}, records[0])
+	assert.Equal(t, []string{
+		"btcusdt", "garantex", "2024-03-01T12:00:00Z", "65000.1", "64999.9", "2024-03-01T12:00:00.15Z", "150000",
+	}, records[1])
+}
+
+func TestWrite_EmptyCSV(t *testing.T) {
+	var buf bytes.Buffer
+	n, err := Write(context.Background(), newStore(), &buf, Query{
+		Market:   "btcusdt",
+		From:     start.Add(time.Hour),
+		To:       start.Add(2 * time.Hour),
+		Interval: time.Minute,
+		Format:   FormatCSV,
+	})
+	require.NoError(t, err)
+	assert.Zero(t, n)
+
+	records, err := csv.NewReader(&buf).ReadAll()
+	require.NoError(t, err)
+	require.Len(t, records, 1)
+	assert.Equal(t, "samples", records[0][len(records[0])-1])
+}
+
+func TestWrite_NDJSONCandles(t *testing.T) {
+	var buf bytes.Buffer
+	n, err := Write(context.Background(), newStore(), &buf, Query{
+		Market:   "btcusdt",
+		From:     start,
+		To:       start.Add(time.Hour),
+		Interval: time.Minute,
+		Format:   FormatNDJSON,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), n)
+
+	var candles []map[string]any
+	scanner := bufio.NewScanner(&buf)
+	for scanner.Scan() {
+		var candle map[string]any
+		require.NoError(t, json.Unmarshal(scanner.Bytes(), &candle))
+		candles = append(candles, candle)
+	}
+	require.Len(t, candles, 2)
+	assert.Equal(t, "2024-03-01T12:01:00Z", candles[1]["start"])
+	assert.Equal(t, "2024-03-01T12:02:00Z", candles[1]["end"])
+	assert.Equal(t, "65060.1", candles[1]["ask_open"])
+	assert.Equal(t, "65119.1", candles[1]["ask_high"])
+	assert.Equal(t, float64(60), candles[1]["samples"])
+}
+
+func TestWrite_Parquet(t *testing.T) {
+	var buf bytes.Buffer
+	n, err := Write(context.Background(), newStore(), &buf, Query{
+		Market: "btcusdt",
+		From:   start,
+		To:     start.Add(time.Hour),
+		Format: FormatParquet,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, int64(120), n)
+
+	rows, err := parquet.Read[rateRow](bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	require.NoError(t, err)
+	require.Len(t, rows, 120)
+	assert.Equal(t, "65119.1", rows[119].Ask)
+	assert.True(t, start.Add(119*time.Second).Equal(rows[119].Timestamp))
+	assert.Equal(t, int64(150000), rows[119].LatencyUS)
+}
+
+func TestWrite_StoreError(t *testing.T) {
+	store := newStore()
+	store.err = errors.New("connection lost")
+
+	_, err := Write(context.Background(), store, &bytes.Buffer{}, Query{
+		Market: "btcusdt",
+		From:   start,
+		To:     start.Add(time.Hour),
+		Format: FormatNDJSON,
+	})
+	assert.ErrorIs(t, err, store.err)
+}
+
+func TestQuery_Validate(t *testing.T) {
+	valid := Query{Market: "btcusdt", From: start, To: start.Add(time.Hour), Format: FormatCSV}
+	assert.NoError(t, valid.Validate())
+
+	for name, mutate := range map[string]func(*Query){
+		"no market":      func(q *Query) { q.Market = "" },
+		"empty window":   func(q *Query) { q.To = q.From },
+		"bad interval":   func(q *Query) { q.Interval = 1500 * time.Millisecond },
+		"unknown format": func(q *Query) { q.Format = "xml" },
+	} {
+		q := valid
+		mutate(&q)
+		assert.Error(t, q.Validate(), name)
+	}
+}
@@ -0,0 +1,111 @@
+package export
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/cawa87/garantex-test/internal/repository"
+)
+
+// row is an exported record; prices are exact decimal strings in every format
+type row interface {
+	rateRow | candleRow
+	header() []string
+	record() []string
+}
+
+// rateRow is an exported raw rate
+type rateRow struct {
+	Market     string    `json:"market" parquet:"market"`
+	Source     string    `json:"source" parquet:"source"`
+	Timestamp  time.Time `json:"timestamp" parquet:"timestamp,timestamp(microsecond)"`
+	Ask        string    `json:"ask" parquet:"ask"`
+	Bid        string    `json:"bid" parquet:"bid"`
+	ReceivedAt time.Time `json:"received_at" parquet:"received_at,timestamp(microsecond)"`
+	LatencyUS  int64     `json:"latency_us" parquet:"latency_us"`
+}
+
+func newRateRow(rate *repository.Rate) rateRow {
+	return rateRow{
+		Market:     rate.Market,
+		Source:     rate.Source,
+		Timestamp:  rate.Timestamp.UTC(),
+		Ask:        rate.Ask.String(),
+		Bid:        rate.Bid.String(),
+		ReceivedAt: rate.ReceivedAt.UTC(),
+		LatencyUS:  rate.Latency.Microseconds(),
+	}
+}
+
+func (rateRow) header() []string {
+	return []string{"market", "source", "timestamp", "ask", "bid", "received_at", "latency_us"}
+}
+
+func (r rateRow) record() []string {
+	return []string{
+		r.Market,
+		r.Source,
+		formatTime(r.Timestamp),
+		r.Ask,
+		r.Bid,
+		formatTime(r.ReceivedAt),
+		strconv.FormatInt(r.LatencyUS, 10),
+	}
+}
+
+// candleRow is an exported candle covering [Start, End)
+type candleRow struct {
+	Market   string    `json:"market" parquet:"market"`
+	Start    time.Time `json:"start" parquet:"start,timestamp(microsecond)"`
+	End      time.Time `json:"end" parquet:"end,timestamp(microsecond)"`
+	AskOpen  string    `json:"ask_open" parquet:"ask_open"`
+	AskHigh  string    `json:"ask_high" parquet:"ask_high"`
+	AskLow   string    `json:"ask_low" parquet:"ask_low"`
+	AskClose string    `json:"ask_close" parquet:"ask_close"`
+	BidOpen  string    `json:"bid_open" parquet:"bid_open"`
+	BidHigh  string    `json:"bid_high" parquet:"bid_high"`
+	BidLow   string    `json:"bid_low" parquet:"bid_low"`
+	BidClose string    `json:"bid_close" parquet:"bid_close"`
+	Samples  int64     `json:"samples" parquet:"samples"`
+}
+
+func newCandleRow(c *repository.Candle, interval time.Duration) candleRow {
+	return candleRow{
+		Market:   c.Market,
+		Start:    c.Start.UTC(),
+		End:      c.Start.Add(interval).UTC(),
+		AskOpen:  c.AskOpen.String(),
+		AskHigh:  c.AskHigh.String(),
+		AskLow:   c.AskLow.String(),
+		AskClose: c.AskClose.String(),
+		BidOpen:  c.BidOpen.String(),
+		BidHigh:  c.BidHigh.String(),
+		BidLow:   c.BidLow.String(),
+		BidClose: c.BidClose.String(),
+		Samples:  c.Samples,
+	}
+}
+
+func (candleRow) header() []string {
+	return []string{
+		"market", "start", "end",
+		"ask_open", "ask_high", "ask_low", "ask_close",
+		"bid_open", "bid_high", "bid_low", "bid_close",
+		"samples",
+	}
+}
+
+func (r candleRow) record() []string {
+	return []string{
+		r.Market,
+		formatTime(r.Start),
+		formatTime(r.End),
+		r.AskOpen, r.AskHigh, r.AskLow, r.AskClose,
+		r.BidOpen, r.BidHigh, r.BidLow, r.BidClose,
+		strconv.FormatInt(r.Samples, 10),
+	}
+}
+
+func formatTime(t time.Time) string {
+	return t.UTC().Format(time.RFC3339Nano)
+}
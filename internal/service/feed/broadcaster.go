@@ -0,0 +1,70 @@
+package feed
+
+import (
+	"context"
+	"sync"
+
+	"github.com/cawa87/garantex-test/internal/repository"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/metric"
+)
+
+// Broadcaster fans new rates out to the subscribers of this instance. A subscriber that does not keep up
+// misses rates rather than slowing the others down
+type Broadcaster struct {
+	mu   sync.Mutex
+	subs map[chan *repository.Rate]struct{}
+
+	dropped metric.Int64Counter
+}
+
+// NewBroadcaster creates a broadcaster without subscribers
+func NewBroadcaster() *Broadcaster {
+	b := &Broadcaster{
+		subs: make(map[chan *repository.Rate]struct{}),
+	}
+	b.dropped, _ = otel.Meter("feed").Int64Counter("feed_rates_dropped",
+		metric.WithDescription("Rates not delivered to a subscriber whose buffer was full"))
+	return b
+}
+
+// Subscribe returns a channel receiving new rates, buffering up to buffer of them, and a function that
+// unsubscribes and closes the channel
+func (b *Broadcaster) Subscribe(buffer int) (<-chan *repository.Rate, func()) {
+	ch := make(chan *repository.Rate, buffer)
+
+	b.mu.Lock()
+	b.subs[ch] = struct{}{}
+	b.mu.Unlock()
+
+	var once sync.Once
+	return ch, func() {
+		once.Do(func() {
+			b.mu.Lock()
+			delete(b.subs, ch)
+			b.mu.Unlock()
+			close(ch)
+		})
+	}
+}
+
+// Publish delivers a rate to every subscriber with room in its buffer
+func (b *Broadcaster) Publish(rate *repository.Rate) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	for ch := range b.subs {
+		select {
+		case ch <- rate:
+		default:
+			b.dropped.Add(context.Background(), 1)
+		}
+	}
+}
+
+// Subscribers returns the number of active subscribers
+func (b *Broadcaster) Subscribers() int {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return len(b.subs)
+}
@@ -0,0 +1,38 @@
+package feed
+
+import (
+	"testing"
+
+	"github.com/cawa87/garantex-test/internal/repository"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBroadcaster(t *testing.T) {
+	b := NewBroadcaster()
+
+	fast, unsubscribeFast := b.Subscribe(2)
+	slow, unsubscribeSlow := b.Subscribe(1)
+	defer unsubscribeSlow()
+	assert.Equal(t, 2, b.Subscribers())
+
+	b.Publish(&repository.Rate{ID: 1})
+	b.Publish(&repository.Rate{ID: 2})
+
+	require.Len(t, fast, 2)
+	assert.Equal(t, int64(1), (<-fast).ID)
+	assert.Equal(t, int64(2), (<-fast).ID)
+
+	// The slow subscriber missed the rate that did not fit its buffer
+	require.Len(t, slow, 1)
+	assert.Equal(t, int64(1), (<-slow).ID)
+
+	unsubscribeFast()
+	unsubscribeFast()
+	_, open := <-fast
+	assert.False(t, open)
+	assert.Equal(t, 1, b.Subscribers())
+
+	b.Publish(&repository.Rate{ID: 3})
+	assert.Equal(t, int64(3), (<-slow).ID)
+}
@@ -0,0 +1,126 @@
+package fixing
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/cawa87/garantex-test/internal/service/exchange"
+	"github.com/shopspring/decimal"
+)
+
+// priceScale is the number of decimal places fixings are rounded to, matching the fixings table
+const priceScale = 8
+
+// ErrNoSamples is returned when a window has no data to fix on
+var ErrNoSamples = errors.New("no samples in fixing window")
+
+// Tick is a stored top-of-book rate
+type Tick struct {
+	Ask       decimal.Decimal
+	Bid       decimal.Decimal
+	Timestamp time.Time
+}
+
+// Fixing is a reference rate computed over a window.
+// Date is the calendar date of the window start in the window's time zone, as midnight UTC
+type Fixing struct {
+	ID          int64
+	Name        string
+	Market      string
+	Date        time.Time
+	Method      Method
+	Methodology string
+	WindowStart time.Time
+	WindowEnd   time.Time
+	Ask         decimal.Decimal
+	Bid         decimal.Decimal
+	Mid         decimal.Decimal
+	SampleCount int
+	ComputedAt  time.Time
+}
+
+// ListFilter selects fixings to list; zero fields match everything
+type ListFilter struct {
+	Name   string
+	Market string
+	From   time.Time
+	To     time.Time
+	Limit  int
+}
+
+// twap returns the time-weighted ask and bid over [from, to). Each tick is in force until the next one;
+// ticks before from (the rate in force at the window start) count from from but not towards samples
+func twap(ticks []Tick, from, to time.Time) (ask, bid decimal.Decimal, samples int, err error) {
+	var askSum, bidSum, total decimal.Decimal
+
+	for i, tick := range ticks {
+		if !tick.Timestamp.Before(from) && tick.Timestamp.Before(to) {
+			samples++
+		}
+
+		start := tick.Timestamp
+		if start.Before(from) {
+			start = from
+		}
+		end := to
+		if i+1 < len(ticks) && ticks[i+1].Timestamp.Before(to) {
+			end = ticks[i+1].Timestamp
+		}
+		if !end.After(start) {
+			continue
+		}
+
+		weight := decimal.NewFromInt(end.Sub(start).Nanoseconds())
+		askSum = askSum.Add(tick.Ask.Mul(weight))
+		bidSum = bidSum.Add(tick.Bid.Mul(weight))
+		total = total.Add(weight)
+	}
+
+	if samples == 0 || total.IsZero() {
+		return decimal.Zero, decimal.Zero, 0, ErrNoSamples
+	}
+	return askSum.Div(total), bidSum.Div(total), samples, nil
+}
+
+// vwap returns the volume-weighted ask and bid over the best depth levels of every snapshot
+func vwap(books []*exchange.OrderBookSnapshot, depth int) (ask, bid decimal.Decimal, samples int, err error) {
+	var askNotional, askVolume, bidNotional, bidVolume decimal.Decimal
+
+	for _, book := range books {
+		book = book.Truncate(depth)
+		for _, level := range book.Asks {
+			askNotional = askNotional.Add(level.Price.Mul(level.Volume))
+			askVolume = askVolume.Add(level.Volume)
+		}
+		for _, level := range book.Bids {
+			bidNotional = bidNotional.Add(level.Price.Mul(level.Volume))
+			bidVolume = bidVolume.Add(level.Volume)
+		}
+	}
+
+	if askVolume.IsZero() || bidVolume.IsZero() {
+		return decimal.Zero, decimal.Zero, 0, fmt.Errorf("%w: %d snapshots without volume on both sides", ErrNoSamples, len(books))
+	}
+	return askNotional.Div(askVolume), bidNotional.Div(bidVolume), len(books), nil
+}
+
+// newFixing rounds the computed prices and fills in the window metadata
+func newFixing(w Window, date, from, to time.Time, ask, bid decimal.Decimal, samples int) *Fixing {
+	ask = ask.Round(priceScale)
+	bid = bid.Round(priceScale)
+	return &Fixing{
+		Name:        w.Name,
+		Market:      w.Market,
+		Date:        date,
+		Method:      w.Method,
+		Methodology: w.Methodology(),
+		WindowStart: from,
+		WindowEnd:   to,
+		Ask:         ask,
+		Bid:         bid,
+		Mid:         ask.Add(bid).Div(decimal.NewFromInt(2)).Round(priceScale),
+		SampleCount: samples,
+		ComputedAt:  time.Now(),
+	}
+}
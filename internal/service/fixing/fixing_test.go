@@ -0,0 +1,195 @@
+package fixing
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/cawa87/garantex-test/internal/lib/logger/sl"
+	"github.com/cawa87/garantex-test/internal/service/exchange"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func dec(s string) decimal.Decimal {
+	return decimal.RequireFromString(s)
+}
+
+func TestWindow(t *testing.T) {
+	w, err := NewWindow("msk-noon", "btcusdt", "Europe/Moscow", "11:45", "12:00", "twap", 0)
+	require.NoError(t, err)
+
+	from, to := w.Bounds(time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC))
+	assert.Equal(t, time.Date(2024, 3, 1, 8, 45, 0, 0, time.UTC), from.UTC())
+	assert.Equal(t, time.Date(2024, 3, 1, 9, 0, 0, 0, time.UTC), to.UTC())
+
+	// Before the window closes the previous day is the latest completed one
+	assert.Equal(t, time.Date(2024, 2, 29, 0, 0, 0, 0, time.UTC), w.LatestCompleted(time.Date(2024, 3, 1, 8, 59, 0, 0, time.UTC)))
+	assert.Equal(t, time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC), w.LatestCompleted(time.Date(2024, 3, 1, 9, 0, 0, 0, time.UTC)))
+
+	overnight, err := NewWindow("overnight", "btcusdt", "UTC", "23:30", "00:30", "vwap", 5)
+	require.NoError(t, err)
+	from, to = overnight.Bounds(time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC))
+	assert.Equal(t, time.Hour, to.Sub(from))
+	// The window that started on Feb 29 is still open at 00:15
+	assert.Equal(t, time.Date(2024, 2, 28, 0, 0, 0, 0, time.UTC), overnight.LatestCompleted(time.Date(2024, 3, 1, 0, 15, 0, 0, time.UTC)))
+	assert.Contains(t, overnight.Methodology(), "top 5 levels")
+
+	_, err = NewWindow("bad", "btcusdt", "Mars/Olympus", "11:45", "12:00", "twap", 0)
+	assert.Error(t, err)
+	_, err = NewWindow("bad", "btcusdt", "UTC", "11:45", "12:00", "median", 0)
+	assert.Error(t, err)
+}
+
+func TestTWAP(t *testing.T) {
+	from := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+	to := from.Add(10 * time.Minute)
+
+	ticks := []Tick{
+		// In force at the window start for 2 minutes
+		{Ask: dec("100"), Bid: dec("99"), Timestamp: from.Add(-time.Minute)},
+		{Ask: dec("110"), Bid: dec("109"), Timestamp: from.Add(2 * time.Minute)},
+	}
+
+	ask, bid, samples, err := twap(ticks, from, to)
+	require.NoError(t, err)
+	assert.True(t, dec("108").Equal(ask), ask.String())
+	assert.True(t, dec("107").Equal(bid), bid.String())
+	assert.Equal(t, 1, samples)
+
+	// Only a stale tick before the window is not enough to fix on
+	_, _, _, err = twap(ticks[:1], from, to)
+	assert.ErrorIs(t, err, ErrNoSamples)
+}
+
+func TestVWAP(t *testing.T) {
+	books := []*exchange.OrderBookSnapshot{
+		{
+			Asks: []exchange.PriceLevel{{Price: dec("101"), Volume: dec("1")}, {Price: dec("102"), Volume: dec("3")}},
+			Bids: []exchange.PriceLevel{{Price: dec("99"), Volume: dec("2")}, {Price: dec("90"), Volume: dec("100")}},
+		},
+		{
+			Asks: []exchange.PriceLevel{{Price: dec("103"), Volume: dec("1")}},
+			Bids: []exchange.PriceLevel{{Price: dec("98"), Volume: dec("2")}},
+		},
+	}
+
+	ask, bid, samples, err := vwap(books, 1)
+	require.NoError(t, err)
+	assert.True(t, dec("102").Equal(ask), ask.String())
+	assert.True(t, dec("98.5").Equal(bid), bid.String())
+	assert.Equal(t, 2, samples)
+
+	_, _, _, err = vwap(nil, 1)
+	assert.ErrorIs(t, err, ErrNoSamples)
+}
+
+type fakeStore struct {
+	ticks []Tick
+	saved []*Fixing
+	loads int
+}
+
+func (s *fakeStore) GetTicks(context.Context, string, time.Time, time.Time) ([]Tick, error) {
+	s.loads++
+	return s.ticks, nil
+}
+
+func (s *fakeStore) GetOrderBooks(context.Context, string, time.Time, time.Time) ([]*exchange.OrderBookSnapshot, error) {
+	return nil, errors.New("not used")
+}
+
+func (s *fakeStore) GetFixing(_ context.Context, name string, date time.Time) (*Fixing, error) {
+	for _, f := range s.saved {
+		if f.Name == name && f.Date.Equal(date) {
+			return f, nil
+		}
+	}
+	return nil, sql.ErrNoRows
+}
+
+func (s *fakeStore) SaveFixing(_ context.Context, fixing *Fixing) error {
+	s.saved = append(s.saved, fixing)
+	return nil
+}
+
+func TestServiceComputeDue(t *testing.T) {
+	logger, err := sl.New("info")
+	require.NoError(t, err)
+
+	w, err := NewWindow("noon", "btcusdt", "UTC", "11:45", "12:00", "twap", 0)
+	require.NoError(t, err)
+
+	now := time.Date(2024, 3, 1, 12, 5, 0, 0, time.UTC)
+	store := &fakeStore{ticks: []Tick{{Ask: dec("100.123456789"), Bid: dec("99"), Timestamp: now.Add(-15 * time.Minute)}}}
+	service := NewService(store, []Window{w}, logger)
+
+	service.computeDue(context.Background(), now)
+	require.Len(t, store.saved, 1)
+
+	fixing := store.saved[0]
+	assert.Equal(t, time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC), fixing.Date)
+	assert.Equal(t, MethodTWAP, fixing.Method)
+	assert.True(t, dec("100.12345679").Equal(fixing.Ask), fixing.Ask.String())
+	assert.True(t, dec("99.56172840").Equal(fixing.Mid), fixing.Mid.String())
+	assert.Equal(t, 1, fixing.SampleCount)
+
+	// Already computed windows are skipped
+	service.computeDue(context.Background(), now.Add(time.Minute))
+	assert.Len(t, store.saved, 1)
+}
+
+func TestServiceComputeDue_SettleDelay(t *testing.T) {
+	logger, err := sl.New("info")
+	require.NoError(t, err)
+
+	w, err := NewWindow("noon", "btcusdt", "UTC", "11:45", "12:00", "twap", 0)
+	require.NoError(t, err)
+
+	closed := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+	store := &fakeStore{ticks: []Tick{{Ask: dec("100"), Bid: dec("99"), Timestamp: closed.Add(-15 * time.Minute)}}}
+	service := NewService(store, []Window{w}, logger, WithSettleDelay(30*time.Second))
+
+	// Until the window has settled, the latest due fixing is the previous day's, which has no samples
+	service.computeDue(context.Background(), closed.Add(10*time.Second))
+	assert.Empty(t, store.saved)
+
+	service.computeDue(context.Background(), closed.Add(30*time.Second))
+	require.Len(t, store.saved, 1)
+	assert.Equal(t, time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC), store.saved[0].Date)
+}
+
+func TestServiceComputeDue_NoSamples(t *testing.T) {
+	logger, err := sl.New("info")
+	require.NoError(t, err)
+
+	w, err := NewWindow("noon", "btcusdt", "UTC", "11:45", "12:00", "twap", 0)
+	require.NoError(t, err)
+
+	now := time.Date(2024, 3, 1, 12, 5, 0, 0, time.UTC)
+	store := &fakeStore{}
+	service := NewService(store, []Window{w}, logger)
+
+	// An empty window is retried after 1, 2 and 4 minutes rather than every minute
+	for minute := 0; minute < 8; minute++ {
+		service.computeDue(context.Background(), now.Add(time.Duration(minute)*time.Minute))
+	}
+	assert.Equal(t, 4, store.loads)
+	assert.Empty(t, store.saved)
+
+	// Late data is picked up by the next attempt
+	store.ticks = []Tick{{Ask: dec("100"), Bid: dec("99"), Timestamp: now.Add(-15 * time.Minute)}}
+	service.computeDue(context.Background(), now.Add(15*time.Minute))
+	require.Len(t, store.saved, 1)
+	assert.Empty(t, service.retries)
+
+	// The backoff does not carry over to the next day's window
+	store.ticks = nil
+	next := now.AddDate(0, 0, 1)
+	service.computeDue(context.Background(), next)
+	service.computeDue(context.Background(), next.Add(time.Minute))
+	assert.Equal(t, 7, store.loads)
+}
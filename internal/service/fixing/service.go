@@ -0,0 +1,184 @@
+package fixing
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/cawa87/garantex-test/internal/lib/logger/sl"
+	"github.com/cawa87/garantex-test/internal/service/exchange"
+)
+
+// checkInterval is how often the scheduler looks for completed windows
+const checkInterval = time.Minute
+
+// Store loads window data and persists fixings
+type Store interface {
+	// GetTicks returns the tick in force at from (if any) followed by the ticks in [from, to), ascending
+	GetTicks(ctx context.Context, market string, from, to time.Time) ([]Tick, error)
+	// GetOrderBooks returns the snapshots taken in [from, to), ascending
+	GetOrderBooks(ctx context.Context, market string, from, to time.Time) ([]*exchange.OrderBookSnapshot, error)
+	// GetFixing returns sql.ErrNoRows when the fixing has not been computed
+	GetFixing(ctx context.Context, name string, date time.Time) (*Fixing, error)
+	// SaveFixing inserts or replaces the fixing of its name and date
+	SaveFixing(ctx context.Context, fixing *Fixing) error
+}
+
+// maxRetryDelay caps the backoff between attempts at a window that had no samples
+const maxRetryDelay = time.Hour
+
+// Service computes fixings for configured windows once each window has closed and settled
+type Service struct {
+	store   Store
+	windows []Window
+	settle  time.Duration
+	retries map[string]*retry
+	logger  *sl.Logger
+}
+
+// retry is the backoff of a window whose latest date had no samples
+type retry struct {
+	date  time.Time
+	delay time.Duration
+	next  time.Time
+}
+
+// Option configures a Service
+type Option func(*Service)
+
+// WithSettleDelay waits d after a window closes before computing it, so rates fetched just before the
+// close have been flushed to the store
+func WithSettleDelay(d time.Duration) Option {
+	return func(s *Service) {
+		s.settle = d
+	}
+}
+
+// NewService creates a fixing service for the windows
+func NewService(store Store, windows []Window, logger *sl.Logger, opts ...Option) *Service {
+	s := &Service{
+		store:   store,
+		windows: windows,
+		retries: map[string]*retry{},
+		logger:  logger,
+	}
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s
+}
+
+// Run computes missing fixings for the latest settled windows immediately and then every minute
+// until ctx is cancelled
+func (s *Service) Run(ctx context.Context) {
+	ticker := time.NewTicker(checkInterval)
+	defer ticker.Stop()
+
+	for {
+		s.computeDue(ctx, time.Now())
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// computeDue computes the latest settled window of every fixing that has not been stored yet. A window
+// without samples is retried with a backoff doubling up to maxRetryDelay, in case late data arrives,
+// until the next window settles
+func (s *Service) computeDue(ctx context.Context, now time.Time) {
+	for _, w := range s.windows {
+		date := w.LatestCompleted(now.Add(-s.settle))
+
+		r := s.retries[w.Name]
+		if r != nil && !r.date.Equal(date) {
+			delete(s.retries, w.Name)
+			r = nil
+		}
+		if r != nil && now.Before(r.next) {
+			continue
+		}
+
+		_, err := s.store.GetFixing(ctx, w.Name, date)
+		if err == nil {
+			continue
+		}
+		if !errors.Is(err, sql.ErrNoRows) {
+			s.logger.Error("Failed to check fixing", "fixing", w.Name, "date", date.Format(time.DateOnly), "error", err)
+			continue
+		}
+
+		_, err = s.Compute(ctx, w, date)
+		switch {
+		case err == nil:
+			delete(s.retries, w.Name)
+		case errors.Is(err, ErrNoSamples):
+			if r == nil {
+				r = &retry{date: date, delay: checkInterval}
+				s.retries[w.Name] = r
+			} else {
+				r.delay = min(2*r.delay, maxRetryDelay)
+			}
+			r.next = now.Add(r.delay)
+			s.logger.Warn("No samples for fixing, retrying later",
+				"fixing", w.Name, "date", date.Format(time.DateOnly), "retry_in", r.delay, "error", err)
+		case ctx.Err() == nil:
+			s.logger.Error("Failed to compute fixing", "fixing", w.Name, "date", date.Format(time.DateOnly), "error", err)
+		}
+	}
+}
+
+// Compute computes and stores the fixing of the window on the calendar date
+func (s *Service) Compute(ctx context.Context, w Window, date time.Time) (*Fixing, error) {
+	date = Date(date)
+	from, to := w.Bounds(date)
+
+	fixing, err := s.compute(ctx, w, date, from, to)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.store.SaveFixing(ctx, fixing); err != nil {
+		return nil, fmt.Errorf("failed to save fixing: %w", err)
+	}
+
+	s.logger.Info("Fixing computed",
+		"fixing", fixing.Name,
+		"market", fixing.Market,
+		"date", date.Format(time.DateOnly),
+		"method", fixing.Method,
+		"ask", fixing.Ask,
+		"bid", fixing.Bid,
+		"samples", fixing.SampleCount)
+
+	return fixing, nil
+}
+
+func (s *Service) compute(ctx context.Context, w Window, date, from, to time.Time) (*Fixing, error) {
+	switch w.Method {
+	case MethodVWAP:
+		books, err := s.store.GetOrderBooks(ctx, w.Market, from, to)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load order books: %w", err)
+		}
+		ask, bid, samples, err := vwap(books, w.Depth)
+		if err != nil {
+			return nil, err
+		}
+		return newFixing(w, date, from, to, ask, bid, samples), nil
+	default:
+		ticks, err := s.store.GetTicks(ctx, w.Market, from, to)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load ticks: %w", err)
+		}
+		ask, bid, samples, err := twap(ticks, from, to)
+		if err != nil {
+			return nil, err
+		}
+		return newFixing(w, date, from, to, ask, bid, samples), nil
+	}
+}
@@ -0,0 +1,132 @@
+package fixing
+
+import (
+	"fmt"
+	"strings"
+	"time"
+	_ "time/tzdata" // fixing windows name IANA time zones; embed the database for minimal images
+)
+
+// Method is the fixing methodology
+type Method string
+
+const (
+	// MethodTWAP time-weights stored top-of-book ticks
+	MethodTWAP Method = "twap"
+	// MethodVWAP volume-weights the levels of stored order book snapshots
+	MethodVWAP Method = "vwap"
+)
+
+// ParseMethod parses a fixing method name
+func ParseMethod(s string) (Method, error) {
+	switch Method(strings.ToLower(s)) {
+	case MethodTWAP:
+		return MethodTWAP, nil
+	case MethodVWAP:
+		return MethodVWAP, nil
+	default:
+		return "", fmt.Errorf("unknown fixing method %q, expected twap or vwap", s)
+	}
+}
+
+// Window is a daily fixing window in a time zone, e.g. 11:45-12:00 Europe/Moscow.
+// A window whose end is not after its start ends on the following day
+type Window struct {
+	Name     string
+	Market   string
+	Method   Method
+	Depth    int
+	Location *time.Location
+	Start    time.Duration
+	End      time.Duration
+}
+
+// NewWindow parses a window from its configuration; start and end are "HH:MM" in timezone.
+// Depth limits the order book levels per side used by VWAP (0 uses all stored levels)
+func NewWindow(name, market, timezone, start, end, method string, depth int) (Window, error) {
+	loc, err := time.LoadLocation(timezone)
+	if err != nil {
+		return Window{}, fmt.Errorf("fixing %q: invalid timezone: %w", name, err)
+	}
+	startOffset, err := parseClock(start)
+	if err != nil {
+		return Window{}, fmt.Errorf("fixing %q: invalid start: %w", name, err)
+	}
+	endOffset, err := parseClock(end)
+	if err != nil {
+		return Window{}, fmt.Errorf("fixing %q: invalid end: %w", name, err)
+	}
+	m, err := ParseMethod(method)
+	if err != nil {
+		return Window{}, fmt.Errorf("fixing %q: %w", name, err)
+	}
+
+	return Window{
+		Name:     name,
+		Market:   market,
+		Method:   m,
+		Depth:    depth,
+		Location: loc,
+		Start:    startOffset,
+		End:      endOffset,
+	}, nil
+}
+
+// Bounds returns the window of the calendar date as [from, to)
+func (w Window) Bounds(date time.Time) (from, to time.Time) {
+	midnight := time.Date(date.Year(), date.Month(), date.Day(), 0, 0, 0, 0, w.Location)
+	from = atClock(midnight, w.Start)
+	to = atClock(midnight, w.End)
+	if !to.After(from) {
+		to = atClock(midnight.AddDate(0, 0, 1), w.End)
+	}
+	return from, to
+}
+
+// LatestCompleted returns the calendar date of the most recent window that ended at or before now
+func (w Window) LatestCompleted(now time.Time) time.Time {
+	local := now.In(w.Location)
+	date := Date(local)
+	for {
+		if _, to := w.Bounds(date); !to.After(now) {
+			return date
+		}
+		date = date.AddDate(0, 0, -1)
+	}
+}
+
+// Methodology describes how the window is computed; it is stored with every fixing
+func (w Window) Methodology() string {
+	span := fmt.Sprintf("[%s, %s) %s", formatClock(w.Start), formatClock(w.End), w.Location)
+	if w.Method == MethodVWAP {
+		levels := "all levels"
+		if w.Depth > 0 {
+			levels = fmt.Sprintf("top %d levels", w.Depth)
+		}
+		return fmt.Sprintf("VWAP of the %s per side of every order book snapshot in %s", levels, span)
+	}
+	return fmt.Sprintf("TWAP of top-of-book ticks, each weighted by the time it was in force within %s", span)
+}
+
+// Date returns the calendar date of t as midnight UTC
+func Date(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
+
+// atClock returns the wall clock time offset from midnight, honouring DST transitions
+func atClock(midnight time.Time, offset time.Duration) time.Time {
+	return time.Date(midnight.Year(), midnight.Month(), midnight.Day(),
+		int(offset/time.Hour), int(offset%time.Hour/time.Minute), 0, 0, midnight.Location())
+}
+
+func parseClock(s string) (time.Duration, error) {
+	t, err := time.Parse("15:04", s)
+	if err != nil {
+		return 0, fmt.Errorf("expected HH:MM, got %q", s)
+	}
+	return time.Duration(t.Hour())*time.Hour + time.Duration(t.Minute())*time.Minute, nil
+}
+
+func formatClock(d time.Duration) string {
+	return fmt.Sprintf("%02d:%02d", int(d/time.Hour), int(d%time.Hour/time.Minute))
+}
@@ -0,0 +1,312 @@
+package guard
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/cawa87/garantex-test/internal/lib/logger/sl"
+	"github.com/cawa87/garantex-test/internal/service/exchange"
+	"github.com/shopspring/decimal"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+)
+
+// Reason is the check a tick failed
+type Reason string
+
+const (
+	// ReasonDeviation is a mid price too far from the rolling median
+	ReasonDeviation Reason = "deviation"
+	// ReasonVolume is a best level thinner than the minimum volume
+	ReasonVolume Reason = "volume"
+	// ReasonSpread is a top-of-book spread wider than the maximum
+	ReasonSpread Reason = "spread"
+)
+
+// ErrRejected is returned for ticks that failed a check
+var ErrRejected = errors.New("tick rejected by price guard")
+
+// Rejection describes why a tick was rejected; it matches ErrRejected
+type Rejection struct {
+	Reason Reason
+	Detail string
+}
+
+func (r *Rejection) Error() string {
+	return fmt.Sprintf("%s: %s: %s", ErrRejected, r.Reason, r.Detail)
+}
+
+func (r *Rejection) Is(target error) bool {
+	return target == ErrRejected
+}
+
+// QuarantinedRate is a rejected tick kept for review
+type QuarantinedRate struct {
+	ID         int64
+	Market     string
+	Source     string
+	Ask        decimal.Decimal
+	Bid        decimal.Decimal
+	Timestamp  time.Time
+	ReceivedAt time.Time
+	Reason     Reason
+	Detail     string
+	CreatedAt  time.Time
+}
+
+// Store quarantines rejected ticks
+type Store interface {
+	QuarantineRate(ctx context.Context, rate *QuarantinedRate) error
+}
+
+// Limits configure the checks; a zero limit disables its check. MaxDeviation and MaxSpread are fractions
+// of the price. The deviation check compares the mid price with the median of the last Window ticks,
+// rejected ones included, so that a lasting move is accepted once it makes up most of the window
+type Limits struct {
+	Window       int
+	MaxDeviation decimal.Decimal
+	MinVolume    decimal.Decimal
+	MaxSpread    decimal.Decimal
+}
+
+// Validate checks the limits
+func (l Limits) Validate() error {
+	if l.MaxDeviation.IsPositive() && l.Window < 3 {
+		return fmt.Errorf("median window must hold at least 3 ticks, got %d", l.Window)
+	}
+	if l.MaxDeviation.IsNegative() || l.MinVolume.IsNegative() || l.MaxSpread.IsNegative() {
+		return fmt.Errorf("price guard limits must not be negative")
+	}
+	return nil
+}
+
+// Alert raises an alert once Threshold ticks of a market are rejected within Window
+type Alert struct {
+	Threshold int
+	Window    time.Duration
+}
+
+// Guard checks ticks before they are served and stored, quarantining the ones it rejects
+type Guard struct {
+	store  Store
+	limits Limits
+	alert  Alert
+	logger *sl.Logger
+
+	mu         sync.Mutex
+	prices     map[string][]decimal.Decimal
+	rejections map[string][]time.Time
+	alerting   map[string]bool
+
+	rejected metric.Int64Counter
+}
+
+// New creates a guard
+func New(store Store, limits Limits, alert Alert, logger *sl.Logger) (*Guard, error) {
+	if err := limits.Validate(); err != nil {
+		return nil, err
+	}
+	if alert.Threshold < 1 || alert.Window <= 0 {
+		return nil, fmt.Errorf("alert needs a positive threshold and window, got %d in %s", alert.Threshold, alert.Window)
+	}
+
+	g := &Guard{
+		store:      store,
+		limits:     limits,
+		alert:      alert,
+		logger:     logger,
+		prices:     map[string][]decimal.Decimal{},
+		rejections: map[string][]time.Time{},
+		alerting:   map[string]bool{},
+	}
+	g.initMetrics()
+	return g, nil
+}
+
+// Check returns a *Rejection when the tick fails a check, after quarantining it. An error quarantining
+// the tick is logged; the tick is rejected either way
+func (g *Guard) Check(ctx context.Context, rate *exchange.Rate) error {
+	rejection := g.check(rate, time.Now())
+	if rejection == nil {
+		return nil
+	}
+
+	g.rejected.Add(ctx, 1, metric.WithAttributes(
+		attribute.String("market", rate.Market),
+		attribute.String("reason", string(rejection.Reason))))
+	g.logger.Warn("Tick rejected by price guard",
+		"market", rate.Market,
+		"reason", rejection.Reason,
+		"detail", rejection.Detail,
+		"ask", rate.Ask,
+		"bid", rate.Bid,
+		"timestamp", rate.Timestamp)
+
+	err := g.store.QuarantineRate(ctx, &QuarantinedRate{
+		Market:     rate.Market,
+		Source:     rate.Source,
+		Ask:        rate.Ask,
+		Bid:        rate.Bid,
+		Timestamp:  rate.Timestamp,
+		ReceivedAt: rate.ReceivedAt,
+		Reason:     rejection.Reason,
+		Detail:     rejection.Detail,
+	})
+	if err != nil {
+		g.logger.Error("Failed to quarantine rejected tick", "market", rate.Market, "error", err)
+	}
+
+	return rejection
+}
+
+// Alerting reports whether repeated rejections have raised the alert of a market
+func (g *Guard) Alerting(market string) bool {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	return g.alerting[market]
+}
+
+func (g *Guard) check(rate *exchange.Rate, now time.Time) *Rejection {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	rejection := g.checkBook(rate.Book)
+	if rejection == nil {
+		rejection = g.checkDeviation(rate)
+	}
+	g.observe(rate, now, rejection != nil)
+	return rejection
+}
+
+// checkBook checks the best level of each side; a tick without an order book passes
+func (g *Guard) checkBook(book *exchange.OrderBookSnapshot) *Rejection {
+	if book == nil || len(book.Asks) == 0 || len(book.Bids) == 0 {
+		return nil
+	}
+	bestAsk, bestBid := book.Asks[0], book.Bids[0]
+
+	if g.limits.MinVolume.IsPositive() {
+		if thinnest := decimal.Min(bestAsk.Volume, bestBid.Volume); thinnest.LessThan(g.limits.MinVolume) {
+			return &Rejection{
+				Reason: ReasonVolume,
+				Detail: fmt.Sprintf("best level volume %s is below %s", thinnest, g.limits.MinVolume),
+			}
+		}
+	}
+
+	if g.limits.MaxSpread.IsPositive() {
+		mid := bestAsk.Price.Add(bestBid.Price).Div(decimal.NewFromInt(2))
+		if mid.IsPositive() {
+			if spread := bestAsk.Price.Sub(bestBid.Price).Div(mid); spread.GreaterThan(g.limits.MaxSpread) {
+				return &Rejection{
+					Reason: ReasonSpread,
+					Detail: fmt.Sprintf("spread %s%% exceeds %s%%", percent(spread), percent(g.limits.MaxSpread)),
+				}
+			}
+		}
+	}
+
+	return nil
+}
+
+// checkDeviation compares the mid price with the median of the window; it passes until the window is full
+func (g *Guard) checkDeviation(rate *exchange.Rate) *Rejection {
+	prices := g.prices[rate.Market]
+	if !g.limits.MaxDeviation.IsPositive() || len(prices) < g.limits.Window {
+		return nil
+	}
+
+	median := median(prices)
+	if !median.IsPositive() {
+		return nil
+	}
+	deviation := mid(rate).Sub(median).Div(median)
+	if deviation.Abs().GreaterThan(g.limits.MaxDeviation) {
+		return &Rejection{
+			Reason: ReasonDeviation,
+			Detail: fmt.Sprintf("mid price %s is %s%% from the median %s", mid(rate), percent(deviation), median),
+		}
+	}
+	return nil
+}
+
+// observe adds the tick to the median window and tracks rejections for the alert
+func (g *Guard) observe(rate *exchange.Rate, now time.Time, rejected bool) {
+	if g.limits.MaxDeviation.IsPositive() {
+		prices := append(g.prices[rate.Market], mid(rate))
+		if len(prices) > g.limits.Window {
+			prices = prices[len(prices)-g.limits.Window:]
+		}
+		g.prices[rate.Market] = prices
+	}
+
+	recent := g.rejections[rate.Market]
+	cutoff := now.Add(-g.alert.Window)
+	for len(recent) > 0 && !recent[0].After(cutoff) {
+		recent = recent[1:]
+	}
+	if rejected {
+		recent = append(recent, now)
+	}
+	g.rejections[rate.Market] = recent
+
+	alerting := len(recent) >= g.alert.Threshold
+	if alerting != g.alerting[rate.Market] {
+		if alerting {
+			g.logger.Error("Price guard rejecting repeatedly",
+				"market", rate.Market,
+				"rejections", len(recent),
+				"window", g.alert.Window)
+		} else {
+			g.logger.Info("Price guard alert cleared", "market", rate.Market)
+		}
+	}
+	g.alerting[rate.Market] = alerting
+}
+
+func mid(rate *exchange.Rate) decimal.Decimal {
+	return rate.Ask.Add(rate.Bid).Div(decimal.NewFromInt(2))
+}
+
+func median(prices []decimal.Decimal) decimal.Decimal {
+	sorted := make([]decimal.Decimal, len(prices))
+	copy(sorted, prices)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].LessThan(sorted[j]) })
+
+	n := len(sorted)
+	if n%2 == 1 {
+		return sorted[n/2]
+	}
+	return sorted[n/2-1].Add(sorted[n/2]).Div(decimal.NewFromInt(2))
+}
+
+func percent(fraction decimal.Decimal) string {
+	return fraction.Mul(decimal.NewFromInt(100)).StringFixed(2)
+}
+
+// initMetrics registers price guard instruments on the global meter provider
+func (g *Guard) initMetrics() {
+	meter := otel.Meter("guard")
+
+	g.rejected, _ = meter.Int64Counter("price_guard_rejections",
+		metric.WithDescription("Ticks rejected by the price guard"))
+	_, _ = meter.Int64ObservableGauge("price_guard_alerting",
+		metric.WithDescription("1 while repeated rejections of a market have raised the alert"),
+		metric.WithInt64Callback(func(_ context.Context, o metric.Int64Observer) error {
+			g.mu.Lock()
+			defer g.mu.Unlock()
+			for market, alerting := range g.alerting {
+				var value int64
+				if alerting {
+					value = 1
+				}
+				o.Observe(value, metric.WithAttributes(attribute.String("market", market)))
+			}
+			return nil
+		}))
+}
@@ -0,0 +1,150 @@
+package guard
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/cawa87/garantex-test/internal/lib/logger/sl"
+	"github.com/cawa87/garantex-test/internal/service/exchange"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func dec(s string) decimal.Decimal {
+	return decimal.RequireFromString(s)
+}
+
+type fakeStore struct {
+	quarantined []*QuarantinedRate
+}
+
+func (s *fakeStore) QuarantineRate(_ context.Context, rate *QuarantinedRate) error {
+	s.quarantined = append(s.quarantined, rate)
+	return nil
+}
+
+func rate(price string) *exchange.Rate {
+	return &exchange.Rate{
+		Market:    "btcusdt",
+		Source:    "garantex",
+		Ask:       dec(price),
+		Bid:       dec(price),
+		Timestamp: time.Now(),
+	}
+}
+
+func book(ask, askVolume, bid, bidVolume string) *exchange.OrderBookSnapshot {
+	return &exchange.OrderBookSnapshot{
+		Market: "btcusdt",
+		Asks:   []exchange.PriceLevel{{Price: dec(ask), Volume: dec(askVolume)}},
+		Bids:   []exchange.PriceLevel{{Price: dec(bid), Volume: dec(bidVolume)}},
+	}
+}
+
+func newGuard(t *testing.T, limits Limits, alert Alert) (*Guard, *fakeStore) {
+	logger, err := sl.New("info")
+	require.NoError(t, err)
+
+	store := &fakeStore{}
+	g, err := New(store, limits, alert, logger)
+	require.NoError(t, err)
+	return g, store
+}
+
+func TestGuard_Deviation(t *testing.T) {
+	g, store := newGuard(t, Limits{Window: 5, MaxDeviation: dec("0.1")}, Alert{Threshold: 10, Window: time.Minute})
+	ctx := context.Background()
+
+	// Nothing is rejected until the window is full
+	for _, price := range []string{"100", "101", "99", "100", "200"} {
+		assert.NoError(t, g.Check(ctx, rate(price)))
+	}
+
+	err := g.Check(ctx, rate("130"))
+	require.ErrorIs(t, err, ErrRejected)
+	var rejection *Rejection
+	require.ErrorAs(t, err, &rejection)
+	assert.Equal(t, ReasonDeviation, rejection.Reason)
+	assert.Contains(t, rejection.Detail, "30.00%")
+
+	require.Len(t, store.quarantined, 1)
+	assert.Equal(t, ReasonDeviation, store.quarantined[0].Reason)
+	assert.True(t, dec("130").Equal(store.quarantined[0].Bid))
+
+	assert.NoError(t, g.Check(ctx, rate("105")))
+}
+
+func TestGuard_LastingMove(t *testing.T) {
+	g, _ := newGuard(t, Limits{Window: 5, MaxDeviation: dec("0.1")}, Alert{Threshold: 10, Window: time.Minute})
+	ctx := context.Background()
+
+	for i := 0; i < 5; i++ {
+		require.NoError(t, g.Check(ctx, rate("100")))
+	}
+
+	// A real move is rejected until it makes up most of the window
+	assert.Error(t, g.Check(ctx, rate("150")))
+	assert.Error(t, g.Check(ctx, rate("150")))
+	assert.Error(t, g.Check(ctx, rate("150")))
+	assert.NoError(t, g.Check(ctx, rate("150")))
+}
+
+func TestGuard_Book(t *testing.T) {
+	g, store := newGuard(t, Limits{MinVolume: dec("0.01"), MaxSpread: dec("0.02")}, Alert{Threshold: 10, Window: time.Minute})
+	ctx := context.Background()
+
+	tick := rate("100")
+	tick.Book = book("101", "1", "100", "0.5")
+	assert.NoError(t, g.Check(ctx, tick))
+
+	tick.Book = book("101", "1", "100", "0.001")
+	var rejection *Rejection
+	require.ErrorAs(t, g.Check(ctx, tick), &rejection)
+	assert.Equal(t, ReasonVolume, rejection.Reason)
+
+	tick.Book = book("110", "1", "100", "1")
+	require.ErrorAs(t, g.Check(ctx, tick), &rejection)
+	assert.Equal(t, ReasonSpread, rejection.Reason)
+
+	// Ticks without an order book skip the book checks
+	tick.Book = nil
+	assert.NoError(t, g.Check(ctx, tick))
+
+	assert.Len(t, store.quarantined, 2)
+}
+
+func TestGuard_Alert(t *testing.T) {
+	g, _ := newGuard(t, Limits{MinVolume: dec("1")}, Alert{Threshold: 3, Window: time.Minute})
+	ctx := context.Background()
+
+	thin := rate("100")
+	thin.Book = book("101", "0.1", "100", "0.1")
+
+	now := time.Now()
+	for i := 0; i < 2; i++ {
+		assert.NotNil(t, g.check(thin, now))
+	}
+	assert.False(t, g.Alerting("btcusdt"))
+
+	assert.NotNil(t, g.check(thin, now))
+	assert.True(t, g.Alerting("btcusdt"))
+
+	// The alert clears once the rejections leave the window
+	assert.Nil(t, g.check(rate("100"), now.Add(2*time.Minute)))
+	assert.False(t, g.Alerting("btcusdt"))
+
+	assert.Error(t, g.Check(ctx, thin))
+}
+
+func TestLimits_Validate(t *testing.T) {
+	assert.NoError(t, Limits{}.Validate())
+	assert.Error(t, Limits{Window: 2, MaxDeviation: dec("0.1")}.Validate())
+	assert.Error(t, Limits{MaxSpread: dec("-0.1")}.Validate())
+
+	logger, err := sl.New("info")
+	require.NoError(t, err)
+	_, err = New(&fakeStore{}, Limits{}, Alert{}, logger)
+	assert.Error(t, err)
+}
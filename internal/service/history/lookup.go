@@ -0,0 +1,150 @@
+package history
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+// priceScale is the number of decimal places interpolated prices are rounded to, matching the rates table
+const priceScale = 8
+
+// ErrNoSample is returned when no sample can answer the lookup
+var ErrNoSample = errors.New("no rate sample")
+
+// ErrOutOfTolerance is returned when the matched sample is further from the requested time than the tolerance
+var ErrOutOfTolerance = errors.New("rate sample outside tolerance")
+
+// Mode selects how a rate is resolved at an instant
+type Mode int
+
+const (
+	// ModePrevious uses the latest sample at or before the requested time
+	ModePrevious Mode = iota
+	// ModeNearest uses the sample closest to the requested time, preferring the earlier on ties
+	ModeNearest
+	// ModeInterpolated linearly interpolates between the samples around the requested time
+	ModeInterpolated
+)
+
+func (m Mode) String() string {
+	switch m {
+	case ModePrevious:
+		return "previous"
+	case ModeNearest:
+		return "nearest"
+	case ModeInterpolated:
+		return "interpolated"
+	default:
+		return fmt.Sprintf("Mode(%d)", int(m))
+	}
+}
+
+// Sample is a stored rate observation
+type Sample struct {
+	ID        int64
+	Ask       decimal.Decimal
+	Bid       decimal.Decimal
+	Timestamp time.Time
+}
+
+// Result is a rate resolved at an instant.
+// Samples holds the sample used, or both bracketing samples when interpolated.
+// Distance is how far the furthest sample used is from the requested time
+type Result struct {
+	Ask      decimal.Decimal
+	Bid      decimal.Decimal
+	Samples  []Sample
+	Distance time.Duration
+}
+
+// Resolve resolves the rate at the requested time from the samples at or before it and after it,
+// either of which may be nil. A zero tolerance accepts samples at any distance
+func Resolve(at time.Time, before, after *Sample, mode Mode, tolerance time.Duration) (*Result, error) {
+	var (
+		result *Result
+		err    error
+	)
+	switch mode {
+	case ModePrevious:
+		result, err = single(at, before)
+	case ModeNearest:
+		result, err = single(at, nearest(at, before, after))
+	case ModeInterpolated:
+		result, err = interpolate(at, before, after)
+	default:
+		return nil, fmt.Errorf("unknown lookup mode %s", mode)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if tolerance > 0 && result.Distance > tolerance {
+		return nil, fmt.Errorf("%w: %s from %s exceeds %s", ErrOutOfTolerance, result.Distance, at.Format(time.RFC3339Nano), tolerance)
+	}
+	return result, nil
+}
+
+func single(at time.Time, sample *Sample) (*Result, error) {
+	if sample == nil {
+		return nil, fmt.Errorf("%w at or around %s", ErrNoSample, at.Format(time.RFC3339Nano))
+	}
+	return &Result{
+		Ask:      sample.Ask,
+		Bid:      sample.Bid,
+		Samples:  []Sample{*sample},
+		Distance: distance(at, sample.Timestamp),
+	}, nil
+}
+
+func nearest(at time.Time, before, after *Sample) *Sample {
+	switch {
+	case before == nil:
+		return after
+	case after == nil:
+		return before
+	case distance(at, after.Timestamp) < distance(at, before.Timestamp):
+		return after
+	default:
+		return before
+	}
+}
+
+// interpolate weighs the bracketing samples by their distance from at.
+// A sample exactly at the requested time is returned as is
+func interpolate(at time.Time, before, after *Sample) (*Result, error) {
+	if before != nil && before.Timestamp.Equal(at) {
+		return single(at, before)
+	}
+	if before == nil || after == nil {
+		return nil, fmt.Errorf("%w on both sides of %s to interpolate", ErrNoSample, at.Format(time.RFC3339Nano))
+	}
+
+	span := decimal.NewFromInt(after.Timestamp.Sub(before.Timestamp).Nanoseconds())
+	weight := decimal.NewFromInt(at.Sub(before.Timestamp).Nanoseconds()).Div(span)
+
+	lerp := func(from, to decimal.Decimal) decimal.Decimal {
+		return from.Add(to.Sub(from).Mul(weight)).Round(priceScale)
+	}
+
+	dist := distance(at, before.Timestamp)
+	if d := distance(at, after.Timestamp); d > dist {
+		dist = d
+	}
+
+	return &Result{
+		Ask:      lerp(before.Ask, after.Ask),
+		Bid:      lerp(before.Bid, after.Bid),
+		Samples:  []Sample{*before, *after},
+		Distance: dist,
+	}, nil
+}
+
+func distance(a, b time.Time) time.Duration {
+	if d := a.Sub(b); d >= 0 {
+		return d
+	}
+	return b.Sub(a)
+}
@@ -0,0 +1,73 @@
+package history
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func sample(id int64, ask, bid string, ts time.Time) *Sample {
+	return &Sample{
+		ID:        id,
+		Ask:       decimal.RequireFromString(ask),
+		Bid:       decimal.RequireFromString(bid),
+		Timestamp: ts,
+	}
+}
+
+func TestResolve(t *testing.T) {
+	at := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+	before := sample(1, "100", "99", at.Add(-30*time.Second))
+	after := sample(2, "103", "102", at.Add(10*time.Second))
+
+	t.Run("previous", func(t *testing.T) {
+		result, err := Resolve(at, before, after, ModePrevious, time.Minute)
+		require.NoError(t, err)
+		assert.Equal(t, int64(1), result.Samples[0].ID)
+		assert.Equal(t, 30*time.Second, result.Distance)
+	})
+
+	t.Run("nearest", func(t *testing.T) {
+		result, err := Resolve(at, before, after, ModeNearest, time.Minute)
+		require.NoError(t, err)
+		assert.Equal(t, int64(2), result.Samples[0].ID)
+		assert.Equal(t, 10*time.Second, result.Distance)
+	})
+
+	t.Run("interpolated", func(t *testing.T) {
+		result, err := Resolve(at, before, after, ModeInterpolated, time.Minute)
+		require.NoError(t, err)
+		assert.Len(t, result.Samples, 2)
+		assert.True(t, decimal.RequireFromString("102.25").Equal(result.Ask), result.Ask.String())
+		assert.True(t, decimal.RequireFromString("101.25").Equal(result.Bid), result.Bid.String())
+		assert.Equal(t, 30*time.Second, result.Distance)
+	})
+
+	t.Run("interpolated exact match", func(t *testing.T) {
+		exact := sample(3, "101", "100", at)
+		result, err := Resolve(at, exact, nil, ModeInterpolated, 0)
+		require.NoError(t, err)
+		assert.Equal(t, []Sample{*exact}, result.Samples)
+		assert.Zero(t, result.Distance)
+	})
+
+	t.Run("out of tolerance", func(t *testing.T) {
+		_, err := Resolve(at, before, after, ModePrevious, 20*time.Second)
+		assert.True(t, errors.Is(err, ErrOutOfTolerance))
+
+		_, err = Resolve(at, before, after, ModePrevious, 0)
+		assert.NoError(t, err)
+	})
+
+	t.Run("no sample", func(t *testing.T) {
+		_, err := Resolve(at, nil, after, ModePrevious, 0)
+		assert.True(t, errors.Is(err, ErrNoSample))
+
+		_, err = Resolve(at, before, nil, ModeInterpolated, 0)
+		assert.True(t, errors.Is(err, ErrNoSample))
+	})
+}
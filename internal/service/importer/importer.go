@@ -0,0 +1,339 @@
+package importer
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/cawa87/garantex-test/internal/lib/logger/sl"
+	"github.com/cawa87/garantex-test/internal/service/exchange"
+)
+
+// Source tags imported rates unless the import sets another one
+const Source = "import"
+
+// DefaultMaxErrors is the number of invalid lines kept in a report when the import sets none
+const DefaultMaxErrors = 100
+
+// ErrInvalidRows is returned for files with invalid rows unless the import skips them
+var ErrInvalidRows = errors.New("file has invalid rows")
+
+// Format is the file format of an import
+type Format string
+
+const (
+	// FormatCSV reads comma-separated values with a header row naming the columns
+	FormatCSV Format = "csv"
+	// FormatNDJSON reads one JSON object per line
+	FormatNDJSON Format = "ndjson"
+)
+
+// ParseFormat parses an import format
+func ParseFormat(s string) (Format, error) {
+	switch f := Format(s); f {
+	case FormatCSV, FormatNDJSON:
+		return f, nil
+	default:
+		return "", fmt.Errorf("unknown import format %q", s)
+	}
+}
+
+// DetectFormat returns the format of a file from its extension
+func DetectFormat(path string) (Format, error) {
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".csv":
+		return FormatCSV, nil
+	case ".ndjson", ".jsonl", ".json":
+		return FormatNDJSON, nil
+	default:
+		return "", fmt.Errorf("cannot detect the format of %s from its extension", path)
+	}
+}
+
+// Mapping names the file columns, or JSON keys, holding each rate field
+type Mapping struct {
+	Market    string
+	Ask       string
+	Bid       string
+	Timestamp string
+}
+
+// DefaultMapping reads fields from columns of the same name
+var DefaultMapping = Mapping{
+	Market:    "market",
+	Ask:       "ask",
+	Bid:       "bid",
+	Timestamp: "timestamp",
+}
+
+// ParseMapping overrides DefaultMapping with comma-separated field=column pairs,
+// e.g. "timestamp=Date,ask=Ask Price"
+func ParseMapping(s string) (Mapping, error) {
+	m := DefaultMapping
+	if strings.TrimSpace(s) == "" {
+		return m, nil
+	}
+
+	for _, pair := range strings.Split(s, ",") {
+		field, column, ok := strings.Cut(pair, "=")
+		field, column = strings.TrimSpace(field), strings.TrimSpace(column)
+		if !ok || column == "" {
+			return Mapping{}, fmt.Errorf("column mapping %q is not field=column", pair)
+		}
+		switch field {
+		case "market":
+			m.Market = column
+		case "ask":
+			m.Ask = column
+		case "bid":
+			m.Bid = column
+		case "timestamp":
+			m.Timestamp = column
+		default:
+			return Mapping{}, fmt.Errorf("unknown rate field %q in column mapping", field)
+		}
+	}
+	return m, nil
+}
+
+// Options configure an import. Timestamps are parsed with Layout in Location unless they carry an
+// offset; an empty Layout accepts RFC 3339 and common spreadsheet layouts, and the layouts "unix" and
+// "unixms" read epoch seconds and milliseconds. Market is used for files without a market column
+type Options struct {
+	Format      Format
+	Mapping     Mapping
+	Location    *time.Location
+	Layout      string
+	Market      string
+	Source      string
+	SkipInvalid bool
+	MaxErrors   int
+	DryRun      bool
+}
+
+// LineError is an invalid line of a file
+type LineError struct {
+	Line    int
+	Message string
+}
+
+func (e *LineError) Error() string {
+	return fmt.Sprintf("line %d: %s", e.Line, e.Message)
+}
+
+// Report summarises the import of a file. Duplicates are valid rows already stored, or repeated in the
+// file, under the same market, source and timestamp
+type Report struct {
+	File       string
+	DryRun     bool
+	Rows       int64
+	Invalid    int64
+	Inserted   int64
+	Duplicates int64
+	From       time.Time
+	To         time.Time
+	Errors     []LineError
+}
+
+// Load is a validated file to load into rates. Next returns its valid rates in file order and io.EOF
+// after the last one; From and To bound their timestamps
+type Load struct {
+	File   string
+	From   time.Time
+	To     time.Time
+	DryRun bool
+	Next   func() (*exchange.Rate, error)
+}
+
+// Store loads rates in one transaction per file, skipping samples that are already stored. It returns
+// the number of rates inserted, or that would be inserted on a dry run
+type Store interface {
+	ImportRates(ctx context.Context, load *Load) (int64, error)
+}
+
+// Importer validates rate history files and loads them into the store
+type Importer struct {
+	store  Store
+	opts   Options
+	logger *sl.Logger
+}
+
+// New creates an importer
+func New(store Store, opts Options, logger *sl.Logger) (*Importer, error) {
+	if opts.Format != "" {
+		if _, err := ParseFormat(string(opts.Format)); err != nil {
+			return nil, err
+		}
+	}
+	if opts.Mapping == (Mapping{}) {
+		opts.Mapping = DefaultMapping
+	}
+	if opts.Location == nil {
+		opts.Location = time.UTC
+	}
+	if opts.Market != "" {
+		market, err := parseMarket(opts.Market)
+		if err != nil {
+			return nil, err
+		}
+		opts.Market = market
+	}
+	if opts.Source == "" {
+		opts.Source = Source
+	}
+	if opts.MaxErrors <= 0 {
+		opts.MaxErrors = DefaultMaxErrors
+	}
+
+	return &Importer{
+		store:  store,
+		opts:   opts,
+		logger: logger,
+	}, nil
+}
+
+// Import validates a file and, unless it has invalid rows that are not skipped, loads its valid rows.
+// The file is read twice, once to validate it and once to load it, so it is never held in memory.
+// Imported rates are tagged with the base name of the file
+func (i *Importer) Import(ctx context.Context, path string) (*Report, error) {
+	format := i.opts.Format
+	if format == "" {
+		var err error
+		if format, err = DetectFormat(path); err != nil {
+			return nil, err
+		}
+	}
+
+	report := &Report{File: filepath.Base(path), DryRun: i.opts.DryRun}
+	if err := i.validate(path, format, report); err != nil {
+		return report, err
+	}
+
+	if report.Invalid > 0 && !i.opts.SkipInvalid {
+		return report, fmt.Errorf("%s: %w: %d of %d", report.File, ErrInvalidRows, report.Invalid, report.Rows)
+	}
+	valid := report.Rows - report.Invalid
+	if valid == 0 {
+		return report, nil
+	}
+
+	r, err := i.open(path, format)
+	if err != nil {
+		return report, err
+	}
+	defer r.close()
+
+	report.Inserted, err = i.store.ImportRates(ctx, &Load{
+		File:   report.File,
+		From:   report.From,
+		To:     report.To,
+		DryRun: i.opts.DryRun,
+		Next:   r.nextValid,
+	})
+	if err != nil {
+		return report, fmt.Errorf("failed to load %s: %w", report.File, err)
+	}
+	report.Duplicates = valid - report.Inserted
+
+	i.logger.Info("Rates imported",
+		"file", report.File,
+		"rows", report.Rows,
+		"invalid", report.Invalid,
+		"inserted", report.Inserted,
+		"duplicates", report.Duplicates,
+		"dry_run", report.DryRun)
+	return report, nil
+}
+
+// validate reads every row of a file into the report
+func (i *Importer) validate(path string, format Format, report *Report) error {
+	r, err := i.open(path, format)
+	if err != nil {
+		return err
+	}
+	defer r.close()
+
+	for {
+		rate, lineErr, err := r.next()
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		report.Rows++
+		if lineErr != nil {
+			report.Invalid++
+			if len(report.Errors) < i.opts.MaxErrors {
+				report.Errors = append(report.Errors, *lineErr)
+			}
+			continue
+		}
+		if report.From.IsZero() || rate.Timestamp.Before(report.From) {
+			report.From = rate.Timestamp
+		}
+		if rate.Timestamp.After(report.To) {
+			report.To = rate.Timestamp
+		}
+	}
+}
+
+// reader reads the rows of a file as rates
+type reader struct {
+	importer *Importer
+	file     *os.File
+	name     string
+	src      source
+}
+
+func (i *Importer) open(path string, format Format) (*reader, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+
+	src, err := newSource(f, format)
+	if err != nil {
+		f.Close()
+		return nil, fmt.Errorf("%s: %w", filepath.Base(path), err)
+	}
+	return &reader{importer: i, file: f, name: filepath.Base(path), src: src}, nil
+}
+
+// next returns the next row as a rate or, if it is invalid, a line error; io.EOF after the last row
+func (r *reader) next() (*exchange.Rate, *LineError, error) {
+	line, fields, err := r.src.next()
+	if errors.Is(err, io.EOF) {
+		return nil, nil, io.EOF
+	}
+
+	var lineErr *LineError
+	if errors.As(err, &lineErr) {
+		return nil, lineErr, nil
+	}
+	if err != nil {
+		return nil, nil, fmt.Errorf("%s: line %d: %w", r.name, line, err)
+	}
+	rate, lineErr := r.importer.rate(line, fields)
+	return rate, lineErr, nil
+}
+
+// nextValid returns the next valid row, skipping invalid ones
+func (r *reader) nextValid() (*exchange.Rate, error) {
+	for {
+		rate, lineErr, err := r.next()
+		if err != nil || lineErr == nil {
+			return rate, err
+		}
+	}
+}
+
+func (r *reader) close() {
+	_ = r.file.Close()
+}
@@ -0,0 +1,199 @@
+package importer
+
+import (
+	"context"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/cawa87/garantex-test/internal/lib/logger/sl"
+	"github.com/cawa87/garantex-test/internal/service/exchange"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeStore keeps the loaded rates and treats rates with a stored market and timestamp as duplicates
+type fakeStore struct {
+	loads  []*Load
+	rates  []*exchange.Rate
+	stored map[string]bool
+}
+
+func (s *fakeStore) ImportRates(_ context.Context, load *Load) (int64, error) {
+	if s.stored == nil {
+		s.stored = map[string]bool{}
+	}
+	s.loads = append(s.loads, load)
+
+	var inserted int64
+	for {
+		rate, err := load.Next()
+		if errors.Is(err, io.EOF) {
+			return inserted, nil
+		}
+		if err != nil {
+			return 0, err
+		}
+		key := rate.Market + rate.Timestamp.String()
+		if s.stored[key] {
+			continue
+		}
+		if !load.DryRun {
+			s.stored[key] = true
+			s.rates = append(s.rates, rate)
+		}
+		inserted++
+	}
+}
+
+func writeFile(t *testing.T, name, content string) string {
+	path := filepath.Join(t.TempDir(), name)
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+	return path
+}
+
+func newImporter(t *testing.T, store Store, opts Options) *Importer {
+	logger, err := sl.New("info")
+	require.NoError(t, err)
+
+	i, err := New(store, opts, logger)
+	require.NoError(t, err)
+	return i
+}
+
+func TestImport_CSVMapping(t *testing.T) {
+	moscow, err := time.LoadLocation("Europe/Moscow")
+	require.NoError(t, err)
+	mapping, err := ParseMapping("timestamp=Date, ask=Ask Price, bid=Bid Price")
+	require.NoError(t, err)
+
+	path := writeFile(t, "history-2019.csv", "\ufeffDate,Ask Price,Bid Price,Comment\n"+
+		"2019-03-01 12:00:00,3950.5,3949.1,\n"+
+		"2019-03-01 12:01:00,\"3951\",3950,\"quoted, with comma\"\n"+
+		"2019-03-01T09:02:00Z,3952,3951,offset wins over -tz\n"+
+		"2019-03-01 12:01:00,3951,3950,duplicate\n")
+
+	store := &fakeStore{}
+	report, err := newImporter(t, store, Options{Mapping: mapping, Location: moscow, Market: "BTCUSDT"}).Import(context.Background(), path)
+	require.NoError(t, err)
+
+	assert.Equal(t, "history-2019.csv", report.File)
+	assert.Equal(t, int64(4), report.Rows)
+	assert.Zero(t, report.Invalid)
+	assert.Equal(t, int64(3), report.Inserted)
+	assert.Equal(t, int64(1), report.Duplicates)
+	assert.Equal(t, time.Date(2019, 3, 1, 9, 0, 0, 0, time.UTC), report.From)
+	assert.Equal(t, time.Date(2019, 3, 1, 9, 2, 0, 0, time.UTC), report.To)
+
+	require.Len(t, store.loads, 1)
+	assert.Equal(t, "history-2019.csv", store.loads[0].File)
+	require.Len(t, store.rates, 3)
+	first := store.rates[0]
+	assert.Equal(t, "btcusdt", first.Market)
+	assert.Equal(t, Source, first.Source)
+	assert.True(t, decimal.RequireFromString("3950.5").Equal(first.Ask))
+	assert.Equal(t, time.Date(2019, 3, 1, 9, 0, 0, 0, time.UTC), first.Timestamp)
+}
+
+func TestImport_InvalidRows(t *testing.T) {
+	path := writeFile(t, "rates.csv", "market,ask,bid,timestamp\n"+
+		"btcusdt,100,99,2020-01-01T00:00:00Z\n"+
+		"btc-usdt,100,99,2020-01-01T00:01:00Z\n"+
+		"btcusdt,abc,99,2020-01-01T00:02:00Z\n"+
+		"btcusdt,99,100,2020-01-01T00:03:00Z\n"+
+		"btcusdt,100.123456789,99,2020-01-01T00:04:00Z\n"+
+		"btcusdt,100,99,yesterday\n"+
+		"btcusdt,100,99\n"+
+		"btcusdt,100,99,2999-01-01T00:00:00Z\n"+
+		"btcusdt,101,100,2020-01-01T00:05:00Z\n")
+
+	store := &fakeStore{}
+	report, err := newImporter(t, store, Options{}).Import(context.Background(), path)
+	require.ErrorIs(t, err, ErrInvalidRows)
+	assert.Empty(t, store.loads, "files with invalid rows are not loaded")
+
+	assert.Equal(t, int64(9), report.Rows)
+	assert.Equal(t, int64(7), report.Invalid)
+	lines := make([]int, len(report.Errors))
+	for i, e := range report.Errors {
+		lines[i] = e.Line
+	}
+	assert.Equal(t, []int{3, 4, 5, 6, 7, 8, 9}, lines)
+	assert.Contains(t, report.Errors[0].Message, "invalid market")
+	assert.Contains(t, report.Errors[2].Message, "bid 100 is above ask 99")
+	assert.Contains(t, report.Errors[3].Message, "more than 8 decimal places")
+	assert.Contains(t, report.Errors[5].Message, "has 3 fields")
+	assert.Contains(t, report.Errors[6].Message, "in the future")
+
+	// Skipping invalid rows loads the rest; the report keeps MaxErrors of them
+	report, err = newImporter(t, store, Options{SkipInvalid: true, MaxErrors: 2}).Import(context.Background(), path)
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), report.Inserted)
+	assert.Equal(t, int64(7), report.Invalid)
+	assert.Len(t, report.Errors, 2)
+}
+
+func TestImport_NDJSON(t *testing.T) {
+	path := writeFile(t, "rates.jsonl", `{"pair": "ethusdt", "ask": 2000.5, "bid": "2000", "ts": 1583020800}`+"\n"+
+		"\n"+
+		`{"pair": "ethusdt", "ask": 2001, "bid": 2000, "ts": 1583020860}`+"\n"+
+		`{"pair": "ethusdt", "ask": 2001, "bid": 2000, "ts": [1]}`+"\n"+
+		`not json`+"\n")
+
+	mapping, err := ParseMapping("market=pair,timestamp=ts")
+	require.NoError(t, err)
+
+	store := &fakeStore{}
+	report, err := newImporter(t, store, Options{Mapping: mapping, Layout: "unix", SkipInvalid: true, DryRun: true}).
+		Import(context.Background(), path)
+	require.NoError(t, err)
+
+	assert.True(t, report.DryRun)
+	assert.Equal(t, int64(4), report.Rows)
+	assert.Equal(t, int64(2), report.Inserted)
+	require.Len(t, report.Errors, 2)
+	assert.Equal(t, 4, report.Errors[0].Line)
+	assert.Equal(t, 5, report.Errors[1].Line)
+	assert.Equal(t, time.Date(2020, 3, 1, 0, 0, 0, 0, time.UTC), report.From)
+
+	require.Len(t, store.loads, 1)
+	assert.True(t, store.loads[0].DryRun)
+	assert.Empty(t, store.rates, "a dry run stores nothing")
+}
+
+func TestImport_NoMarket(t *testing.T) {
+	path := writeFile(t, "rates.csv", "ask,bid,timestamp\n100,99,2020-01-01 00:00:00\n")
+
+	report, err := newImporter(t, &fakeStore{}, Options{}).Import(context.Background(), path)
+	require.ErrorIs(t, err, ErrInvalidRows)
+	require.Len(t, report.Errors, 1)
+	assert.Equal(t, "line 2: no market column and no default market", report.Errors[0].Error())
+}
+
+func TestParseMapping(t *testing.T) {
+	m, err := ParseMapping("")
+	require.NoError(t, err)
+	assert.Equal(t, DefaultMapping, m)
+
+	_, err = ParseMapping("price=Close")
+	assert.Error(t, err)
+	_, err = ParseMapping("ask")
+	assert.Error(t, err)
+}
+
+func TestDetectFormat(t *testing.T) {
+	format, err := DetectFormat("rates.CSV")
+	require.NoError(t, err)
+	assert.Equal(t, FormatCSV, format)
+
+	format, err = DetectFormat("rates.ndjson")
+	require.NoError(t, err)
+	assert.Equal(t, FormatNDJSON, format)
+
+	_, err = DetectFormat("rates.xlsx")
+	assert.Error(t, err)
+}
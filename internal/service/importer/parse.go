@@ -0,0 +1,269 @@
+package importer
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/cawa87/garantex-test/internal/service/exchange"
+	"github.com/shopspring/decimal"
+)
+
+// maxLineSize bounds a line of an NDJSON file
+const maxLineSize = 1 << 20
+
+// priceDecimals is the number of decimal places stored for prices
+const priceDecimals = 8
+
+// maxPrice is the first price that does not fit the DECIMAL(20, 8) rate columns
+var maxPrice = decimal.New(1, 20-priceDecimals)
+
+// marketPattern matches the market names accepted by the API
+var marketPattern = regexp.MustCompile(`^[a-z0-9]{1,20}$`)
+
+// defaultLayouts are tried in order for timestamps when the import sets no layout
+var defaultLayouts = []string{
+	time.RFC3339Nano,
+	"2006-01-02T15:04:05",
+	"2006-01-02 15:04:05",
+	"2006-01-02 15:04",
+	"02.01.2006 15:04:05",
+	"02.01.2006 15:04",
+}
+
+// source reads the records of a file as fields by column name
+type source interface {
+	// next returns the line of the next record and its fields; a *LineError for a malformed record and
+	// io.EOF after the last one
+	next() (line int, fields map[string]string, err error)
+}
+
+func newSource(r io.Reader, format Format) (source, error) {
+	switch format {
+	case FormatCSV:
+		return newCSVSource(r)
+	case FormatNDJSON:
+		scanner := bufio.NewScanner(r)
+		scanner.Buffer(make([]byte, 0, 64*1024), maxLineSize)
+		return &jsonSource{scanner: scanner}, nil
+	default:
+		return nil, fmt.Errorf("unknown import format %q", format)
+	}
+}
+
+// csvSource reads CSV records under the column names of the header row
+type csvSource struct {
+	r      *csv.Reader
+	header []string
+}
+
+func newCSVSource(r io.Reader) (*csvSource, error) {
+	cr := csv.NewReader(r)
+	cr.FieldsPerRecord = -1
+	cr.TrimLeadingSpace = true
+
+	header, err := cr.Read()
+	if errors.Is(err, io.EOF) {
+		return nil, fmt.Errorf("file is empty, expected a header row")
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read the header row: %w", err)
+	}
+	for i, column := range header {
+		// Spreadsheet exports often start with a UTF-8 byte order mark
+		if i == 0 {
+			column = strings.TrimPrefix(column, "\ufeff")
+		}
+		header[i] = strings.TrimSpace(column)
+	}
+	return &csvSource{r: cr, header: header}, nil
+}
+
+func (s *csvSource) next() (int, map[string]string, error) {
+	record, err := s.r.Read()
+	if errors.Is(err, io.EOF) {
+		return 0, nil, io.EOF
+	}
+	var parseErr *csv.ParseError
+	if errors.As(err, &parseErr) {
+		return parseErr.StartLine, nil, &LineError{Line: parseErr.StartLine, Message: parseErr.Err.Error()}
+	}
+	if err != nil {
+		return 0, nil, err
+	}
+
+	line, _ := s.r.FieldPos(0)
+	if len(record) != len(s.header) {
+		return line, nil, &LineError{
+			Line:    line,
+			Message: fmt.Sprintf("has %d fields, the header has %d", len(record), len(s.header)),
+		}
+	}
+
+	fields := make(map[string]string, len(record))
+	for i, value := range record {
+		fields[s.header[i]] = strings.TrimSpace(value)
+	}
+	return line, fields, nil
+}
+
+// jsonSource reads one JSON object per line; blank lines are skipped
+type jsonSource struct {
+	scanner *bufio.Scanner
+	line    int
+}
+
+func (s *jsonSource) next() (int, map[string]string, error) {
+	for s.scanner.Scan() {
+		s.line++
+		data := bytes.TrimSpace(s.scanner.Bytes())
+		if len(data) == 0 {
+			continue
+		}
+
+		var object map[string]any
+		dec := json.NewDecoder(bytes.NewReader(data))
+		dec.UseNumber()
+		if err := dec.Decode(&object); err != nil {
+			return s.line, nil, &LineError{Line: s.line, Message: fmt.Sprintf("invalid JSON object: %v", err)}
+		}
+		if dec.More() {
+			return s.line, nil, &LineError{Line: s.line, Message: "unexpected data after the JSON object"}
+		}
+
+		fields := make(map[string]string, len(object))
+		for key, value := range object {
+			switch v := value.(type) {
+			case string:
+				fields[key] = strings.TrimSpace(v)
+			case json.Number:
+				fields[key] = v.String()
+			case nil:
+				fields[key] = ""
+			default:
+				return s.line, nil, &LineError{Line: s.line, Message: fmt.Sprintf("%s is not a string or a number", key)}
+			}
+		}
+		return s.line, fields, nil
+	}
+	if err := s.scanner.Err(); err != nil {
+		return s.line + 1, nil, err
+	}
+	return 0, nil, io.EOF
+}
+
+// rate converts the fields of a record into a rate, or describes why they are invalid
+func (i *Importer) rate(line int, fields map[string]string) (*exchange.Rate, *LineError) {
+	invalid := func(format string, args ...any) (*exchange.Rate, *LineError) {
+		return nil, &LineError{Line: line, Message: fmt.Sprintf(format, args...)}
+	}
+	m := i.opts.Mapping
+
+	market := i.opts.Market
+	if value, ok := fields[m.Market]; ok {
+		var err error
+		if market, err = parseMarket(value); err != nil {
+			return invalid("%s: %v", m.Market, err)
+		}
+	}
+	if market == "" {
+		return invalid("no %s column and no default market", m.Market)
+	}
+
+	ask, err := parsePrice(fields, m.Ask)
+	if err != nil {
+		return invalid("%s: %v", m.Ask, err)
+	}
+	bid, err := parsePrice(fields, m.Bid)
+	if err != nil {
+		return invalid("%s: %v", m.Bid, err)
+	}
+	if bid.GreaterThan(ask) {
+		return invalid("bid %s is above ask %s", bid, ask)
+	}
+
+	value, ok := fields[m.Timestamp]
+	if !ok || value == "" {
+		return invalid("%s is missing", m.Timestamp)
+	}
+	timestamp, err := i.parseTime(value)
+	if err != nil {
+		return invalid("%s: %v", m.Timestamp, err)
+	}
+	if timestamp.After(time.Now()) {
+		return invalid("%s %s is in the future", m.Timestamp, timestamp.Format(time.RFC3339))
+	}
+
+	return &exchange.Rate{
+		Market:     market,
+		Source:     i.opts.Source,
+		Ask:        ask,
+		Bid:        bid,
+		Timestamp:  timestamp,
+		ReceivedAt: timestamp,
+	}, nil
+}
+
+func parseMarket(value string) (string, error) {
+	market := strings.ToLower(strings.TrimSpace(value))
+	if !marketPattern.MatchString(market) {
+		return "", fmt.Errorf("invalid market %q", value)
+	}
+	return market, nil
+}
+
+func parsePrice(fields map[string]string, column string) (decimal.Decimal, error) {
+	value, ok := fields[column]
+	if !ok || value == "" {
+		return decimal.Decimal{}, fmt.Errorf("is missing")
+	}
+	price, err := decimal.NewFromString(value)
+	if err != nil {
+		return decimal.Decimal{}, fmt.Errorf("%q is not a number", value)
+	}
+	switch {
+	case !price.IsPositive():
+		return decimal.Decimal{}, fmt.Errorf("%s is not positive", price)
+	case price.GreaterThanOrEqual(maxPrice):
+		return decimal.Decimal{}, fmt.Errorf("%s is too large", price)
+	case !price.Equal(price.Round(priceDecimals)):
+		return decimal.Decimal{}, fmt.Errorf("%s has more than %d decimal places", price, priceDecimals)
+	}
+	return price, nil
+}
+
+// parseTime parses a timestamp with the import layout, or the default layouts, and returns it in UTC
+func (i *Importer) parseTime(value string) (time.Time, error) {
+	switch i.opts.Layout {
+	case "unix", "unixms":
+		epoch, err := decimal.NewFromString(value)
+		if err != nil {
+			return time.Time{}, fmt.Errorf("%q is not an epoch timestamp", value)
+		}
+		unit := decimal.NewFromInt(int64(time.Second))
+		if i.opts.Layout == "unixms" {
+			unit = decimal.NewFromInt(int64(time.Millisecond))
+		}
+		return time.Unix(0, epoch.Mul(unit).IntPart()).UTC(), nil
+	case "":
+		for _, layout := range defaultLayouts {
+			if t, err := time.ParseInLocation(layout, value, i.opts.Location); err == nil {
+				return t.UTC(), nil
+			}
+		}
+		return time.Time{}, fmt.Errorf("%q does not match a known layout", value)
+	default:
+		t, err := time.ParseInLocation(i.opts.Layout, value, i.opts.Location)
+		if err != nil {
+			return time.Time{}, fmt.Errorf("%q does not match layout %q", value, i.opts.Layout)
+		}
+		return t.UTC(), nil
+	}
+}
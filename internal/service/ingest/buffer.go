@@ -0,0 +1,164 @@
+package ingest
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/cawa87/garantex-test/internal/lib/logger/sl"
+	"github.com/cawa87/garantex-test/internal/service/exchange"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+)
+
+// maxPendingBatches bounds how many batches are kept for retry while the store is failing
+const maxPendingBatches = 10
+
+// flushTimeout bounds the final flush on shutdown
+const flushTimeout = 10 * time.Second
+
+// Store persists rates in bulk
+type Store interface {
+	SaveRates(ctx context.Context, rates []*exchange.Rate) (int64, error)
+}
+
+// Buffer collects rates and writes them in batches once batchSize rates are pending or every interval,
+// whichever comes first. A crash loses at most the rates of one flush window
+type Buffer struct {
+	store     Store
+	batchSize int
+	interval  time.Duration
+	logger    *sl.Logger
+
+	mu      sync.Mutex
+	pending []*exchange.Rate
+	full    chan struct{}
+
+	flushSize     metric.Int64Histogram
+	flushDuration metric.Float64Histogram
+	dropped       metric.Int64Counter
+}
+
+// NewBuffer creates a buffer flushing batchSize rates or every interval
+func NewBuffer(store Store, batchSize int, interval time.Duration, logger *sl.Logger) *Buffer {
+	if batchSize < 1 {
+		batchSize = 1
+	}
+
+	b := &Buffer{
+		store:     store,
+		batchSize: batchSize,
+		interval:  interval,
+		logger:    logger,
+		pending:   make([]*exchange.Rate, 0, batchSize),
+		full:      make(chan struct{}, 1),
+	}
+	b.initMetrics()
+	return b
+}
+
+// Add queues a rate for the next flush
+func (b *Buffer) Add(rate *exchange.Rate) {
+	b.mu.Lock()
+	b.pending = append(b.pending, rate)
+	full := len(b.pending) >= b.batchSize
+	b.mu.Unlock()
+
+	if full {
+		select {
+		case b.full <- struct{}{}:
+		default:
+		}
+	}
+}
+
+// Len returns the number of rates waiting to be flushed
+func (b *Buffer) Len() int {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return len(b.pending)
+}
+
+// Run flushes on every interval or full batch until ctx is cancelled, then flushes what is left
+func (b *Buffer) Run(ctx context.Context) {
+	ticker := time.NewTicker(b.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			flushCtx, cancel := context.WithTimeout(context.Background(), flushTimeout)
+			defer cancel()
+			if err := b.Flush(flushCtx); err != nil {
+				b.logger.Error("Failed to flush rates on shutdown", "error", err, "pending", b.Len())
+			}
+			return
+		case <-ticker.C:
+		case <-b.full:
+		}
+
+		if err := b.Flush(ctx); err != nil && ctx.Err() == nil {
+			b.logger.Error("Failed to flush rates", "error", err, "pending", b.Len())
+		}
+	}
+}
+
+// Flush writes all pending rates in batches of batchSize. Rates of a failed batch are kept for the
+// next flush, up to maxPendingBatches batches; older ones are dropped
+func (b *Buffer) Flush(ctx context.Context) error {
+	for {
+		b.mu.Lock()
+		n := len(b.pending)
+		if n > b.batchSize {
+			n = b.batchSize
+		}
+		batch := b.pending[:n:n]
+		b.pending = b.pending[n:]
+		b.mu.Unlock()
+
+		if len(batch) == 0 {
+			return nil
+		}
+
+		start := time.Now()
+		_, err := b.store.SaveRates(ctx, batch)
+		attrs := metric.WithAttributes(attribute.Bool("success", err == nil))
+		b.flushDuration.Record(ctx, time.Since(start).Seconds(), attrs)
+		b.flushSize.Record(ctx, int64(len(batch)), attrs)
+
+		if err != nil {
+			b.requeue(ctx, batch)
+			return err
+		}
+
+		b.logger.Debug("Rates flushed", "count", len(batch), "duration", time.Since(start))
+	}
+}
+
+// requeue puts a failed batch back in front of the pending rates, dropping the oldest beyond the limit
+func (b *Buffer) requeue(ctx context.Context, batch []*exchange.Rate) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.pending = append(batch, b.pending...)
+	if limit := maxPendingBatches * b.batchSize; len(b.pending) > limit {
+		drop := len(b.pending) - limit
+		b.pending = b.pending[drop:]
+		b.dropped.Add(ctx, int64(drop))
+		b.logger.Warn("Ingest buffer full, dropped oldest rates", "dropped", drop)
+	}
+}
+
+// initMetrics registers flush size, flush duration and drop instruments on the global meter provider
+func (b *Buffer) initMetrics() {
+	meter := otel.Meter("ingest")
+
+	b.flushSize, _ = meter.Int64Histogram("ingest_flush_size",
+		metric.WithDescription("Rates written per ingest buffer flush"))
+	b.flushDuration, _ = meter.Float64Histogram("ingest_flush_duration",
+		metric.WithUnit("s"),
+		metric.WithDescription("Duration of ingest buffer flushes"))
+	b.dropped, _ = meter.Int64Counter("ingest_rates_dropped",
+		metric.WithDescription("Rates dropped because the ingest buffer stayed full while the store was failing"))
+}
@@ -0,0 +1,102 @@
+package ingest
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/cawa87/garantex-test/internal/lib/logger/sl"
+	"github.com/cawa87/garantex-test/internal/service/exchange"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeStore struct {
+	mu      sync.Mutex
+	batches []int
+	saved   int
+	err     error
+}
+
+func (s *fakeStore) SaveRates(_ context.Context, rates []*exchange.Rate) (int64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.err != nil {
+		return 0, s.err
+	}
+	s.batches = append(s.batches, len(rates))
+	s.saved += len(rates)
+	return int64(len(rates)), nil
+}
+
+func (s *fakeStore) savedCount() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.saved
+}
+
+func TestFlushBatches(t *testing.T) {
+	logger, err := sl.New("info")
+	require.NoError(t, err)
+
+	store := &fakeStore{}
+	buffer := NewBuffer(store, 2, time.Hour, logger)
+	for i := 0; i < 5; i++ {
+		buffer.Add(&exchange.Rate{Market: exchange.DefaultMarket})
+	}
+
+	require.NoError(t, buffer.Flush(context.Background()))
+	assert.Equal(t, []int{2, 2, 1}, store.batches)
+	assert.Equal(t, 0, buffer.Len())
+}
+
+func TestFlushFailureKeepsRates(t *testing.T) {
+	logger, err := sl.New("info")
+	require.NoError(t, err)
+
+	store := &fakeStore{err: errors.New("db down")}
+	buffer := NewBuffer(store, 2, time.Hour, logger)
+	buffer.Add(&exchange.Rate{})
+	buffer.Add(&exchange.Rate{})
+	buffer.Add(&exchange.Rate{})
+
+	assert.Error(t, buffer.Flush(context.Background()))
+	assert.Equal(t, 3, buffer.Len())
+
+	// Beyond maxPendingBatches batches the oldest rates are dropped
+	for i := 0; i < maxPendingBatches*2; i++ {
+		buffer.Add(&exchange.Rate{})
+	}
+	assert.Error(t, buffer.Flush(context.Background()))
+	assert.Equal(t, maxPendingBatches*2, buffer.Len())
+
+	store.err = nil
+	require.NoError(t, buffer.Flush(context.Background()))
+	assert.Equal(t, maxPendingBatches*2, store.saved)
+}
+
+func TestRunFlushesFullBatchAndOnShutdown(t *testing.T) {
+	logger, err := sl.New("info")
+	require.NoError(t, err)
+
+	store := &fakeStore{}
+	buffer := NewBuffer(store, 2, time.Hour, logger)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		buffer.Run(ctx)
+		close(done)
+	}()
+
+	buffer.Add(&exchange.Rate{})
+	buffer.Add(&exchange.Rate{})
+	assert.Eventually(t, func() bool { return store.savedCount() == 2 }, time.Second, 10*time.Millisecond)
+
+	buffer.Add(&exchange.Rate{})
+	cancel()
+	<-done
+	assert.Equal(t, 3, store.savedCount())
+}
@@ -0,0 +1,217 @@
+package leader
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/cawa87/garantex-test/internal/lib/logger/sl"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/metric"
+)
+
+// releaseTimeout bounds giving up the lock on resignation
+const releaseTimeout = 5 * time.Second
+
+// Lock is a lock held by at most one replica at a time
+type Lock interface {
+	// TryAcquire takes the lock if it is free; the lease holds it until released or lost
+	TryAcquire(ctx context.Context) (lease Lease, acquired bool, err error)
+	// Holder returns the identity of the replica holding the lock, empty when it is free
+	Holder(ctx context.Context) (string, error)
+}
+
+// Lease is a held lock
+type Lease interface {
+	// Check returns an error once the lock may have been lost
+	Check(ctx context.Context) error
+	// Release gives the lock up
+	Release(ctx context.Context) error
+}
+
+// Job is a singleton background job that runs until its context is cancelled
+type Job interface {
+	Run(ctx context.Context)
+}
+
+// Elector runs singleton jobs on the replica holding the lock. Replicas try to take the lock and the
+// holder checks it every interval; when the lock is lost, or a check does not answer within the interval,
+// the jobs' context is cancelled (fenced) before anything else happens
+type Elector struct {
+	lock     Lock
+	identity string
+	interval time.Duration
+	logger   *sl.Logger
+
+	jobs      []Job
+	callbacks []func(leading bool)
+
+	leading atomic.Bool
+	leader  atomic.Value
+
+	changes metric.Int64Counter
+}
+
+// term is a period of leadership
+type term struct {
+	lease  Lease
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+}
+
+// NewElector creates an elector competing for lock as identity every interval
+func NewElector(lock Lock, identity string, interval time.Duration, logger *sl.Logger) *Elector {
+	e := &Elector{
+		lock:     lock,
+		identity: identity,
+		interval: interval,
+		logger:   logger,
+	}
+	e.leader.Store("")
+	e.initMetrics()
+	return e
+}
+
+// Add registers jobs to run while this replica leads. It must be called before Run
+func (e *Elector) Add(jobs ...Job) {
+	e.jobs = append(e.jobs, jobs...)
+}
+
+// OnChange registers a callback for gaining (true) and losing (false) leadership. Callbacks run on the
+// elector's goroutine after the jobs have been started or stopped. It must be called before Run
+func (e *Elector) OnChange(fn func(leading bool)) {
+	e.callbacks = append(e.callbacks, fn)
+}
+
+// IsLeader reports whether this replica currently leads
+func (e *Elector) IsLeader() bool {
+	return e.leading.Load()
+}
+
+// Leader returns the identity of the leading replica as of the last check, empty when there is none
+func (e *Elector) Leader() string {
+	return e.leader.Load().(string)
+}
+
+// Run campaigns every interval until ctx is cancelled, then stops the jobs and releases the lock
+func (e *Elector) Run(ctx context.Context) {
+	ticker := time.NewTicker(e.interval)
+	defer ticker.Stop()
+
+	var current *term
+	for {
+		current = e.step(ctx, current)
+
+		select {
+		case <-ctx.Done():
+			e.resign(current)
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// step takes the lock when it is free, or checks it when held, and returns the resulting term.
+// Each lock call is bounded by the interval: a check that times out, e.g. on a half-open connection
+// whose session the database may already have ended, counts as a lost lock
+func (e *Elector) step(ctx context.Context, current *term) *term {
+	if current == nil {
+		callCtx, cancel := context.WithTimeout(ctx, e.interval)
+		lease, acquired, err := e.lock.TryAcquire(callCtx)
+		cancel()
+		if err != nil {
+			if ctx.Err() == nil {
+				e.logger.Warn("Failed to campaign for leadership", "error", err)
+			}
+		} else if acquired {
+			current = e.lead(ctx, lease)
+		}
+	} else {
+		callCtx, cancel := context.WithTimeout(ctx, e.interval)
+		err := current.lease.Check(callCtx)
+		cancel()
+		if err != nil && ctx.Err() == nil {
+			e.logger.Error("Leadership lost, stopping singleton jobs", "error", err)
+			e.resign(current)
+			current = nil
+		}
+	}
+
+	if current != nil {
+		e.leader.Store(e.identity)
+		return current
+	}
+
+	callCtx, cancel := context.WithTimeout(ctx, e.interval)
+	defer cancel()
+	if holder, err := e.lock.Holder(callCtx); err == nil {
+		e.leader.Store(holder)
+	} else if ctx.Err() == nil {
+		e.logger.Warn("Failed to look up the leader", "error", err)
+	}
+	return current
+}
+
+// lead starts the jobs under a new term
+func (e *Elector) lead(ctx context.Context, lease Lease) *term {
+	jobCtx, cancel := context.WithCancel(ctx)
+	t := &term{lease: lease, cancel: cancel}
+
+	for _, job := range e.jobs {
+		t.wg.Add(1)
+		go func(job Job) {
+			defer t.wg.Done()
+			job.Run(jobCtx)
+		}(job)
+	}
+
+	e.leading.Store(true)
+	e.changes.Add(ctx, 1)
+	e.logger.Info("Became leader", "identity", e.identity, "jobs", len(e.jobs))
+	for _, fn := range e.callbacks {
+		fn(true)
+	}
+	return t
+}
+
+// resign stops the jobs of a term, waits for them and then releases its lock
+func (e *Elector) resign(t *term) {
+	if t == nil {
+		return
+	}
+
+	t.cancel()
+	t.wg.Wait()
+
+	e.leading.Store(false)
+	e.leader.Store("")
+	e.logger.Info("Stepped down as leader", "identity", e.identity)
+	for _, fn := range e.callbacks {
+		fn(false)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), releaseTimeout)
+	defer cancel()
+	if err := t.lease.Release(ctx); err != nil {
+		e.logger.Warn("Failed to release leader lock", "error", err)
+	}
+}
+
+// initMetrics registers leadership instruments on the global meter provider
+func (e *Elector) initMetrics() {
+	meter := otel.Meter("leader")
+
+	e.changes, _ = meter.Int64Counter("leader_elections_won",
+		metric.WithDescription("Times this replica became leader"))
+	_, _ = meter.Int64ObservableGauge("leader_is_leader",
+		metric.WithDescription("1 while this replica runs the singleton jobs"),
+		metric.WithInt64Callback(func(_ context.Context, o metric.Int64Observer) error {
+			if e.leading.Load() {
+				o.Observe(1)
+			} else {
+				o.Observe(0)
+			}
+			return nil
+		}))
+}
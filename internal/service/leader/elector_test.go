@@ -0,0 +1,229 @@
+package leader
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/cawa87/garantex-test/internal/lib/logger/sl"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeLock is an in-process lock shared by several electors
+type fakeLock struct {
+	mu     sync.Mutex
+	holder string
+}
+
+func (l *fakeLock) candidate(identity string) *fakeCandidate {
+	return &fakeCandidate{lock: l, identity: identity}
+}
+
+func (l *fakeLock) holderName() string {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.holder
+}
+
+// fakeCandidate is the Lock as seen by one replica
+type fakeCandidate struct {
+	lock     *fakeLock
+	identity string
+}
+
+func (c *fakeCandidate) TryAcquire(_ context.Context) (Lease, bool, error) {
+	c.lock.mu.Lock()
+	defer c.lock.mu.Unlock()
+	if c.lock.holder != "" {
+		return nil, false, nil
+	}
+	c.lock.holder = c.identity
+	return &fakeLease{candidate: c}, true, nil
+}
+
+func (c *fakeCandidate) Holder(_ context.Context) (string, error) {
+	return c.lock.holderName(), nil
+}
+
+type fakeLease struct {
+	candidate *fakeCandidate
+}
+
+func (l *fakeLease) Check(_ context.Context) error {
+	if l.candidate.lock.holderName() != l.candidate.identity {
+		return errors.New("lock lost")
+	}
+	return nil
+}
+
+func (l *fakeLease) Release(_ context.Context) error {
+	l.candidate.lock.mu.Lock()
+	defer l.candidate.lock.mu.Unlock()
+	if l.candidate.lock.holder == l.candidate.identity {
+		l.candidate.lock.holder = ""
+	}
+	return nil
+}
+
+// countingJob counts running instances
+type countingJob struct {
+	mu      sync.Mutex
+	running int
+	started int
+}
+
+func (j *countingJob) Run(ctx context.Context) {
+	j.mu.Lock()
+	j.running++
+	j.started++
+	j.mu.Unlock()
+
+	<-ctx.Done()
+
+	j.mu.Lock()
+	j.running--
+	j.mu.Unlock()
+}
+
+func (j *countingJob) counts() (running, started int) {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	return j.running, j.started
+}
+
+func TestElector(t *testing.T) {
+	logger, err := sl.New("info")
+	require.NoError(t, err)
+
+	lock := &fakeLock{}
+	job := &countingJob{}
+
+	var (
+		mu      sync.Mutex
+		changes []bool
+	)
+	a := NewElector(lock.candidate("a"), "a", 10*time.Millisecond, logger)
+	a.Add(job)
+	a.OnChange(func(leading bool) {
+		mu.Lock()
+		changes = append(changes, leading)
+		mu.Unlock()
+	})
+	b := NewElector(lock.candidate("b"), "b", 10*time.Millisecond, logger)
+	b.Add(job)
+
+	ctxA, cancelA := context.WithCancel(context.Background())
+	doneA := make(chan struct{})
+	go func() {
+		a.Run(ctxA)
+		close(doneA)
+	}()
+	require.Eventually(t, a.IsLeader, time.Second, 5*time.Millisecond)
+
+	ctxB, cancelB := context.WithCancel(context.Background())
+	defer cancelB()
+	doneB := make(chan struct{})
+	go func() {
+		b.Run(ctxB)
+		close(doneB)
+	}()
+
+	// Only the leader runs the job; the follower reports who leads
+	require.Eventually(t, func() bool { return b.Leader() == "a" }, time.Second, 5*time.Millisecond)
+	assert.False(t, b.IsLeader())
+	running, _ := job.counts()
+	assert.Equal(t, 1, running)
+
+	// Losing the lock fences the job before the other replica takes over
+	lock.mu.Lock()
+	lock.holder = ""
+	lock.mu.Unlock()
+	require.Eventually(t, func() bool { return b.IsLeader() && !a.IsLeader() }, time.Second, 5*time.Millisecond)
+	running, started := job.counts()
+	assert.Equal(t, 1, running)
+	assert.Equal(t, 2, started)
+	require.Eventually(t, func() bool { return a.Leader() == "b" }, time.Second, 5*time.Millisecond)
+
+	mu.Lock()
+	assert.Equal(t, []bool{true, false}, changes)
+	mu.Unlock()
+
+	// Shutting down stops the jobs and frees the lock
+	cancelA()
+	<-doneA
+	cancelB()
+	<-doneB
+	running, _ = job.counts()
+	assert.Zero(t, running)
+	assert.Empty(t, lock.holderName())
+}
+
+// hangingLease is a lease on a half-open connection: checks block until their context ends
+type hangingLease struct {
+	fakeLease
+}
+
+func (l *hangingLease) Check(ctx context.Context) error {
+	<-ctx.Done()
+	return ctx.Err()
+}
+
+// hangingCandidate hands out hanging leases
+type hangingCandidate struct {
+	*fakeCandidate
+}
+
+func (c hangingCandidate) TryAcquire(ctx context.Context) (Lease, bool, error) {
+	lease, acquired, err := c.fakeCandidate.TryAcquire(ctx)
+	if !acquired {
+		return lease, acquired, err
+	}
+	return &hangingLease{fakeLease: *lease.(*fakeLease)}, true, nil
+}
+
+func TestElector_CheckTimeout(t *testing.T) {
+	logger, err := sl.New("info")
+	require.NoError(t, err)
+
+	lock := &fakeLock{}
+	job := &countingJob{}
+	e := NewElector(hangingCandidate{lock.candidate("a")}, "a", 20*time.Millisecond, logger)
+	e.Add(job)
+
+	var (
+		mu      sync.Mutex
+		changes []bool
+	)
+	e.OnChange(func(leading bool) {
+		mu.Lock()
+		changes = append(changes, leading)
+		mu.Unlock()
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	done := make(chan struct{})
+	go func() {
+		e.Run(ctx)
+		close(done)
+	}()
+	require.Eventually(t, e.IsLeader, time.Second, 5*time.Millisecond)
+
+	// A check that does not answer within the interval counts as a lost lock: the job is fenced and
+	// restarted once the lock is taken again, instead of the elector waiting on the check forever
+	require.Eventually(t, func() bool {
+		_, started := job.counts()
+		return started >= 2
+	}, time.Second, 5*time.Millisecond)
+	mu.Lock()
+	assert.Equal(t, []bool{true, false}, changes[:2])
+	mu.Unlock()
+
+	cancel()
+	<-done
+	running, _ := job.counts()
+	assert.Zero(t, running)
+}
@@ -0,0 +1,68 @@
+package orderbook
+
+import (
+	"context"
+	"time"
+
+	"github.com/cawa87/garantex-test/internal/lib/logger/sl"
+)
+
+// deleteBatchSize bounds the rows removed per statement so pruning never holds long locks
+const deleteBatchSize = 1000
+
+// Store deletes expired order book snapshots
+type Store interface {
+	DeleteOrderBooksBefore(ctx context.Context, cutoff time.Time, batchSize int) (int64, error)
+}
+
+// Pruner periodically deletes order book snapshots older than the retention period.
+// It runs independently of tick retention, which leaves snapshots in place
+type Pruner struct {
+	store     Store
+	retention time.Duration
+	interval  time.Duration
+	logger    *sl.Logger
+}
+
+// NewPruner creates a pruner that keeps snapshots for retention and runs every interval
+func NewPruner(store Store, retention, interval time.Duration, logger *sl.Logger) *Pruner {
+	return &Pruner{
+		store:     store,
+		retention: retention,
+		interval:  interval,
+		logger:    logger,
+	}
+}
+
+// Run prunes immediately and then on every interval until ctx is cancelled
+func (p *Pruner) Run(ctx context.Context) {
+	ticker := time.NewTicker(p.interval)
+	defer ticker.Stop()
+
+	for {
+		if _, err := p.Prune(ctx); err != nil && ctx.Err() == nil {
+			p.logger.Error("Failed to prune order book snapshots", "error", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Prune deletes snapshots older than the retention period and returns the number deleted
+func (p *Pruner) Prune(ctx context.Context) (int64, error) {
+	cutoff := time.Now().Add(-p.retention)
+
+	deleted, err := p.store.DeleteOrderBooksBefore(ctx, cutoff, deleteBatchSize)
+	if err != nil {
+		return deleted, err
+	}
+
+	if deleted > 0 {
+		p.logger.Info("Pruned order book snapshots", "deleted", deleted, "cutoff", cutoff)
+	}
+	return deleted, nil
+}
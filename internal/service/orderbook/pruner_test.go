@@ -0,0 +1,40 @@
+package orderbook
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/cawa87/garantex-test/internal/lib/logger/sl"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeStore struct {
+	cutoff  time.Time
+	deleted int64
+	err     error
+}
+
+func (s *fakeStore) DeleteOrderBooksBefore(_ context.Context, cutoff time.Time, _ int) (int64, error) {
+	s.cutoff = cutoff
+	return s.deleted, s.err
+}
+
+func TestPrune(t *testing.T) {
+	logger, err := sl.New("info")
+	require.NoError(t, err)
+
+	store := &fakeStore{deleted: 3}
+	pruner := NewPruner(store, 24*time.Hour, time.Hour, logger)
+
+	deleted, err := pruner.Prune(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, int64(3), deleted)
+	assert.WithinDuration(t, time.Now().Add(-24*time.Hour), store.cutoff, time.Second)
+
+	store.err = errors.New("db down")
+	_, err = pruner.Prune(context.Background())
+	assert.Error(t, err)
+}
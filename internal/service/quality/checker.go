@@ -0,0 +1,216 @@
+package quality
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/cawa87/garantex-test/internal/lib/logger/sl"
+	"github.com/cawa87/garantex-test/internal/service/fixing"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+)
+
+// Store loads rates and persists issues
+type Store interface {
+	// GetTicks returns the tick in force at from (if any) followed by the ticks in [from, to), ascending
+	GetTicks(ctx context.Context, market string, from, to time.Time) ([]fixing.Tick, error)
+	// ReplaceQualityIssues deletes the issues of a market starting in [from, to) and stores issues,
+	// replacing stored issues with the same market, kind and start
+	ReplaceQualityIssues(ctx context.Context, market string, from, to time.Time, issues []Issue) error
+	// ListQualityIssues returns the issues matching the filter, oldest first
+	ListQualityIssues(ctx context.Context, filter ListFilter) ([]Issue, error)
+}
+
+// ListFilter selects issues; zero fields match everything. Issues overlapping [From, To) match
+type ListFilter struct {
+	Market string
+	Kind   Kind
+	From   time.Time
+	To     time.Time
+	Limit  int
+}
+
+// Report is the data quality of a market over a window. Missing is the time lost to gaps and
+// Completeness the share of the window that was not; time before the first stored sample is not counted
+type Report struct {
+	Market       string
+	From         time.Time
+	To           time.Time
+	Issues       []Issue
+	Missing      time.Duration
+	Completeness float64
+}
+
+// Checker scans the recent rates of each market every interval and stores the issues it finds.
+// Each scan covers the lookback window, so issues are revised while the window still covers them;
+// runs longer than the lookback are stored in overlapping pieces
+type Checker struct {
+	store      Store
+	markets    []string
+	thresholds Thresholds
+	interval   time.Duration
+	lookback   time.Duration
+	logger     *sl.Logger
+
+	mu     sync.Mutex
+	latest map[string]*Report
+
+	scans metric.Int64Counter
+}
+
+// NewChecker creates a checker for the markets
+func NewChecker(store Store, markets []string, thresholds Thresholds, interval, lookback time.Duration, logger *sl.Logger) (*Checker, error) {
+	if err := thresholds.Validate(); err != nil {
+		return nil, err
+	}
+	if len(markets) == 0 {
+		return nil, fmt.Errorf("no markets to check")
+	}
+	if lookback < interval {
+		return nil, fmt.Errorf("lookback %s is shorter than the scan interval %s", lookback, interval)
+	}
+
+	c := &Checker{
+		store:      store,
+		markets:    markets,
+		thresholds: thresholds,
+		interval:   interval,
+		lookback:   lookback,
+		logger:     logger,
+		latest:     make(map[string]*Report, len(markets)),
+	}
+	c.initMetrics()
+	return c, nil
+}
+
+// Run scans every market immediately and then on every interval until ctx is cancelled
+func (c *Checker) Run(ctx context.Context) {
+	ticker := time.NewTicker(c.interval)
+	defer ticker.Stop()
+
+	for {
+		now := time.Now()
+		for _, market := range c.markets {
+			if _, err := c.Scan(ctx, market, now); err != nil && ctx.Err() == nil {
+				c.logger.Error("Data quality scan failed", "market", market, "error", err)
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Scan checks the lookback window of a market ending at now and stores the issues found
+func (c *Checker) Scan(ctx context.Context, market string, now time.Time) (*Report, error) {
+	from := now.Add(-c.lookback)
+
+	ticks, err := c.store.GetTicks(ctx, market, from, now)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load ticks: %w", err)
+	}
+	if len(ticks) == 0 {
+		c.logger.Warn("No rates stored for market, skipping data quality scan", "market", market)
+		return nil, nil
+	}
+
+	issues := Detect(market, ticks, now, c.thresholds)
+	for i := range issues {
+		issues[i].DetectedAt = now
+	}
+	if err := c.store.ReplaceQualityIssues(ctx, market, from, now, issues); err != nil {
+		return nil, fmt.Errorf("failed to save issues: %w", err)
+	}
+
+	missing := Missing(issues, from, now, c.thresholds.ExpectedInterval)
+	report := &Report{
+		Market:       market,
+		From:         from,
+		To:           now,
+		Issues:       issues,
+		Missing:      missing,
+		Completeness: Completeness(missing, from, now),
+	}
+
+	c.mu.Lock()
+	c.latest[market] = report
+	c.mu.Unlock()
+	c.scans.Add(ctx, 1, metric.WithAttributes(attribute.String("market", market)))
+
+	c.logger.Debug("Data quality scan completed",
+		"market", market,
+		"ticks", len(ticks),
+		"issues", len(issues),
+		"completeness", report.Completeness)
+	return report, nil
+}
+
+// Report returns the stored issues of a market overlapping [from, to), filtered by kind (empty for all)
+// and limited to limit issues (0 for all). Completeness always counts every gap in the window
+func (c *Checker) Report(ctx context.Context, market string, from, to time.Time, kind Kind, limit int) (*Report, error) {
+	gaps, err := c.store.ListQualityIssues(ctx, ListFilter{Market: market, Kind: KindGap, From: from, To: to})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list gaps: %w", err)
+	}
+
+	issues := gaps
+	if kind != KindGap || limit > 0 {
+		issues, err = c.store.ListQualityIssues(ctx, ListFilter{Market: market, Kind: kind, From: from, To: to, Limit: limit})
+		if err != nil {
+			return nil, fmt.Errorf("failed to list issues: %w", err)
+		}
+	}
+
+	missing := Missing(gaps, from, to, c.thresholds.ExpectedInterval)
+	return &Report{
+		Market:       market,
+		From:         from,
+		To:           to,
+		Issues:       issues,
+		Missing:      missing,
+		Completeness: Completeness(missing, from, to),
+	}, nil
+}
+
+// initMetrics registers data quality instruments on the global meter provider. The gauges describe the
+// latest scan of each market on this replica
+func (c *Checker) initMetrics() {
+	meter := otel.Meter("quality")
+
+	c.scans, _ = meter.Int64Counter("data_quality_scans",
+		metric.WithDescription("Completed data quality scans"))
+	_, _ = meter.Int64ObservableGauge("data_quality_issues",
+		metric.WithDescription("Issues found by the latest data quality scan of the lookback window"),
+		metric.WithInt64Callback(func(_ context.Context, o metric.Int64Observer) error {
+			c.mu.Lock()
+			defer c.mu.Unlock()
+			for market, report := range c.latest {
+				counts := make(map[Kind]int64, len(Kinds))
+				for _, issue := range report.Issues {
+					counts[issue.Kind]++
+				}
+				for _, kind := range Kinds {
+					o.Observe(counts[kind], metric.WithAttributes(
+						attribute.String("market", market),
+						attribute.String("kind", string(kind))))
+				}
+			}
+			return nil
+		}))
+	_, _ = meter.Float64ObservableGauge("data_quality_completeness",
+		metric.WithDescription("Share of the lookback window covered by samples in the latest scan"),
+		metric.WithFloat64Callback(func(_ context.Context, o metric.Float64Observer) error {
+			c.mu.Lock()
+			defer c.mu.Unlock()
+			for market, report := range c.latest {
+				o.Observe(report.Completeness, metric.WithAttributes(attribute.String("market", market)))
+			}
+			return nil
+		}))
+}
@@ -0,0 +1,195 @@
+package quality
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/cawa87/garantex-test/internal/service/fixing"
+	"github.com/shopspring/decimal"
+)
+
+// Kind is the check an issue failed
+type Kind string
+
+const (
+	// KindGap is a stretch without samples longer than the maximum gap
+	KindGap Kind = "gap"
+	// KindFrozen is a run of samples with identical ask and bid
+	KindFrozen Kind = "frozen"
+	// KindCrossed is a run of samples with the bid above the ask
+	KindCrossed Kind = "crossed"
+	// KindJump is a mid price move between consecutive samples larger than the maximum jump
+	KindJump Kind = "jump"
+)
+
+// Kinds lists every issue kind
+var Kinds = []Kind{KindGap, KindFrozen, KindCrossed, KindJump}
+
+// ParseKind parses an issue kind
+func ParseKind(s string) (Kind, error) {
+	for _, kind := range Kinds {
+		if string(kind) == s {
+			return kind, nil
+		}
+	}
+	return "", fmt.Errorf("unknown data quality issue kind %q", s)
+}
+
+// Issue is a stretch of a market's history that failed a check. Start and End are the first and last
+// samples involved; a gap still open at the end of a scan runs to the end of the scanned window
+type Issue struct {
+	ID         int64
+	Market     string
+	Kind       Kind
+	Start      time.Time
+	End        time.Time
+	Samples    int
+	Detail     string
+	DetectedAt time.Time
+}
+
+// Thresholds configure the checks. ExpectedInterval is the poll interval: a gap loses its length less one
+// interval. A zero FrozenSamples or MaxJump disables that check
+type Thresholds struct {
+	ExpectedInterval time.Duration
+	MaxGap           time.Duration
+	FrozenSamples    int
+	MaxJump          decimal.Decimal
+}
+
+// Validate checks the thresholds
+func (t Thresholds) Validate() error {
+	if t.ExpectedInterval <= 0 {
+		return fmt.Errorf("expected interval must be positive, got %s", t.ExpectedInterval)
+	}
+	if t.MaxGap < t.ExpectedInterval {
+		return fmt.Errorf("max gap %s is shorter than the expected interval %s", t.MaxGap, t.ExpectedInterval)
+	}
+	if t.FrozenSamples == 1 || t.FrozenSamples < 0 {
+		return fmt.Errorf("frozen samples must be 0 (disabled) or at least 2, got %d", t.FrozenSamples)
+	}
+	if t.MaxJump.IsNegative() {
+		return fmt.Errorf("max jump must not be negative, got %s", t.MaxJump)
+	}
+	return nil
+}
+
+// Detect runs the checks over the ascending ticks of a market scanned up to end
+func Detect(market string, ticks []fixing.Tick, end time.Time, t Thresholds) []Issue {
+	var issues []Issue
+	add := func(kind Kind, start, end time.Time, samples int, detail string) {
+		issues = append(issues, Issue{
+			Market:  market,
+			Kind:    kind,
+			Start:   start,
+			End:     end,
+			Samples: samples,
+			Detail:  detail,
+		})
+	}
+
+	var (
+		frozenStart  int
+		crossedStart = -1
+		maxCrossing  decimal.Decimal
+	)
+	closeFrozen := func(i int) {
+		if t.FrozenSamples > 0 && i-frozenStart >= t.FrozenSamples {
+			first := ticks[frozenStart]
+			add(KindFrozen, first.Timestamp, ticks[i-1].Timestamp, i-frozenStart,
+				fmt.Sprintf("ask %s and bid %s unchanged for %d samples", first.Ask, first.Bid, i-frozenStart))
+		}
+		frozenStart = i
+	}
+	closeCrossed := func(i int) {
+		if crossedStart >= 0 {
+			add(KindCrossed, ticks[crossedStart].Timestamp, ticks[i-1].Timestamp, i-crossedStart,
+				fmt.Sprintf("bid above ask by up to %s", maxCrossing))
+		}
+		crossedStart = -1
+		maxCrossing = decimal.Zero
+	}
+
+	for i, tick := range ticks {
+		if tick.Bid.GreaterThan(tick.Ask) {
+			if crossedStart < 0 {
+				crossedStart = i
+			}
+			maxCrossing = decimal.Max(maxCrossing, tick.Bid.Sub(tick.Ask))
+		} else {
+			closeCrossed(i)
+		}
+
+		if i == 0 {
+			continue
+		}
+		prev := ticks[i-1]
+
+		if !tick.Ask.Equal(prev.Ask) || !tick.Bid.Equal(prev.Bid) {
+			closeFrozen(i)
+		}
+
+		if d := tick.Timestamp.Sub(prev.Timestamp); d > t.MaxGap {
+			add(KindGap, prev.Timestamp, tick.Timestamp, 2, fmt.Sprintf("no samples for %s", d))
+		}
+
+		if move, ok := midMove(prev, tick); ok && t.MaxJump.IsPositive() && move.Abs().GreaterThan(t.MaxJump) {
+			add(KindJump, prev.Timestamp, tick.Timestamp, 2,
+				fmt.Sprintf("mid price moved %s%%", move.Mul(decimal.NewFromInt(100)).StringFixed(2)))
+		}
+	}
+
+	if len(ticks) > 0 {
+		closeFrozen(len(ticks))
+		closeCrossed(len(ticks))
+
+		last := ticks[len(ticks)-1].Timestamp
+		if d := end.Sub(last); d > t.MaxGap {
+			add(KindGap, last, end, 1, fmt.Sprintf("no samples for %s (ongoing)", d))
+		}
+	}
+
+	return issues
+}
+
+// midMove returns the relative mid price move from prev to next; ok is false when prev has no mid price
+func midMove(prev, next fixing.Tick) (decimal.Decimal, bool) {
+	two := decimal.NewFromInt(2)
+	before := prev.Ask.Add(prev.Bid).Div(two)
+	if !before.IsPositive() {
+		return decimal.Zero, false
+	}
+	after := next.Ask.Add(next.Bid).Div(two)
+	return after.Sub(before).Div(before), true
+}
+
+// Missing returns how much of [from, to) the gap issues lost: each gap less one expected interval
+func Missing(issues []Issue, from, to time.Time, expectedInterval time.Duration) time.Duration {
+	var missing time.Duration
+	for _, issue := range issues {
+		if issue.Kind != KindGap {
+			continue
+		}
+		start := issue.Start.Add(expectedInterval)
+		if start.Before(from) {
+			start = from
+		}
+		end := issue.End
+		if end.After(to) {
+			end = to
+		}
+		if end.After(start) {
+			missing += end.Sub(start)
+		}
+	}
+	return missing
+}
+
+// Completeness returns the share of [from, to) not lost to gaps, between 0 and 1
+func Completeness(missing time.Duration, from, to time.Time) float64 {
+	window := to.Sub(from)
+	if window <= 0 {
+		return 1
+	}
+	return 1 - float64(missing)/float64(window)
+}
@@ -0,0 +1,249 @@
+package quality
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/cawa87/garantex-test/internal/lib/logger/sl"
+	"github.com/cawa87/garantex-test/internal/service/exchange"
+	"github.com/cawa87/garantex-test/internal/service/fixing"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func dec(s string) decimal.Decimal {
+	return decimal.RequireFromString(s)
+}
+
+var thresholds = Thresholds{
+	ExpectedInterval: 10 * time.Second,
+	MaxGap:           30 * time.Second,
+	FrozenSamples:    3,
+	MaxJump:          dec("0.05"),
+}
+
+func tick(at time.Time, ask, bid string) fixing.Tick {
+	return fixing.Tick{Ask: dec(ask), Bid: dec(bid), Timestamp: at}
+}
+
+func TestDetect(t *testing.T) {
+	start := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+	at := func(seconds int) time.Time { return start.Add(time.Duration(seconds) * time.Second) }
+
+	ticks := []fixing.Tick{
+		tick(at(0), "100", "99"),
+		tick(at(10), "100", "99"),
+		tick(at(20), "100", "99"),
+		// 2-minute gap
+		tick(at(140), "101", "100"),
+		// crossed for two samples
+		tick(at(150), "100", "101"),
+		tick(at(160), "100", "102"),
+		// 10% jump
+		tick(at(170), "111", "110"),
+	}
+
+	issues := Detect("btcusdt", ticks, at(260), thresholds)
+
+	byKind := map[Kind][]Issue{}
+	for _, issue := range issues {
+		assert.Equal(t, "btcusdt", issue.Market)
+		byKind[issue.Kind] = append(byKind[issue.Kind], issue)
+	}
+
+	require.Len(t, byKind[KindFrozen], 1)
+	assert.Equal(t, at(0), byKind[KindFrozen][0].Start)
+	assert.Equal(t, at(20), byKind[KindFrozen][0].End)
+	assert.Equal(t, 3, byKind[KindFrozen][0].Samples)
+
+	require.Len(t, byKind[KindGap], 2)
+	assert.Equal(t, at(20), byKind[KindGap][0].Start)
+	assert.Equal(t, at(140), byKind[KindGap][0].End)
+	// The gap still open at the end of the scan
+	assert.Equal(t, at(170), byKind[KindGap][1].Start)
+	assert.Equal(t, at(260), byKind[KindGap][1].End)
+	assert.Contains(t, byKind[KindGap][1].Detail, "ongoing")
+
+	require.Len(t, byKind[KindCrossed], 1)
+	assert.Equal(t, at(150), byKind[KindCrossed][0].Start)
+	assert.Equal(t, at(160), byKind[KindCrossed][0].End)
+	assert.Equal(t, 2, byKind[KindCrossed][0].Samples)
+	assert.Contains(t, byKind[KindCrossed][0].Detail, "up to 2")
+
+	require.Len(t, byKind[KindJump], 1)
+	assert.Equal(t, at(160), byKind[KindJump][0].Start)
+	assert.Equal(t, at(170), byKind[KindJump][0].End)
+}
+
+func TestDetect_ExchangeBooks(t *testing.T) {
+	start := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+
+	// Three identical books, then two with the best bid above the best ask
+	books := []struct{ ask, bid string }{
+		{"100.5", "100.1"}, {"100.5", "100.1"}, {"100.5", "100.1"},
+		{"100.2", "100.3"}, {"100.2", "100.4"},
+	}
+	var served int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		book := books[served]
+		ts := start.Add(time.Duration(served) * 10 * time.Second).Unix()
+		served++
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = fmt.Fprintf(w, `{"timestamp": %d, "asks": [{"price": %q, "volume": "1"}], "bids": [{"price": %q, "volume": "1"}]}`,
+			ts, book.ask, book.bid)
+	}))
+	defer server.Close()
+
+	logger, err := sl.New("error")
+	require.NoError(t, err)
+	client := exchange.NewClient(server.URL, time.Second, logger)
+
+	var ticks []fixing.Tick
+	for range books {
+		rate, err := client.GetRates(context.Background())
+		require.NoError(t, err)
+		ticks = append(ticks, fixing.Tick{Ask: rate.Ask, Bid: rate.Bid, Timestamp: rate.Timestamp})
+	}
+
+	byKind := map[Kind][]Issue{}
+	for _, issue := range Detect(exchange.DefaultMarket, ticks, ticks[len(ticks)-1].Timestamp, thresholds) {
+		byKind[issue.Kind] = append(byKind[issue.Kind], issue)
+	}
+
+	require.Len(t, byKind[KindFrozen], 1)
+	assert.Equal(t, 3, byKind[KindFrozen][0].Samples)
+	require.Len(t, byKind[KindCrossed], 1)
+	assert.Equal(t, ticks[3].Timestamp, byKind[KindCrossed][0].Start)
+	assert.Equal(t, 2, byKind[KindCrossed][0].Samples)
+}
+
+func TestDetect_Disabled(t *testing.T) {
+	start := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+	ticks := []fixing.Tick{
+		tick(start, "100", "99"),
+		tick(start.Add(10*time.Second), "100", "99"),
+		tick(start.Add(20*time.Second), "100", "99"),
+		tick(start.Add(30*time.Second), "200", "199"),
+	}
+
+	issues := Detect("btcusdt", ticks, start.Add(40*time.Second), Thresholds{
+		ExpectedInterval: 10 * time.Second,
+		MaxGap:           30 * time.Second,
+	})
+	assert.Empty(t, issues)
+}
+
+func TestMissing(t *testing.T) {
+	from := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+	to := from.Add(time.Hour)
+
+	issues := []Issue{
+		// 6 minutes lost, less one 10s interval
+		{Kind: KindGap, Start: from.Add(10 * time.Minute), End: from.Add(16 * time.Minute)},
+		// Starts before the window, so only the part inside counts
+		{Kind: KindGap, Start: from.Add(-5 * time.Minute), End: from.Add(time.Minute)},
+		{Kind: KindFrozen, Start: from, End: to},
+	}
+
+	missing := Missing(issues, from, to, 10*time.Second)
+	assert.Equal(t, 6*time.Minute-10*time.Second+time.Minute, missing)
+	assert.InDelta(t, 1-missing.Seconds()/3600, Completeness(missing, from, to), 1e-9)
+	assert.Equal(t, 1.0, Completeness(0, from, to))
+}
+
+func TestThresholds_Validate(t *testing.T) {
+	assert.NoError(t, thresholds.Validate())
+
+	bad := thresholds
+	bad.MaxGap = 5 * time.Second
+	assert.Error(t, bad.Validate())
+
+	bad = thresholds
+	bad.FrozenSamples = 1
+	assert.Error(t, bad.Validate())
+
+	bad = thresholds
+	bad.MaxJump = dec("-0.1")
+	assert.Error(t, bad.Validate())
+}
+
+type fakeStore struct {
+	ticks  []fixing.Tick
+	issues []Issue
+}
+
+func (s *fakeStore) GetTicks(_ context.Context, _ string, _, _ time.Time) ([]fixing.Tick, error) {
+	return s.ticks, nil
+}
+
+func (s *fakeStore) ReplaceQualityIssues(_ context.Context, market string, from, to time.Time, issues []Issue) error {
+	kept := s.issues[:0]
+	for _, issue := range s.issues {
+		if issue.Market == market && !issue.Start.Before(from) && issue.Start.Before(to) {
+			continue
+		}
+		kept = append(kept, issue)
+	}
+	s.issues = append(kept, issues...)
+	return nil
+}
+
+func (s *fakeStore) ListQualityIssues(_ context.Context, filter ListFilter) ([]Issue, error) {
+	var issues []Issue
+	for _, issue := range s.issues {
+		if filter.Kind != "" && issue.Kind != filter.Kind {
+			continue
+		}
+		issues = append(issues, issue)
+		if filter.Limit > 0 && len(issues) == filter.Limit {
+			break
+		}
+	}
+	return issues, nil
+}
+
+func TestChecker(t *testing.T) {
+	logger, err := sl.New("info")
+	require.NoError(t, err)
+
+	now := time.Date(2024, 3, 1, 13, 0, 0, 0, time.UTC)
+	store := &fakeStore{ticks: []fixing.Tick{
+		tick(now.Add(-time.Hour), "100", "99"),
+		tick(now.Add(-30*time.Minute), "101", "100"),
+		tick(now.Add(-30*time.Minute+10*time.Second), "100", "101"),
+		tick(now, "101", "100"),
+	}}
+
+	_, err = NewChecker(store, nil, thresholds, time.Minute, time.Hour, logger)
+	assert.Error(t, err)
+	_, err = NewChecker(store, []string{"btcusdt"}, thresholds, time.Hour, time.Minute, logger)
+	assert.Error(t, err)
+
+	checker, err := NewChecker(store, []string{"btcusdt"}, thresholds, time.Minute, time.Hour, logger)
+	require.NoError(t, err)
+
+	report, err := checker.Scan(context.Background(), "btcusdt", now)
+	require.NoError(t, err)
+	require.Len(t, report.Issues, 3)
+	assert.Equal(t, []Kind{KindGap, KindCrossed, KindGap},
+		[]Kind{report.Issues[0].Kind, report.Issues[1].Kind, report.Issues[2].Kind})
+	assert.Equal(t, now, report.Issues[0].DetectedAt)
+	assert.Equal(t, 2*(30*time.Minute-10*time.Second)-10*time.Second, report.Missing)
+
+	// Rescanning replaces the stored issues instead of adding to them
+	_, err = checker.Scan(context.Background(), "btcusdt", now)
+	require.NoError(t, err)
+	assert.Len(t, store.issues, 3)
+
+	stored, err := checker.Report(context.Background(), "btcusdt", now.Add(-time.Hour), now, KindCrossed, 0)
+	require.NoError(t, err)
+	require.Len(t, stored.Issues, 1)
+	assert.Equal(t, KindCrossed, stored.Issues[0].Kind)
+	assert.Equal(t, report.Missing, stored.Missing)
+	assert.Equal(t, report.Completeness, stored.Completeness)
+}
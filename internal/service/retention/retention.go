@@ -0,0 +1,259 @@
+package retention
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/cawa87/garantex-test/internal/lib/logger/sl"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+)
+
+// advisoryLockKey identifies the retention job among Postgres advisory locks
+const advisoryLockKey int64 = 0x7261746573726574 // "ratesret"
+
+// Resolution is the bucket width of rate aggregates
+type Resolution string
+
+const (
+	// Minute aggregates raw ticks into 1-minute OHLC buckets
+	Minute Resolution = "1m"
+	// Hour rolls 1-minute buckets up into 1-hour buckets
+	Hour Resolution = "1h"
+)
+
+// Store performs the retention steps; counts are rows affected
+type Store interface {
+	// WithAdvisoryLock runs fn while holding the session advisory lock; acquired is false when another session holds it
+	WithAdvisoryLock(ctx context.Context, key int64, fn func(ctx context.Context) error) (acquired bool, err error)
+	// DownsampleRates moves raw rates before cutoff into 1-minute buckets per market and source in batches,
+	// merging them into existing buckets; it returns the buckets written and the rates moved
+	DownsampleRates(ctx context.Context, cutoff time.Time, batchSize int) (buckets, rates int64, err error)
+	// RollupAggregates moves 1-minute buckets before cutoff into 1-hour buckets the same way; it returns the
+	// 1-hour buckets written and the 1-minute buckets moved
+	RollupAggregates(ctx context.Context, cutoff time.Time, batchSize int) (buckets, minutes int64, err error)
+	// DeleteAggregatesBefore deletes buckets of the resolution before cutoff in batches
+	DeleteAggregatesBefore(ctx context.Context, resolution Resolution, cutoff time.Time, batchSize int) (int64, error)
+	// CountRatesBefore counts raw rates before cutoff
+	CountRatesBefore(ctx context.Context, cutoff time.Time) (int64, error)
+	// CountAggregatesBefore counts buckets of the resolution before cutoff
+	CountAggregatesBefore(ctx context.Context, resolution Resolution, cutoff time.Time) (int64, error)
+}
+
+// Policy is how long each tier is kept; zero keeps a tier forever.
+// Raw ticks are downsampled into 1-minute buckets when they expire, and 1-minute buckets into 1-hour buckets
+type Policy struct {
+	Raw    time.Duration
+	Minute time.Duration
+	Hour   time.Duration
+}
+
+// Validate checks that every tier outlives the tier it is built from
+func (p Policy) Validate() error {
+	if p.Raw < 0 || p.Minute < 0 || p.Hour < 0 {
+		return fmt.Errorf("retention periods must not be negative")
+	}
+	if p.Minute > 0 && p.Minute < p.Raw {
+		return fmt.Errorf("1-minute retention %s is shorter than raw retention %s", p.Minute, p.Raw)
+	}
+	if p.Hour > 0 && p.Hour < p.Minute {
+		return fmt.Errorf("1-hour retention %s is shorter than 1-minute retention %s", p.Hour, p.Minute)
+	}
+	if p.Raw == 0 && p.Minute > 0 {
+		return fmt.Errorf("1-minute retention needs a raw retention")
+	}
+	return nil
+}
+
+// Report summarises one retention run
+type Report struct {
+	DryRun      bool
+	Downsampled map[Resolution]int64
+	Pruned      map[string]int64
+}
+
+// Job downsamples and prunes rates periodically. Only the replica holding the advisory lock runs it
+type Job struct {
+	store     Store
+	policy    Policy
+	interval  time.Duration
+	batchSize int
+	dryRun    bool
+	logger    *sl.Logger
+
+	pruned      metric.Int64Counter
+	downsampled metric.Int64Counter
+	duration    metric.Float64Histogram
+}
+
+// NewJob creates a retention job running every interval. In dry-run mode it only counts what it would prune
+func NewJob(store Store, policy Policy, interval time.Duration, batchSize int, dryRun bool, logger *sl.Logger) (*Job, error) {
+	if err := policy.Validate(); err != nil {
+		return nil, err
+	}
+	if batchSize <= 0 {
+		return nil, fmt.Errorf("batch size must be positive, got %d", batchSize)
+	}
+
+	j := &Job{
+		store:     store,
+		policy:    policy,
+		interval:  interval,
+		batchSize: batchSize,
+		dryRun:    dryRun,
+		logger:    logger,
+	}
+	j.initMetrics()
+	return j, nil
+}
+
+// Run runs the job immediately and then on every interval until ctx is cancelled
+func (j *Job) Run(ctx context.Context) {
+	ticker := time.NewTicker(j.interval)
+	defer ticker.Stop()
+
+	for {
+		if _, err := j.RunOnce(ctx); err != nil && ctx.Err() == nil {
+			j.logger.Error("Retention run failed", "error", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// RunOnce runs one retention pass if the advisory lock is free; the report is nil when another replica holds it
+func (j *Job) RunOnce(ctx context.Context) (*Report, error) {
+	start := time.Now()
+
+	var report *Report
+	acquired, err := j.store.WithAdvisoryLock(ctx, advisoryLockKey, func(ctx context.Context) error {
+		var err error
+		report, err = j.run(ctx, start)
+		return err
+	})
+	if err != nil {
+		return report, err
+	}
+	if !acquired {
+		j.logger.Debug("Retention run skipped, lock held by another replica")
+		return nil, nil
+	}
+
+	j.duration.Record(ctx, time.Since(start).Seconds(), metric.WithAttributes(attribute.Bool("dry_run", j.dryRun)))
+	j.logger.Info("Retention run completed",
+		"dry_run", report.DryRun,
+		"downsampled", report.Downsampled,
+		"pruned", report.Pruned,
+		"duration", time.Since(start))
+	return report, nil
+}
+
+func (j *Job) run(ctx context.Context, now time.Time) (*Report, error) {
+	report := &Report{
+		DryRun:      j.dryRun,
+		Downsampled: map[Resolution]int64{},
+		Pruned:      map[string]int64{},
+	}
+
+	// Cutoffs are whole hours so every downsampled bucket is complete
+	if j.policy.Raw > 0 {
+		cutoff := now.Add(-j.policy.Raw).Truncate(time.Hour)
+		if err := j.downsample(ctx, report, Minute, "rates", cutoff, j.store.CountRatesBefore, j.store.DownsampleRates); err != nil {
+			return report, err
+		}
+	}
+
+	if j.policy.Minute > 0 {
+		cutoff := now.Add(-j.policy.Minute).Truncate(time.Hour)
+		count := func(ctx context.Context, cutoff time.Time) (int64, error) {
+			return j.store.CountAggregatesBefore(ctx, Minute, cutoff)
+		}
+		if err := j.downsample(ctx, report, Hour, "rate_aggregates_"+string(Minute), cutoff, count, j.store.RollupAggregates); err != nil {
+			return report, err
+		}
+	}
+
+	if j.policy.Hour > 0 {
+		cutoff := now.Add(-j.policy.Hour).Truncate(time.Hour)
+		if err := j.pruneAggregates(ctx, report, Hour, cutoff); err != nil {
+			return report, err
+		}
+	}
+
+	return report, nil
+}
+
+// downsample moves the rows of table before cutoff into buckets of the resolution; a dry run only counts them
+func (j *Job) downsample(ctx context.Context, report *Report, resolution Resolution, table string, cutoff time.Time,
+	count func(context.Context, time.Time) (int64, error),
+	move func(context.Context, time.Time, int) (int64, int64, error)) error {
+	if j.dryRun {
+		return j.prune(ctx, report, table, cutoff, count, nil)
+	}
+
+	buckets, moved, err := move(ctx, cutoff, j.batchSize)
+	if err != nil {
+		return fmt.Errorf("failed to downsample into %s buckets: %w", resolution, err)
+	}
+	report.Downsampled[resolution] = buckets
+	j.downsampled.Add(ctx, buckets, metric.WithAttributes(attribute.String("resolution", string(resolution))))
+	j.recordPruned(ctx, report, table, moved)
+	return nil
+}
+
+func (j *Job) pruneAggregates(ctx context.Context, report *Report, resolution Resolution, cutoff time.Time) error {
+	count := func(ctx context.Context, cutoff time.Time) (int64, error) {
+		return j.store.CountAggregatesBefore(ctx, resolution, cutoff)
+	}
+	del := func(ctx context.Context, cutoff time.Time, batchSize int) (int64, error) {
+		return j.store.DeleteAggregatesBefore(ctx, resolution, cutoff, batchSize)
+	}
+	return j.prune(ctx, report, "rate_aggregates_"+string(resolution), cutoff, count, del)
+}
+
+func (j *Job) prune(ctx context.Context, report *Report, table string, cutoff time.Time,
+	count func(context.Context, time.Time) (int64, error),
+	del func(context.Context, time.Time, int) (int64, error)) error {
+	var (
+		n   int64
+		err error
+	)
+	if j.dryRun {
+		n, err = count(ctx, cutoff)
+	} else {
+		n, err = del(ctx, cutoff, j.batchSize)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to prune %s: %w", table, err)
+	}
+
+	j.recordPruned(ctx, report, table, n)
+	return nil
+}
+
+func (j *Job) recordPruned(ctx context.Context, report *Report, table string, n int64) {
+	report.Pruned[table] = n
+	j.pruned.Add(ctx, n, metric.WithAttributes(
+		attribute.String("table", table),
+		attribute.Bool("dry_run", j.dryRun),
+	))
+}
+
+// initMetrics registers pruning, downsampling and run duration instruments on the global meter provider
+func (j *Job) initMetrics() {
+	meter := otel.Meter("retention")
+
+	j.pruned, _ = meter.Int64Counter("retention_rows_pruned",
+		metric.WithDescription("Rows deleted by the retention job (counted only in dry-run mode)"))
+	j.downsampled, _ = meter.Int64Counter("retention_buckets_downsampled",
+		metric.WithDescription("Aggregate bucket writes by the retention job; a bucket merged from several batches counts once per batch"))
+	j.duration, _ = meter.Float64Histogram("retention_run_duration",
+		metric.WithUnit("s"),
+		metric.WithDescription("Duration of retention runs"))
+}
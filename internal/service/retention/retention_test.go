@@ -0,0 +1,99 @@
+package retention
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/cawa87/garantex-test/internal/lib/logger/sl"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeStore struct {
+	locked bool
+	calls  []string
+}
+
+func (s *fakeStore) WithAdvisoryLock(ctx context.Context, _ int64, fn func(ctx context.Context) error) (bool, error) {
+	if s.locked {
+		return false, nil
+	}
+	return true, fn(ctx)
+}
+
+func (s *fakeStore) DownsampleRates(context.Context, time.Time, int) (int64, int64, error) {
+	s.calls = append(s.calls, "downsample")
+	return 3, 120, nil
+}
+
+func (s *fakeStore) RollupAggregates(context.Context, time.Time, int) (int64, int64, error) {
+	s.calls = append(s.calls, "rollup")
+	return 1, 60, nil
+}
+
+func (s *fakeStore) DeleteAggregatesBefore(_ context.Context, resolution Resolution, _ time.Time, _ int) (int64, error) {
+	s.calls = append(s.calls, "delete "+string(resolution))
+	return 60, nil
+}
+
+func (s *fakeStore) CountRatesBefore(context.Context, time.Time) (int64, error) {
+	s.calls = append(s.calls, "count rates")
+	return 120, nil
+}
+
+func (s *fakeStore) CountAggregatesBefore(_ context.Context, resolution Resolution, _ time.Time) (int64, error) {
+	s.calls = append(s.calls, "count "+string(resolution))
+	return 60, nil
+}
+
+func TestPolicyValidate(t *testing.T) {
+	assert.NoError(t, Policy{Raw: 24 * time.Hour, Minute: 48 * time.Hour}.Validate())
+	assert.NoError(t, Policy{}.Validate())
+	assert.Error(t, Policy{Raw: 48 * time.Hour, Minute: 24 * time.Hour}.Validate())
+	assert.Error(t, Policy{Raw: 24 * time.Hour, Minute: 48 * time.Hour, Hour: 36 * time.Hour}.Validate())
+	assert.Error(t, Policy{Minute: 48 * time.Hour}.Validate())
+}
+
+func TestRunOnce(t *testing.T) {
+	logger, err := sl.New("info")
+	require.NoError(t, err)
+
+	policy := Policy{Raw: 24 * time.Hour, Minute: 48 * time.Hour, Hour: 96 * time.Hour}
+
+	t.Run("moves expired rows into buckets", func(t *testing.T) {
+		store := &fakeStore{}
+		job, err := NewJob(store, policy, time.Hour, 100, false, logger)
+		require.NoError(t, err)
+
+		report, err := job.RunOnce(context.Background())
+		require.NoError(t, err)
+		assert.Equal(t, []string{"downsample", "rollup", "delete 1h"}, store.calls)
+		assert.Equal(t, int64(3), report.Downsampled[Minute])
+		assert.Equal(t, int64(120), report.Pruned["rates"])
+		assert.Equal(t, int64(1), report.Downsampled[Hour])
+		assert.Equal(t, int64(60), report.Pruned["rate_aggregates_1m"])
+	})
+
+	t.Run("dry run only counts", func(t *testing.T) {
+		store := &fakeStore{}
+		job, err := NewJob(store, policy, time.Hour, 100, true, logger)
+		require.NoError(t, err)
+
+		report, err := job.RunOnce(context.Background())
+		require.NoError(t, err)
+		assert.Equal(t, []string{"count rates", "count 1m", "count 1h"}, store.calls)
+		assert.True(t, report.DryRun)
+	})
+
+	t.Run("skips when lock is held", func(t *testing.T) {
+		store := &fakeStore{locked: true}
+		job, err := NewJob(store, policy, time.Hour, 100, false, logger)
+		require.NoError(t, err)
+
+		report, err := job.RunOnce(context.Background())
+		require.NoError(t, err)
+		assert.Nil(t, report)
+		assert.Empty(t, store.calls)
+	})
+}
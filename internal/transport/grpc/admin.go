@@ -0,0 +1,77 @@
+package grpc
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/cawa87/garantex-test/internal/service/auth"
+	"go.opentelemetry.io/otel/attribute"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	pb "github.com/cawa87/garantex-test/gen/go/rate_service.v1"
+)
+
+func (s *Server) CreateAPIKey(ctx context.Context, req *pb.CreateAPIKeyRequest) (*pb.CreateAPIKeyResponse, error) {
+	ctx, span := s.startSpan(ctx, "CreateAPIKey")
+	defer span.End()
+
+	if s.authenticator == nil {
+		return nil, status.Error(codes.FailedPrecondition, "authentication is disabled")
+	}
+
+	createdBy := ""
+	if principal, ok := auth.PrincipalFromContext(ctx); ok {
+		createdBy = principal.Identity()
+	}
+
+	key, plaintext, err := auth.GenerateAPIKey(req.Name, req.Scopes, createdBy)
+	if err != nil {
+		span.RecordError(err)
+		return nil, status.Errorf(codes.Internal, "failed to generate API key: %v", err)
+	}
+
+	if err := s.repo.CreateAPIKey(ctx, key); err != nil {
+		span.RecordError(err)
+		s.log(ctx).Error("Failed to create API key", "error", err)
+		return nil, status.Errorf(codes.Internal, "failed to create API key: %v", err)
+	}
+
+	span.SetAttributes(attribute.String("api_key.id", key.ID))
+
+	return &pb.CreateAPIKeyResponse{
+		Id:        key.ID,
+		Key:       plaintext,
+		Scopes:    key.Scopes,
+		CreatedAt: timestamppb.New(key.CreatedAt),
+	}, nil
+}
+
+func (s *Server) RevokeAPIKey(ctx context.Context, req *pb.RevokeAPIKeyRequest) (*pb.RevokeAPIKeyResponse, error) {
+	ctx, span := s.startSpan(ctx, "RevokeAPIKey")
+	defer span.End()
+
+	if s.authenticator == nil {
+		return nil, status.Error(codes.FailedPrecondition, "authentication is disabled")
+	}
+
+	span.SetAttributes(attribute.String("api_key.id", req.Id))
+
+	revokedAt, err := s.repo.RevokeAPIKey(ctx, req.Id)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, status.Errorf(codes.NotFound, "active API key %q not found", req.Id)
+		}
+		span.RecordError(err)
+		s.log(ctx).Error("Failed to revoke API key", "error", err)
+		return nil, status.Errorf(codes.Internal, "failed to revoke API key: %v", err)
+	}
+
+	s.authenticator.InvalidateKey(req.Id)
+
+	return &pb.RevokeAPIKeyResponse{
+		RevokedAt: timestamppb.New(revokedAt),
+	}, nil
+}
@@ -0,0 +1,226 @@
+package grpc
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/cawa87/garantex-test/internal/service/auth"
+	"golang.org/x/time/rate"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/durationpb"
+)
+
+// WithAuth enables authentication and method-level authorization
+func WithAuth(authenticator *auth.Authenticator, policy *auth.Policy) Option {
+	return func(s *Server) {
+		s.authenticator = authenticator
+		s.policy = policy
+	}
+}
+
+// authInterceptor authenticates unary calls and stores the principal in the context
+func (s *Server) authInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		ctx, err := s.authenticate(ctx, info.FullMethod)
+		if err != nil {
+			return nil, err
+		}
+		return handler(ctx, req)
+	}
+}
+
+// authStreamInterceptor authenticates stream calls and stores the principal in the stream context
+func (s *Server) authStreamInterceptor() grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		ctx, err := s.authenticate(ss.Context(), info.FullMethod)
+		if err != nil {
+			return err
+		}
+		return handler(srv, &contextStream{ServerStream: ss, ctx: ctx})
+	}
+}
+
+func (s *Server) authenticate(ctx context.Context, method string) (context.Context, error) {
+	if s.authenticator == nil {
+		return ctx, nil
+	}
+
+	// Before authentication the identity is the peer's, which made-up credentials cannot change
+	caller := clientIdentity(ctx)
+	if retryAfter, blocked := s.authFailures.blocked(caller); blocked {
+		s.log(ctx).Warn("gRPC authentication throttled",
+			"method", method,
+			"client", caller)
+		return nil, authThrottled(retryAfter)
+	}
+
+	principal, err := s.authenticator.Authenticate(ctx, credentialsFromContext(ctx))
+	if err != nil {
+		if errors.Is(err, auth.ErrUnauthenticated) {
+			s.authFailures.failed(caller)
+		}
+		s.log(ctx).Warn("gRPC authentication failed",
+			"method", method,
+			"client", caller,
+			"error", err)
+		return nil, authStatus(err)
+	}
+
+	if err := s.policy.Authorize(method, principal); err != nil {
+		s.log(ctx).Warn("gRPC authorization failed",
+			"method", method,
+			"client", caller,
+			"error", err)
+		return nil, authStatus(err)
+	}
+
+	if principal == nil {
+		return ctx, nil
+	}
+	return auth.WithPrincipal(ctx, principal), nil
+}
+
+// Failed authentications are limited per peer, checked before the credentials are verified, so made-up
+// API keys cannot become an unthrottled stream of key lookups
+const (
+	authFailureRate  = rate.Limit(1)
+	authFailureBurst = 10
+	// authFailureIdle is how long a peer's failures are remembered; by then its attempts have refilled
+	authFailureIdle = time.Minute
+)
+
+// authFailureLimiter tracks failed authentications per peer
+type authFailureLimiter struct {
+	mu        sync.Mutex
+	peers     map[string]*authFailures
+	lastSweep time.Time
+}
+
+type authFailures struct {
+	limiter  *rate.Limiter
+	lastSeen time.Time
+}
+
+func newAuthFailureLimiter() *authFailureLimiter {
+	return &authFailureLimiter{peers: make(map[string]*authFailures)}
+}
+
+// blocked reports whether peer has used up its failed attempts and how long until it may try again
+func (l *authFailureLimiter) blocked(peer string) (time.Duration, bool) {
+	now := time.Now()
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	f, ok := l.peers[peer]
+	if !ok {
+		return 0, false
+	}
+	tokens := f.limiter.TokensAt(now)
+	if tokens >= 1 {
+		return 0, false
+	}
+	return time.Duration((1 - tokens) / float64(authFailureRate) * float64(time.Second)), true
+}
+
+// failed records a failed authentication by peer
+func (l *authFailureLimiter) failed(peer string) {
+	now := time.Now()
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.sweep(now)
+
+	f, ok := l.peers[peer]
+	if !ok {
+		f = &authFailures{limiter: rate.NewLimiter(authFailureRate, authFailureBurst)}
+		l.peers[peer] = f
+	}
+	f.limiter.AllowN(now, 1)
+	f.lastSeen = now
+}
+
+// sweep forgets peers that have not failed for authFailureIdle
+func (l *authFailureLimiter) sweep(now time.Time) {
+	if now.Sub(l.lastSweep) < time.Minute {
+		return
+	}
+	l.lastSweep = now
+
+	for peer, f := range l.peers {
+		if now.Sub(f.lastSeen) > authFailureIdle {
+			delete(l.peers, peer)
+		}
+	}
+}
+
+func authThrottled(retryAfter time.Duration) error {
+	if retryAfter <= 0 {
+		retryAfter = time.Second
+	}
+
+	st := status.New(codes.ResourceExhausted, "too many failed authentication attempts")
+	if detailed, err := st.WithDetails(&errdetails.RetryInfo{RetryDelay: durationpb.New(retryAfter)}); err == nil {
+		st = detailed
+	}
+	return st.Err()
+}
+
+// credentialsFromContext extracts an API key or bearer token from request metadata
+// and the subject of a verified client certificate from the connection
+func credentialsFromContext(ctx context.Context) auth.Credentials {
+	var creds auth.Credentials
+
+	if p, ok := peer.FromContext(ctx); ok {
+		if tlsInfo, ok := p.AuthInfo.(credentials.TLSInfo); ok && len(tlsInfo.State.VerifiedChains) > 0 {
+			creds.ClientCertSubject = tlsInfo.State.VerifiedChains[0][0].Subject.CommonName
+		}
+	}
+
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return creds
+	}
+
+	if keys := md.Get(apiKeyHeader); len(keys) > 0 {
+		creds.APIKey = keys[0]
+	}
+	if values := md.Get("authorization"); len(values) > 0 {
+		scheme, token, ok := strings.Cut(values[0], " ")
+		if ok && strings.EqualFold(scheme, "bearer") {
+			creds.BearerToken = strings.TrimSpace(token)
+		}
+	}
+	return creds
+}
+
+func authStatus(err error) error {
+	switch {
+	case errors.Is(err, auth.ErrUnauthenticated):
+		return status.Error(codes.Unauthenticated, err.Error())
+	case errors.Is(err, auth.ErrPermissionDenied):
+		return status.Error(codes.PermissionDenied, err.Error())
+	default:
+		return status.Error(codes.Internal, "authentication failed")
+	}
+}
+
+// contextStream overrides the context of a server stream
+type contextStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *contextStream) Context() context.Context {
+	return s.ctx
+}
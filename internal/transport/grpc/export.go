@@ -0,0 +1,109 @@
+package grpc
+
+import (
+	"github.com/cawa87/garantex-test/internal/service/exchange"
+	"github.com/cawa87/garantex-test/internal/service/export"
+	"go.opentelemetry.io/otel/attribute"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	pb "github.com/cawa87/garantex-test/gen/go/rate_service.v1"
+)
+
+// exportChunkSize is the amount of encoded data sent per ExportChunk
+const exportChunkSize = 64 * 1024
+
+func (s *Server) ExportRates(req *pb.ExportRatesRequest, stream pb.RateService_ExportRatesServer) error {
+	ctx, span := s.startSpan(stream.Context(), "ExportRates")
+	defer span.End()
+
+	market := req.Market
+	if market == "" {
+		market = exchange.DefaultMarket
+	}
+	query := export.Query{
+		Market:   market,
+		From:     req.From.AsTime(),
+		To:       req.To.AsTime(),
+		Interval: req.Interval.AsDuration(),
+		Format:   exportFormat(req.Format),
+	}
+	if err := query.Validate(); err != nil {
+		return status.Errorf(codes.InvalidArgument, "%v", err)
+	}
+
+	span.SetAttributes(
+		attribute.String("market", market),
+		attribute.String("format", string(query.Format)),
+		attribute.String("interval", query.Interval.String()),
+	)
+
+	w := &chunkWriter{stream: stream, buf: make([]byte, 0, exportChunkSize)}
+	rows, err := export.Write(ctx, s.repo, w, query)
+	if err == nil {
+		err = w.Flush()
+	}
+	if err != nil {
+		if ctx.Err() != nil {
+			return status.FromContextError(ctx.Err()).Err()
+		}
+		span.RecordError(err)
+		s.log(ctx).Error("Failed to export rates", "market", market, "rows", rows, "error", err)
+		return status.Errorf(codes.Internal, "failed to export rates: %v", err)
+	}
+
+	span.SetAttributes(
+		attribute.Int64("rows", rows),
+		attribute.Int64("bytes", w.sent),
+	)
+	return nil
+}
+
+func exportFormat(format pb.ExportFormat) export.Format {
+	switch format {
+	case pb.ExportFormat_EXPORT_FORMAT_NDJSON:
+		return export.FormatNDJSON
+	case pb.ExportFormat_EXPORT_FORMAT_PARQUET:
+		return export.FormatParquet
+	default:
+		return export.FormatCSV
+	}
+}
+
+// chunkWriter sends written data as ExportChunk messages of up to exportChunkSize bytes
+type chunkWriter struct {
+	stream pb.RateService_ExportRatesServer
+	buf    []byte
+	sent   int64
+}
+
+func (w *chunkWriter) Write(p []byte) (int, error) {
+	n := len(p)
+	for len(p) > 0 {
+		free := cap(w.buf) - len(w.buf)
+		if free > len(p) {
+			free = len(p)
+		}
+		w.buf = append(w.buf, p[:free]...)
+		p = p[free:]
+		if len(w.buf) == cap(w.buf) {
+			if err := w.Flush(); err != nil {
+				return 0, err
+			}
+		}
+	}
+	return n, nil
+}
+
+// Flush sends the buffered data
+func (w *chunkWriter) Flush() error {
+	if len(w.buf) == 0 {
+		return nil
+	}
+	if err := w.stream.Send(&pb.ExportChunk{Data: w.buf}); err != nil {
+		return err
+	}
+	w.sent += int64(len(w.buf))
+	w.buf = make([]byte, 0, exportChunkSize)
+	return nil
+}
@@ -0,0 +1,111 @@
+package grpc
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/cawa87/garantex-test/internal/service/fixing"
+	"go.opentelemetry.io/otel/attribute"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	pb "github.com/cawa87/garantex-test/gen/go/rate_service.v1"
+)
+
+// defaultFixingsLimit is the ListFixings page size when the request sets none
+const defaultFixingsLimit = 100
+
+func (s *Server) GetFixing(ctx context.Context, req *pb.GetFixingRequest) (*pb.Fixing, error) {
+	ctx, span := s.startSpan(ctx, "GetFixing")
+	defer span.End()
+
+	span.SetAttributes(
+		attribute.String("fixing.name", req.Name),
+		attribute.String("fixing.date", req.Date),
+	)
+
+	date, err := parseDate(req.Date)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid date: %v", err)
+	}
+
+	f, err := s.repo.GetFixing(ctx, req.Name, date)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, status.Errorf(codes.NotFound, "fixing %q on %s not found", req.Name, req.Date)
+		}
+		span.RecordError(err)
+		s.log(ctx).Error("Failed to get fixing", "name", req.Name, "date", req.Date, "error", err)
+		return nil, status.Errorf(codes.Internal, "failed to get fixing: %v", err)
+	}
+
+	return toFixing(f), nil
+}
+
+func (s *Server) ListFixings(ctx context.Context, req *pb.ListFixingsRequest) (*pb.ListFixingsResponse, error) {
+	ctx, span := s.startSpan(ctx, "ListFixings")
+	defer span.End()
+
+	filter := fixing.ListFilter{
+		Name:   req.Name,
+		Market: req.Market,
+		Limit:  int(req.Limit),
+	}
+	if filter.Limit == 0 {
+		filter.Limit = defaultFixingsLimit
+	}
+
+	var err error
+	if req.FromDate != "" {
+		if filter.From, err = parseDate(req.FromDate); err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "invalid from_date: %v", err)
+		}
+	}
+	if req.ToDate != "" {
+		if filter.To, err = parseDate(req.ToDate); err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "invalid to_date: %v", err)
+		}
+	}
+
+	fixings, err := s.repo.ListFixings(ctx, filter)
+	if err != nil {
+		span.RecordError(err)
+		s.log(ctx).Error("Failed to list fixings", "error", err)
+		return nil, status.Errorf(codes.Internal, "failed to list fixings: %v", err)
+	}
+
+	response := &pb.ListFixingsResponse{
+		Fixings: make([]*pb.Fixing, len(fixings)),
+	}
+	for i, f := range fixings {
+		response.Fixings[i] = toFixing(f)
+	}
+
+	span.SetAttributes(attribute.Int("fixings", len(fixings)))
+	return response, nil
+}
+
+func parseDate(s string) (time.Time, error) {
+	return time.Parse(time.DateOnly, s)
+}
+
+// toFixing converts a fixing to its proto representation
+func toFixing(f *fixing.Fixing) *pb.Fixing {
+	return &pb.Fixing{
+		Name:        f.Name,
+		Market:      f.Market,
+		Date:        f.Date.Format(time.DateOnly),
+		Method:      string(f.Method),
+		Methodology: f.Methodology,
+		WindowStart: timestamppb.New(f.WindowStart),
+		WindowEnd:   timestamppb.New(f.WindowEnd),
+		AskPrice:    toDecimal(f.Ask),
+		BidPrice:    toDecimal(f.Bid),
+		MidPrice:    toDecimal(f.Mid),
+		SampleCount: int32(f.SampleCount),
+		ComputedAt:  timestamppb.New(f.ComputedAt),
+	}
+}
@@ -0,0 +1,120 @@
+package grpc
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/cawa87/garantex-test/internal/repository"
+	"github.com/cawa87/garantex-test/internal/service/exchange"
+	"github.com/cawa87/garantex-test/internal/service/history"
+	"go.opentelemetry.io/otel/attribute"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	pb "github.com/cawa87/garantex-test/gen/go/rate_service.v1"
+)
+
+// WithLookupTolerance rejects GetRateAt lookups whose samples are further than d from the requested time;
+// zero accepts samples at any distance
+func WithLookupTolerance(d time.Duration) Option {
+	return func(s *Server) {
+		s.lookupTolerance = d
+	}
+}
+
+func (s *Server) GetRateAt(ctx context.Context, req *pb.GetRateAtRequest) (*pb.GetRateAtResponse, error) {
+	ctx, span := s.startSpan(ctx, "GetRateAt")
+	defer span.End()
+
+	market := req.Market
+	if market == "" {
+		market = exchange.DefaultMarket
+	}
+	at := req.Timestamp.AsTime()
+	mode := lookupMode(req.Mode)
+
+	span.SetAttributes(
+		attribute.String("market", market),
+		attribute.String("mode", mode.String()),
+	)
+
+	before, after, err := s.repo.GetRatesAround(ctx, market, at)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		span.RecordError(err)
+		s.log(ctx).Error("Failed to look up rates", "market", market, "timestamp", at, "error", err)
+		return nil, status.Errorf(codes.Internal, "failed to look up rates: %v", err)
+	}
+
+	result, err := history.Resolve(at, toSample(before), toSample(after), mode, s.lookupTolerance)
+	if err != nil {
+		switch {
+		case errors.Is(err, history.ErrNoSample):
+			return nil, status.Errorf(codes.NotFound, "market %q: %v", market, err)
+		case errors.Is(err, history.ErrOutOfTolerance):
+			return nil, status.Errorf(codes.OutOfRange, "market %q: %v", market, err)
+		default:
+			return nil, status.Errorf(codes.InvalidArgument, "%v", err)
+		}
+	}
+
+	samples := make([]*pb.RateSample, len(result.Samples))
+	for i, sample := range result.Samples {
+		samples[i] = &pb.RateSample{
+			Id:        sample.ID,
+			AskPrice:  toDecimal(sample.Ask),
+			BidPrice:  toDecimal(sample.Bid),
+			Timestamp: timestamppb.New(sample.Timestamp),
+		}
+	}
+
+	span.SetAttributes(attribute.String("distance", result.Distance.String()))
+
+	return &pb.GetRateAtResponse{
+		Market:    market,
+		Timestamp: req.Timestamp,
+		AskPrice:  toDecimal(result.Ask),
+		BidPrice:  toDecimal(result.Bid),
+		Samples:   samples,
+		Distance:  durationpb.New(result.Distance),
+		Mode:      fromLookupMode(mode),
+	}, nil
+}
+
+// lookupMode converts the proto lookup mode; unspecified means previous
+func lookupMode(mode pb.LookupMode) history.Mode {
+	switch mode {
+	case pb.LookupMode_LOOKUP_MODE_NEAREST:
+		return history.ModeNearest
+	case pb.LookupMode_LOOKUP_MODE_INTERPOLATED:
+		return history.ModeInterpolated
+	default:
+		return history.ModePrevious
+	}
+}
+
+func fromLookupMode(mode history.Mode) pb.LookupMode {
+	switch mode {
+	case history.ModeNearest:
+		return pb.LookupMode_LOOKUP_MODE_NEAREST
+	case history.ModeInterpolated:
+		return pb.LookupMode_LOOKUP_MODE_INTERPOLATED
+	default:
+		return pb.LookupMode_LOOKUP_MODE_PREVIOUS
+	}
+}
+
+func toSample(rate *repository.Rate) *history.Sample {
+	if rate == nil {
+		return nil
+	}
+	return &history.Sample{
+		ID:        rate.ID,
+		Ask:       rate.Ask,
+		Bid:       rate.Bid,
+		Timestamp: rate.Timestamp,
+	}
+}
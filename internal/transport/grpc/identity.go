@@ -0,0 +1,42 @@
+package grpc
+
+import (
+	"context"
+	"net"
+
+	"github.com/cawa87/garantex-test/internal/service/auth"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/peer"
+)
+
+// apiKeyHeader is the metadata key clients use to present an API key
+const apiKeyHeader = "x-api-key"
+
+// clientIdentity derives a stable identity for the caller, preferring the authenticated principal,
+// then the mTLS certificate common name, then the peer IP address. An API key counts only once the
+// authenticator has verified it, so callers cannot get fresh buckets by sending made-up keys
+func clientIdentity(ctx context.Context) string {
+	if principal, ok := auth.PrincipalFromContext(ctx); ok {
+		return principal.Identity()
+	}
+
+	p, ok := peer.FromContext(ctx)
+	if !ok {
+		return "unknown"
+	}
+
+	if tlsInfo, ok := p.AuthInfo.(credentials.TLSInfo); ok {
+		if certs := tlsInfo.State.PeerCertificates; len(certs) > 0 && certs[0].Subject.CommonName != "" {
+			return "cn:" + certs[0].Subject.CommonName
+		}
+	}
+
+	if p.Addr == nil {
+		return "unknown"
+	}
+	host, _, err := net.SplitHostPort(p.Addr.String())
+	if err != nil {
+		return "ip:" + p.Addr.String()
+	}
+	return "ip:" + host
+}
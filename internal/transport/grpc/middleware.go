@@ -0,0 +1,187 @@
+package grpc
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"runtime/debug"
+
+	"github.com/cawa87/garantex-test/internal/lib/logger/sl"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// requestIDHeader is the metadata key carrying the request ID in both directions
+const requestIDHeader = "x-request-id"
+
+// maxRequestIDLength bounds client-supplied request IDs so they cannot bloat logs
+const maxRequestIDLength = 128
+
+type requestIDKey struct{}
+
+// RequestIDFromContext returns the request ID assigned by the request ID interceptor
+func RequestIDFromContext(ctx context.Context) (string, bool) {
+	id, ok := ctx.Value(requestIDKey{}).(string)
+	return id, ok
+}
+
+// log returns the request-scoped logger, falling back to the server logger
+func (s *Server) log(ctx context.Context) *sl.Logger {
+	return sl.FromContext(ctx, s.logger)
+}
+
+// recoveryInterceptor converts handler panics into codes.Internal and logs the stack trace
+func (s *Server) recoveryInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (resp interface{}, err error) {
+		defer func() {
+			if r := recover(); r != nil {
+				err = s.recovered(ctx, info.FullMethod, r)
+			}
+		}()
+		return handler(ctx, req)
+	}
+}
+
+// recoveryStreamInterceptor converts stream handler panics into codes.Internal and logs the stack trace
+func (s *Server) recoveryStreamInterceptor() grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) (err error) {
+		defer func() {
+			if r := recover(); r != nil {
+				err = s.recovered(ss.Context(), info.FullMethod, r)
+			}
+		}()
+		return handler(srv, ss)
+	}
+}
+
+func (s *Server) recovered(ctx context.Context, method string, r interface{}) error {
+	s.log(ctx).Error("gRPC handler panicked",
+		"method", method,
+		"panic", fmt.Sprintf("%v", r),
+		"stack", string(debug.Stack()))
+	return status.Error(codes.Internal, "internal error")
+}
+
+// requestIDInterceptor takes the request ID from metadata or generates one, echoes it
+// in the response trailer and attaches a logger carrying it to the context
+func (s *Server) requestIDInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		ctx, id := s.withRequestID(ctx)
+		_ = grpc.SetTrailer(ctx, metadata.Pairs(requestIDHeader, id))
+		return handler(ctx, req)
+	}
+}
+
+// requestIDStreamInterceptor is the stream counterpart of requestIDInterceptor
+func (s *Server) requestIDStreamInterceptor() grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		ctx, id := s.withRequestID(ss.Context())
+		ss.SetTrailer(metadata.Pairs(requestIDHeader, id))
+		return handler(srv, &contextStream{ServerStream: ss, ctx: ctx})
+	}
+}
+
+func (s *Server) withRequestID(ctx context.Context) (context.Context, string) {
+	var id string
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		if values := md.Get(requestIDHeader); len(values) > 0 && len(values[0]) <= maxRequestIDLength {
+			id = values[0]
+		}
+	}
+	if id == "" {
+		id = newRequestID()
+	}
+
+	ctx = context.WithValue(ctx, requestIDKey{}, id)
+	ctx = sl.WithContext(ctx, s.logger.With("request_id", id))
+	return ctx, id
+}
+
+func newRequestID() string {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "unknown"
+	}
+	return hex.EncodeToString(b)
+}
+
+// validator is implemented by messages generated with protoc-gen-validate
+type validator interface {
+	ValidateAll() error
+}
+
+// validationInterceptor rejects requests that violate their proto validation rules
+func (s *Server) validationInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		if err := validateMessage(req); err != nil {
+			return nil, err
+		}
+		return handler(ctx, req)
+	}
+}
+
+// validationStreamInterceptor validates every message received on a stream
+func (s *Server) validationStreamInterceptor() grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		return handler(srv, &validatingStream{ServerStream: ss})
+	}
+}
+
+type validatingStream struct {
+	grpc.ServerStream
+}
+
+func (s *validatingStream) RecvMsg(m interface{}) error {
+	if err := s.ServerStream.RecvMsg(m); err != nil {
+		return err
+	}
+	return validateMessage(m)
+}
+
+// fieldError is implemented by protoc-gen-validate field errors
+type fieldError interface {
+	Field() string
+	Reason() string
+}
+
+// validateMessage returns codes.InvalidArgument with BadRequest details if msg violates its rules
+func validateMessage(msg interface{}) error {
+	v, ok := msg.(validator)
+	if !ok {
+		return nil
+	}
+
+	err := v.ValidateAll()
+	if err == nil {
+		return nil
+	}
+
+	var errs []error
+	if multi, ok := err.(interface{ AllErrors() []error }); ok {
+		errs = multi.AllErrors()
+	} else {
+		errs = []error{err}
+	}
+
+	badRequest := &errdetails.BadRequest{}
+	for _, e := range errs {
+		var fe fieldError
+		if errors.As(e, &fe) {
+			badRequest.FieldViolations = append(badRequest.FieldViolations, &errdetails.BadRequest_FieldViolation{
+				Field:       fe.Field(),
+				Description: fe.Reason(),
+			})
+		}
+	}
+
+	st := status.New(codes.InvalidArgument, err.Error())
+	if detailed, detailErr := st.WithDetails(badRequest); detailErr == nil {
+		st = detailed
+	}
+	return st.Err()
+}
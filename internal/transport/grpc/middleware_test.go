@@ -0,0 +1,118 @@
+package grpc
+
+import (
+	"context"
+	"testing"
+
+	"github.com/cawa87/garantex-test/internal/lib/logger/sl"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	pb "github.com/cawa87/garantex-test/gen/go/rate_service.v1"
+)
+
+func newTestServer(t *testing.T) *Server {
+	logger, err := sl.New("info")
+	require.NoError(t, err)
+
+	return NewServer(nil, nil, logger)
+}
+
+func TestRecoveryInterceptor(t *testing.T) {
+	s := newTestServer(t)
+	info := &grpc.UnaryServerInfo{FullMethod: "/rate_service.v1.RateService/GetRates"}
+
+	resp, err := s.recoveryInterceptor()(context.Background(), nil, info, func(ctx context.Context, req interface{}) (interface{}, error) {
+		panic("boom")
+	})
+
+	assert.Nil(t, resp)
+	assert.Equal(t, codes.Internal, status.Code(err))
+}
+
+// chainUnary composes interceptors around handler the way grpc.ChainUnaryInterceptor does
+func chainUnary(interceptors []grpc.UnaryServerInterceptor, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) grpc.UnaryHandler {
+	for i := len(interceptors) - 1; i >= 0; i-- {
+		interceptor, next := interceptors[i], handler
+		handler = func(ctx context.Context, req interface{}) (interface{}, error) {
+			return interceptor(ctx, req, info, next)
+		}
+	}
+	return handler
+}
+
+func TestInterceptors_PanicLogCarriesRequestID(t *testing.T) {
+	core, logs := observer.New(zapcore.ErrorLevel)
+	s := NewServer(nil, nil, &sl.Logger{Logger: zap.New(core)})
+	info := &grpc.UnaryServerInfo{FullMethod: "/rate_service.v1.RateService/GetRates"}
+
+	handler := chainUnary(s.unaryInterceptors(), info, func(ctx context.Context, req interface{}) (interface{}, error) {
+		panic("boom")
+	})
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs(requestIDHeader, "req-123"))
+	_, err := handler(ctx, nil)
+	assert.Equal(t, codes.Internal, status.Code(err))
+
+	panics := logs.FilterMessage("gRPC handler panicked").All()
+	require.Len(t, panics, 1)
+	assert.Equal(t, "req-123", panics[0].ContextMap()["request_id"])
+}
+
+func TestRequestIDInterceptor(t *testing.T) {
+	s := newTestServer(t)
+	info := &grpc.UnaryServerInfo{FullMethod: "/rate_service.v1.RateService/GetRates"}
+
+	var seen string
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		seen, _ = RequestIDFromContext(ctx)
+		assert.NotSame(t, s.logger, s.log(ctx))
+		return nil, nil
+	}
+
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs(requestIDHeader, "req-123"))
+	_, err := s.requestIDInterceptor()(ctx, nil, info, handler)
+	require.NoError(t, err)
+	assert.Equal(t, "req-123", seen)
+
+	_, err = s.requestIDInterceptor()(context.Background(), nil, info, handler)
+	require.NoError(t, err)
+	assert.Len(t, seen, 32)
+}
+
+func TestValidationInterceptor(t *testing.T) {
+	s := newTestServer(t)
+	info := &grpc.UnaryServerInfo{FullMethod: "/rate_service.v1.RateService/CreateAPIKey"}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return "ok", nil
+	}
+
+	resp, err := s.validationInterceptor()(context.Background(), &pb.CreateAPIKeyRequest{
+		Name:   "reporting",
+		Scopes: []string{"rates:read"},
+	}, info, handler)
+	require.NoError(t, err)
+	assert.Equal(t, "ok", resp)
+
+	_, err = s.validationInterceptor()(context.Background(), &pb.CreateAPIKeyRequest{
+		Scopes: []string{"rates:write"},
+	}, info, handler)
+	st := status.Convert(err)
+	assert.Equal(t, codes.InvalidArgument, st.Code())
+	require.Len(t, st.Details(), 1)
+
+	badRequest, ok := st.Details()[0].(*errdetails.BadRequest)
+	require.True(t, ok)
+	fields := make([]string, 0, len(badRequest.FieldViolations))
+	for _, v := range badRequest.FieldViolations {
+		fields = append(fields, v.Field)
+	}
+	assert.ElementsMatch(t, []string{"Name", "Scopes[0]"}, fields)
+}
@@ -0,0 +1,75 @@
+package grpc
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/cawa87/garantex-test/internal/service/exchange"
+	"go.opentelemetry.io/otel/attribute"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	pb "github.com/cawa87/garantex-test/gen/go/rate_service.v1"
+)
+
+// WithOrderBooks stores an order book snapshot limited to depth levels per side with every rate;
+// depth <= 0 stores the full book
+func WithOrderBooks(depth int) Option {
+	return func(s *Server) {
+		s.orderBooks = true
+		s.orderBookDepth = depth
+	}
+}
+
+func (s *Server) GetOrderBook(ctx context.Context, req *pb.GetOrderBookRequest) (*pb.GetOrderBookResponse, error) {
+	ctx, span := s.startSpan(ctx, "GetOrderBook")
+	defer span.End()
+
+	market := req.Market
+	if market == "" {
+		market = exchange.DefaultMarket
+	}
+	span.SetAttributes(attribute.String("market", market))
+
+	var asOf time.Time
+	if req.AsOf != nil {
+		asOf = req.AsOf.AsTime()
+	}
+
+	book, err := s.repo.GetOrderBook(ctx, market, asOf)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, status.Errorf(codes.NotFound, "no order book snapshot for market %q", market)
+		}
+		span.RecordError(err)
+		s.log(ctx).Error("Failed to get order book", "market", market, "error", err)
+		return nil, status.Errorf(codes.Internal, "failed to get order book: %v", err)
+	}
+
+	response := &pb.GetOrderBookResponse{
+		Market:    book.Market,
+		Timestamp: timestamppb.New(book.Timestamp),
+		Asks:      toLevels(book.Asks),
+		Bids:      toLevels(book.Bids),
+	}
+	if book.RateID != nil {
+		response.RateId = *book.RateID
+	}
+
+	return response, nil
+}
+
+// toLevels converts order book levels to their proto representation
+func toLevels(levels []exchange.PriceLevel) []*pb.OrderBookLevel {
+	out := make([]*pb.OrderBookLevel, len(levels))
+	for i, level := range levels {
+		out[i] = &pb.OrderBookLevel{
+			Price:  toDecimal(level.Price),
+			Volume: toDecimal(level.Volume),
+		}
+	}
+	return out
+}
@@ -0,0 +1,97 @@
+package grpc
+
+import (
+	"context"
+	"time"
+
+	"github.com/cawa87/garantex-test/internal/service/exchange"
+	"github.com/cawa87/garantex-test/internal/service/quality"
+	"go.opentelemetry.io/otel/attribute"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	pb "github.com/cawa87/garantex-test/gen/go/rate_service.v1"
+)
+
+// defaultQualityIssuesLimit is the GetDataQuality page size when the request sets none
+const defaultQualityIssuesLimit = 100
+
+// QualityReporter reports the stored data quality issues of a market
+type QualityReporter interface {
+	Report(ctx context.Context, market string, from, to time.Time, kind quality.Kind, limit int) (*quality.Report, error)
+}
+
+// WithDataQuality serves GetDataQuality from the reporter; without it the method is unavailable
+func WithDataQuality(reporter QualityReporter) Option {
+	return func(s *Server) {
+		s.quality = reporter
+	}
+}
+
+func (s *Server) GetDataQuality(ctx context.Context, req *pb.GetDataQualityRequest) (*pb.GetDataQualityResponse, error) {
+	ctx, span := s.startSpan(ctx, "GetDataQuality")
+	defer span.End()
+
+	if s.quality == nil {
+		return nil, status.Error(codes.FailedPrecondition, "data quality checks are disabled")
+	}
+
+	market := req.Market
+	if market == "" {
+		market = exchange.DefaultMarket
+	}
+	from, to := req.From.AsTime(), req.To.AsTime()
+	if !from.Before(to) {
+		return nil, status.Errorf(codes.InvalidArgument, "from %s is not before to %s", from, to)
+	}
+
+	var kind quality.Kind
+	if req.Kind != "" {
+		var err error
+		if kind, err = quality.ParseKind(req.Kind); err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "%v", err)
+		}
+	}
+	limit := int(req.Limit)
+	if limit == 0 {
+		limit = defaultQualityIssuesLimit
+	}
+
+	span.SetAttributes(
+		attribute.String("market", market),
+		attribute.String("kind", string(kind)),
+	)
+
+	report, err := s.quality.Report(ctx, market, from, to, kind, limit)
+	if err != nil {
+		span.RecordError(err)
+		s.log(ctx).Error("Failed to report data quality", "market", market, "error", err)
+		return nil, status.Errorf(codes.Internal, "failed to report data quality: %v", err)
+	}
+
+	response := &pb.GetDataQualityResponse{
+		Market:       market,
+		Issues:       make([]*pb.DataQualityIssue, len(report.Issues)),
+		Missing:      durationpb.New(report.Missing),
+		Completeness: report.Completeness,
+	}
+	for i, issue := range report.Issues {
+		response.Issues[i] = &pb.DataQualityIssue{
+			Market:     issue.Market,
+			Kind:       string(issue.Kind),
+			Start:      timestamppb.New(issue.Start),
+			End:        timestamppb.New(issue.End),
+			Samples:    int32(issue.Samples),
+			Detail:     issue.Detail,
+			DetectedAt: timestamppb.New(issue.DetectedAt),
+		}
+	}
+
+	span.SetAttributes(
+		attribute.Int("issues", len(report.Issues)),
+		attribute.Float64("completeness", report.Completeness),
+	)
+	return response, nil
+}
@@ -0,0 +1,212 @@
+package grpc
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+	"golang.org/x/time/rate"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/durationpb"
+)
+
+// idleBucketTTL is how long an unused client bucket is kept; it spans a full quota day so idling cannot reset usage
+const idleBucketTTL = 24 * time.Hour
+
+// RateLimitTier describes the request rate and daily quota granted to a class of clients
+type RateLimitTier struct {
+	RPS        float64
+	Burst      int
+	DailyQuota int64
+}
+
+// RateLimitPolicy maps client identities to tiers; unknown clients get DefaultTier
+type RateLimitPolicy struct {
+	DefaultTier string
+	Tiers       map[string]RateLimitTier
+	Clients     map[string]string
+}
+
+// clientBucket tracks the token bucket and daily usage of a single client
+type clientBucket struct {
+	tier     RateLimitTier
+	limiter  *rate.Limiter
+	day      time.Time
+	used     int64
+	lastSeen time.Time
+}
+
+// clientLimiter enforces a RateLimitPolicy per client identity
+type clientLimiter struct {
+	mu        sync.Mutex
+	policy    RateLimitPolicy
+	buckets   map[string]*clientBucket
+	lastSweep time.Time
+
+	rejected metric.Int64Counter
+}
+
+func newClientLimiter(policy RateLimitPolicy) *clientLimiter {
+	rejected, _ := otel.Meter("grpc").Int64Counter("grpc_client_rate_limited",
+		metric.WithDescription("Requests rejected by per-client rate limits and quotas"))
+
+	return &clientLimiter{
+		policy:   policy,
+		buckets:  make(map[string]*clientBucket),
+		rejected: rejected,
+	}
+}
+
+// update swaps the policy; token buckets of clients whose tier changed are rebuilt on next use,
+// while daily usage is preserved
+func (l *clientLimiter) update(policy RateLimitPolicy) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.policy = policy
+}
+
+// allow checks a request from identity against its tier and returns a status error when it must be rejected
+func (l *clientLimiter) allow(ctx context.Context, identity string) error {
+	now := time.Now()
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.sweep(now)
+
+	tierName := l.policy.Clients[identity]
+	if tierName == "" {
+		tierName = l.policy.DefaultTier
+	}
+	tier, ok := l.policy.Tiers[tierName]
+	if !ok {
+		return nil
+	}
+
+	bucket, ok := l.buckets[identity]
+	if !ok {
+		bucket = &clientBucket{}
+		l.buckets[identity] = bucket
+	}
+	if bucket.limiter == nil || bucket.tier != tier {
+		bucket.tier = tier
+		bucket.limiter = newTierLimiter(tier)
+	}
+	bucket.lastSeen = now
+
+	day := now.UTC().Truncate(24 * time.Hour)
+	if !bucket.day.Equal(day) {
+		bucket.day = day
+		bucket.used = 0
+	}
+
+	if tier.DailyQuota > 0 && bucket.used >= tier.DailyQuota {
+		l.rejected.Add(ctx, 1, metric.WithAttributes(attribute.String("tier", tierName), attribute.String("reason", "quota")))
+		return quotaExceeded(identity, tierName, tier.DailyQuota, day.Add(24*time.Hour).Sub(now))
+	}
+
+	r := bucket.limiter.ReserveN(now, 1)
+	if delay := r.DelayFrom(now); !r.OK() || delay > 0 {
+		r.CancelAt(now)
+		l.rejected.Add(ctx, 1, metric.WithAttributes(attribute.String("tier", tierName), attribute.String("reason", "rate")))
+		return rateExceeded(tierName, delay)
+	}
+
+	bucket.used++
+	return nil
+}
+
+// sweep evicts buckets of clients that have been idle for longer than idleBucketTTL
+func (l *clientLimiter) sweep(now time.Time) {
+	if now.Sub(l.lastSweep) < time.Minute {
+		return
+	}
+	l.lastSweep = now
+
+	for identity, bucket := range l.buckets {
+		if now.Sub(bucket.lastSeen) > idleBucketTTL {
+			delete(l.buckets, identity)
+		}
+	}
+}
+
+func newTierLimiter(tier RateLimitTier) *rate.Limiter {
+	if tier.RPS <= 0 {
+		return rate.NewLimiter(rate.Inf, 0)
+	}
+
+	burst := tier.Burst
+	if burst < 1 {
+		burst = 1
+	}
+	return rate.NewLimiter(rate.Limit(tier.RPS), burst)
+}
+
+func rateExceeded(tier string, retryAfter time.Duration) error {
+	if retryAfter <= 0 || retryAfter == rate.InfDuration {
+		retryAfter = time.Second
+	}
+
+	st := status.New(codes.ResourceExhausted, fmt.Sprintf("rate limit exceeded for tier %q", tier))
+	if detailed, err := st.WithDetails(&errdetails.RetryInfo{RetryDelay: durationpb.New(retryAfter)}); err == nil {
+		st = detailed
+	}
+	return st.Err()
+}
+
+func quotaExceeded(identity, tier string, quota int64, retryAfter time.Duration) error {
+	st := status.New(codes.ResourceExhausted, fmt.Sprintf("daily quota of %d requests exhausted for tier %q", quota, tier))
+	detailed, err := st.WithDetails(
+		&errdetails.RetryInfo{RetryDelay: durationpb.New(retryAfter)},
+		&errdetails.QuotaFailure{Violations: []*errdetails.QuotaFailure_Violation{{
+			Subject:     identity,
+			Description: fmt.Sprintf("daily quota of %d requests", quota),
+		}}},
+	)
+	if err == nil {
+		st = detailed
+	}
+	return st.Err()
+}
+
+// UpdateRateLimits replaces the per-client rate limit policy, e.g. after a config reload
+func (s *Server) UpdateRateLimits(policy RateLimitPolicy) {
+	s.limiter.update(policy)
+}
+
+// rateLimitInterceptor rejects unary calls that exceed the caller's tier
+func (s *Server) rateLimitInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		identity := clientIdentity(ctx)
+		if err := s.limiter.allow(ctx, identity); err != nil {
+			s.log(ctx).Warn("gRPC request rate limited",
+				"method", info.FullMethod,
+				"client", identity)
+			return nil, err
+		}
+		return handler(ctx, req)
+	}
+}
+
+// rateLimitStreamInterceptor rejects stream calls that exceed the caller's tier
+func (s *Server) rateLimitStreamInterceptor() grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		ctx := ss.Context()
+		identity := clientIdentity(ctx)
+		if err := s.limiter.allow(ctx, identity); err != nil {
+			s.log(ctx).Warn("gRPC stream rate limited",
+				"method", info.FullMethod,
+				"client", identity)
+			return err
+		}
+		return handler(srv, ss)
+	}
+}
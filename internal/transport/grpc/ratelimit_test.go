@@ -0,0 +1,173 @@
+package grpc
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/cawa87/garantex-test/internal/service/auth"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+func TestClientIdentity(t *testing.T) {
+	ctx := peer.NewContext(context.Background(), &peer.Peer{
+		Addr: &net.TCPAddr{IP: net.ParseIP("10.0.0.5"), Port: 41000},
+	})
+	assert.Equal(t, "ip:10.0.0.5", clientIdentity(ctx))
+
+	// Unverified keys do not split the caller's bucket
+	first := metadata.NewIncomingContext(ctx, metadata.Pairs(apiKeyHeader, "made-up-1"))
+	second := metadata.NewIncomingContext(ctx, metadata.Pairs(apiKeyHeader, "made-up-2"))
+	assert.Equal(t, "ip:10.0.0.5", clientIdentity(first))
+	assert.Equal(t, clientIdentity(first), clientIdentity(second))
+
+	verified := auth.WithPrincipal(first, &auth.Principal{Kind: auth.KindAPIKey, Subject: "k1"})
+	assert.Equal(t, "key:k1", clientIdentity(verified))
+
+	assert.Equal(t, "unknown", clientIdentity(context.Background()))
+}
+
+func TestClientLimiter_UnverifiedKeysShareBucket(t *testing.T) {
+	limiter := newClientLimiter(RateLimitPolicy{
+		DefaultTier: "standard",
+		Tiers: map[string]RateLimitTier{
+			"standard": {RPS: 1, Burst: 1},
+		},
+	})
+
+	ctx := peer.NewContext(context.Background(), &peer.Peer{
+		Addr: &net.TCPAddr{IP: net.ParseIP("10.0.0.5"), Port: 41000},
+	})
+	first := metadata.NewIncomingContext(ctx, metadata.Pairs(apiKeyHeader, "made-up-1"))
+	second := metadata.NewIncomingContext(ctx, metadata.Pairs(apiKeyHeader, "made-up-2"))
+
+	require.NoError(t, limiter.allow(first, clientIdentity(first)))
+	err := limiter.allow(second, clientIdentity(second))
+	assert.Equal(t, codes.ResourceExhausted, status.Code(err))
+}
+
+func TestClientLimiter_RateExceeded(t *testing.T) {
+	limiter := newClientLimiter(RateLimitPolicy{
+		DefaultTier: "standard",
+		Tiers: map[string]RateLimitTier{
+			"standard": {RPS: 1, Burst: 2},
+		},
+	})
+
+	ctx := context.Background()
+	require.NoError(t, limiter.allow(ctx, "ip:10.0.0.5"))
+	require.NoError(t, limiter.allow(ctx, "ip:10.0.0.5"))
+
+	err := limiter.allow(ctx, "ip:10.0.0.5")
+	require.Error(t, err)
+
+	st := status.Convert(err)
+	assert.Equal(t, codes.ResourceExhausted, st.Code())
+	require.Len(t, st.Details(), 1)
+	retry, ok := st.Details()[0].(*errdetails.RetryInfo)
+	require.True(t, ok)
+	assert.Greater(t, retry.RetryDelay.AsDuration().Seconds(), 0.0)
+
+	// Other clients have their own bucket
+	assert.NoError(t, limiter.allow(ctx, "ip:10.0.0.6"))
+}
+
+func TestClientLimiter_DailyQuota(t *testing.T) {
+	limiter := newClientLimiter(RateLimitPolicy{
+		DefaultTier: "standard",
+		Tiers: map[string]RateLimitTier{
+			"standard": {DailyQuota: 2},
+			"internal": {},
+		},
+		Clients: map[string]string{"cn:reporting": "internal"},
+	})
+
+	ctx := context.Background()
+	require.NoError(t, limiter.allow(ctx, "ip:10.0.0.5"))
+	require.NoError(t, limiter.allow(ctx, "ip:10.0.0.5"))
+
+	err := limiter.allow(ctx, "ip:10.0.0.5")
+	st := status.Convert(err)
+	assert.Equal(t, codes.ResourceExhausted, st.Code())
+	assert.Len(t, st.Details(), 2)
+
+	for i := 0; i < 10; i++ {
+		assert.NoError(t, limiter.allow(ctx, "cn:reporting"))
+	}
+}
+
+func TestClientLimiter_Update(t *testing.T) {
+	limiter := newClientLimiter(RateLimitPolicy{})
+
+	ctx := context.Background()
+	for i := 0; i < 10; i++ {
+		require.NoError(t, limiter.allow(ctx, "ip:10.0.0.5"))
+	}
+
+	limiter.update(RateLimitPolicy{
+		DefaultTier: "strict",
+		Tiers:       map[string]RateLimitTier{"strict": {RPS: 1, Burst: 1}},
+	})
+
+	require.NoError(t, limiter.allow(ctx, "ip:10.0.0.5"))
+	assert.Error(t, limiter.allow(ctx, "ip:10.0.0.5"))
+}
+
+// countingKeyStore knows no keys and counts the lookups
+type countingKeyStore struct {
+	lookups int
+}
+
+func (s *countingKeyStore) GetAPIKey(_ context.Context, _ string) (*auth.APIKey, error) {
+	s.lookups++
+	return nil, sql.ErrNoRows
+}
+
+func TestAuthenticate_FailuresLimitedPerPeer(t *testing.T) {
+	store := &countingKeyStore{}
+	authenticator := auth.NewAuthenticator(auth.NewKeyVerifier(store, time.Minute), nil, nil)
+	s := newTestServer(t)
+	WithAuth(authenticator, auth.NewPolicy(nil))(s)
+
+	method := "/rate_service.v1.RateService/GetRates"
+	attacker := peer.NewContext(context.Background(), &peer.Peer{
+		Addr: &net.TCPAddr{IP: net.ParseIP("10.0.0.5"), Port: 41000},
+	})
+
+	// Every made-up key is a fresh lookup until the peer's failed attempts run out
+	for i := 0; i < authFailureBurst; i++ {
+		ctx := metadata.NewIncomingContext(attacker, metadata.Pairs(apiKeyHeader, fmt.Sprintf("made-up-%d.secret", i)))
+		_, err := s.authenticate(ctx, method)
+		assert.Equal(t, codes.Unauthenticated, status.Code(err))
+	}
+	assert.Equal(t, authFailureBurst, store.lookups)
+
+	// Then the peer is rejected before its keys reach the store
+	ctx := metadata.NewIncomingContext(attacker, metadata.Pairs(apiKeyHeader, "made-up-x.secret"))
+	_, err := s.authenticate(ctx, method)
+	st := status.Convert(err)
+	assert.Equal(t, codes.ResourceExhausted, st.Code())
+	require.Len(t, st.Details(), 1)
+	retry, ok := st.Details()[0].(*errdetails.RetryInfo)
+	require.True(t, ok)
+	assert.Positive(t, retry.RetryDelay.AsDuration())
+	assert.Equal(t, authFailureBurst, store.lookups)
+
+	// Other peers are not affected
+	other := peer.NewContext(context.Background(), &peer.Peer{
+		Addr: &net.TCPAddr{IP: net.ParseIP("10.0.0.6"), Port: 41000},
+	})
+	ctx = metadata.NewIncomingContext(other, metadata.Pairs(apiKeyHeader, "made-up-y.secret"))
+	_, err = s.authenticate(ctx, method)
+	assert.Equal(t, codes.Unauthenticated, status.Code(err))
+	assert.Equal(t, authFailureBurst+1, store.lookups)
+}
@@ -2,18 +2,29 @@ package grpc
 
 import (
 	"context"
+	"crypto/tls"
+	"errors"
 	"fmt"
 	"net"
+	"strconv"
 	"time"
 
 	"github.com/cawa87/garantex-test/internal/lib/logger/sl"
-	"github.com/cawa87/garantex-test/internal/repository/postgres"
+	"github.com/cawa87/garantex-test/internal/repository"
+	"github.com/cawa87/garantex-test/internal/service/auth"
 	"github.com/cawa87/garantex-test/internal/service/exchange"
+	"github.com/cawa87/garantex-test/internal/service/feed"
+	"github.com/cawa87/garantex-test/internal/service/guard"
+	"github.com/cawa87/garantex-test/internal/service/ingest"
+	"github.com/shopspring/decimal"
 	"go.opentelemetry.io/otel"
 	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
 	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/durationpb"
 	"google.golang.org/protobuf/types/known/timestamppb"
 
 	pb "github.com/cawa87/garantex-test/gen/go/rate_service.v1"
@@ -22,66 +33,148 @@ import (
 // Server represents the gRPC server for rate service
 type Server struct {
 	pb.UnimplementedRateServiceServer
-	repo     *postgres.Repository
-	exchange *exchange.Client
-	logger   *sl.Logger
+	repo            repository.Store
+	exchange        *exchange.Client
+	limiter         *clientLimiter
+	authFailures    *authFailureLimiter
+	authenticator   *auth.Authenticator
+	policy          *auth.Policy
+	tlsConfig       *tls.Config
+	ingest          *ingest.Buffer
+	guard           *guard.Guard
+	orderBooks      bool
+	orderBookDepth  int
+	lookupTolerance time.Duration
+	leader          LeaderStatus
+	quality         QualityReporter
+	feed            *feed.Broadcaster
+	logger          *sl.Logger
 }
 
-// NewServer creates a new gRPC server with repository and exchange client
-func NewServer(repo *postgres.Repository, exchange *exchange.Client, logger *sl.Logger) *Server {
-	return &Server{
-		repo:     repo,
-		exchange: exchange,
-		logger:   logger,
+// Option configures optional server behaviour
+type Option func(*Server)
+
+// WithRateLimits enables per-client rate limiting and quotas
+func WithRateLimits(policy RateLimitPolicy) Option {
+	return func(s *Server) {
+		s.limiter.update(policy)
+	}
+}
+
+// WithTLS serves gRPC over TLS, optionally verifying client certificates
+func WithTLS(cfg *tls.Config) Option {
+	return func(s *Server) {
+		s.tlsConfig = cfg
+	}
+}
+
+// WithIngestBuffer writes fetched rates through the buffer in batches instead of one insert per call.
+// Rates stored together with an order book snapshot are still written immediately
+func WithIngestBuffer(buffer *ingest.Buffer) Option {
+	return func(s *Server) {
+		s.ingest = buffer
+	}
+}
+
+// WithPriceGuard checks fetched ticks before they are served and stored; rejected ticks fail GetRates
+// with UNAVAILABLE and are quarantined instead
+func WithPriceGuard(g *guard.Guard) Option {
+	return func(s *Server) {
+		s.guard = g
 	}
 }
 
+// LeaderStatus reports the leader election state of this replica
+type LeaderStatus interface {
+	IsLeader() bool
+	Leader() string
+}
+
+// WithLeader reports the elected leader in health check details
+func WithLeader(status LeaderStatus) Option {
+	return func(s *Server) {
+		s.leader = status
+	}
+}
+
+// NewServer creates a new gRPC server with a storage backend and exchange client
+func NewServer(repo repository.Store, exchange *exchange.Client, logger *sl.Logger, opts ...Option) *Server {
+	s := &Server{
+		repo:         repo,
+		exchange:     exchange,
+		limiter:      newClientLimiter(RateLimitPolicy{}),
+		authFailures: newAuthFailureLimiter(),
+		logger:       logger,
+	}
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s
+}
+
 func (s *Server) GetRates(ctx context.Context, req *pb.GetRatesRequest) (*pb.GetRatesResponse, error) {
-	ctx, span := otel.Tracer("rate-service").Start(ctx, "GetRates")
+	ctx, span := s.startSpan(ctx, "GetRates")
 	defer span.End()
 
-	s.logger.Info("GetRates called")
+	s.log(ctx).Info("GetRates called")
 
 	rate, err := s.exchange.GetRates(ctx)
 	if err != nil {
 		span.RecordError(err)
-		s.logger.Error("Failed to get rates from exchange", "error", err)
+		s.log(ctx).Error("Failed to get rates from exchange", "error", err)
+		if errors.Is(err, exchange.ErrRateLimited) {
+			return nil, status.Errorf(codes.ResourceExhausted, "exchange rate limit exceeded: %v", err)
+		}
+		if ctx.Err() != nil {
+			return nil, status.FromContextError(ctx.Err()).Err()
+		}
 		return nil, status.Errorf(codes.Internal, "failed to get rates from exchange: %v", err)
 	}
 
-	if err := s.repo.SaveRate(ctx, rate); err != nil {
+	if s.guard != nil {
+		if err := s.guard.Check(ctx, rate); err != nil {
+			span.RecordError(err)
+			return nil, status.Errorf(codes.Unavailable, "%v", err)
+		}
+	}
+
+	if err := s.saveRate(ctx, rate); err != nil {
 		span.RecordError(err)
-		s.logger.Error("Failed to save rate to database", "error", err)
+		s.log(ctx).Error("Failed to save rate to database", "error", err)
 	}
 
 	response := &pb.GetRatesResponse{
-		Ask:       rate.Ask,
-		Bid:       rate.Bid,
-		Timestamp: timestamppb.New(rate.Timestamp),
+		Ask:        rate.Ask.InexactFloat64(), //nolint:staticcheck // deprecated field kept for older clients
+		Bid:        rate.Bid.InexactFloat64(), //nolint:staticcheck // deprecated field kept for older clients
+		Timestamp:  timestamppb.New(rate.Timestamp),
+		AskPrice:   toDecimal(rate.Ask),
+		BidPrice:   toDecimal(rate.Bid),
+		ReceivedAt: timestamppb.New(rate.ReceivedAt),
+		Latency:    durationpb.New(rate.Latency),
 	}
 
 	span.SetAttributes(
-		attribute.Float64("ask", rate.Ask),
-		attribute.Float64("bid", rate.Bid),
+		attribute.String("ask", rate.Ask.String()),
+		attribute.String("bid", rate.Bid.String()),
 	)
 
-	s.logger.Info("GetRates completed successfully",
-		"ask", response.Ask,
-		"bid", response.Bid)
+	s.log(ctx).Info("GetRates completed successfully",
+		"ask", rate.Ask,
+		"bid", rate.Bid)
 
 	return response, nil
 }
 
 func (s *Server) HealthCheck(ctx context.Context, req *pb.HealthCheckRequest) (*pb.HealthCheckResponse, error) {
-	ctx, span := otel.Tracer("rate-service").Start(ctx, "HealthCheck")
+	ctx, span := s.startSpan(ctx, "HealthCheck")
 	defer span.End()
 
-	s.logger.Debug("HealthCheck called")
+	s.log(ctx).Debug("HealthCheck called")
 
 	dbCount, err := s.repo.GetRatesCount(ctx)
 	if err != nil {
 		span.RecordError(err)
-		s.logger.Error("Database health check failed", "error", err)
+		s.log(ctx).Error("Database health check failed", "error", err)
 		return &pb.HealthCheckResponse{
 			Status: "unhealthy",
 			Details: map[string]string{
@@ -98,7 +191,7 @@ func (s *Server) HealthCheck(ctx context.Context, req *pb.HealthCheckRequest) (*
 	exchangeStatus := "healthy"
 	if err != nil {
 		span.RecordError(err)
-		s.logger.Warn("Exchange health check failed", "error", err)
+		s.log(ctx).Warn("Exchange health check failed", "error", err)
 		exchangeStatus = "unhealthy"
 	}
 
@@ -116,6 +209,10 @@ func (s *Server) HealthCheck(ctx context.Context, req *pb.HealthCheckRequest) (*
 			"timestamp":        time.Now().Format(time.RFC3339),
 		},
 	}
+	if s.leader != nil {
+		response.Details["leader"] = s.leader.Leader()
+		response.Details["is_leader"] = strconv.FormatBool(s.leader.IsLeader())
+	}
 
 	span.SetAttributes(
 		attribute.String("status", overallStatus),
@@ -123,10 +220,51 @@ func (s *Server) HealthCheck(ctx context.Context, req *pb.HealthCheckRequest) (*
 		attribute.String("exchange_status", exchangeStatus),
 	)
 
-	s.logger.Debug("HealthCheck completed", "status", overallStatus)
+	s.log(ctx).Debug("HealthCheck completed", "status", overallStatus)
 	return response, nil
 }
 
+// saveRate stores the rate, together with its order book snapshot when enabled
+func (s *Server) saveRate(ctx context.Context, rate *exchange.Rate) error {
+	var (
+		created bool
+		err     error
+	)
+	switch {
+	case s.orderBooks && rate.Book != nil:
+		created, err = s.repo.SaveRateWithOrderBook(ctx, rate, rate.Book.Truncate(s.orderBookDepth))
+	case s.ingest != nil:
+		s.ingest.Add(rate)
+		return nil
+	default:
+		created, err = s.repo.SaveRate(ctx, rate)
+	}
+	if err == nil && !created {
+		s.log(ctx).Debug("Rate already stored", "market", rate.Market, "source", rate.Source, "timestamp", rate.Timestamp)
+	}
+	return err
+}
+
+// toDecimal converts an exact decimal to its proto representation
+func toDecimal(d decimal.Decimal) *pb.Decimal {
+	return &pb.Decimal{Value: d.String()}
+}
+
+// startSpan starts a handler span annotated with the caller identity
+func (s *Server) startSpan(ctx context.Context, name string) (context.Context, trace.Span) {
+	ctx, span := otel.Tracer("rate-service").Start(ctx, name)
+	if id, ok := RequestIDFromContext(ctx); ok {
+		span.SetAttributes(attribute.String("request.id", id))
+	}
+	if principal, ok := auth.PrincipalFromContext(ctx); ok {
+		span.SetAttributes(
+			attribute.String("caller.kind", principal.Kind),
+			attribute.String("caller.subject", principal.Subject),
+		)
+	}
+	return ctx, span
+}
+
 // Run starts the gRPC server on the specified port
 func (s *Server) Run(port int) error {
 	lis, err := net.Listen("tcp", fmt.Sprintf(":%d", port))
@@ -134,12 +272,18 @@ func (s *Server) Run(port int) error {
 		return fmt.Errorf("failed to listen: %w", err)
 	}
 
-	grpcServer := grpc.NewServer(
-		grpc.UnaryInterceptor(s.loggingInterceptor()),
-	)
+	opts := []grpc.ServerOption{
+		grpc.ChainUnaryInterceptor(s.unaryInterceptors()...),
+		grpc.ChainStreamInterceptor(s.streamInterceptors()...),
+	}
+	if s.tlsConfig != nil {
+		opts = append(opts, grpc.Creds(credentials.NewTLS(s.tlsConfig)))
+	}
+
+	grpcServer := grpc.NewServer(opts...)
 	pb.RegisterRateServiceServer(grpcServer, s)
 
-	s.logger.Info("Starting gRPC server", "port", port)
+	s.logger.Info("Starting gRPC server", "port", port, "tls", s.tlsConfig != nil)
 
 	if err := grpcServer.Serve(lis); err != nil {
 		return fmt.Errorf("failed to serve: %w", err)
@@ -148,26 +292,54 @@ func (s *Server) Run(port int) error {
 	return nil
 }
 
+// unaryInterceptors returns the unary interceptor chain, outermost first. The request ID comes first so
+// that every later log line, including the panic logged by recovery, carries it
+func (s *Server) unaryInterceptors() []grpc.UnaryServerInterceptor {
+	return []grpc.UnaryServerInterceptor{
+		s.requestIDInterceptor(),
+		s.recoveryInterceptor(),
+		s.authInterceptor(),
+		s.loggingInterceptor(),
+		s.rateLimitInterceptor(),
+		s.validationInterceptor(),
+	}
+}
+
+// streamInterceptors returns the stream interceptor chain, outermost first
+func (s *Server) streamInterceptors() []grpc.StreamServerInterceptor {
+	return []grpc.StreamServerInterceptor{
+		s.requestIDStreamInterceptor(),
+		s.recoveryStreamInterceptor(),
+		s.authStreamInterceptor(),
+		s.rateLimitStreamInterceptor(),
+		s.validationStreamInterceptor(),
+	}
+}
+
 // loggingInterceptor provides request logging for gRPC calls
 func (s *Server) loggingInterceptor() grpc.UnaryServerInterceptor {
 	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
 		start := time.Now()
+		caller := clientIdentity(ctx)
 
-		s.logger.Info("gRPC request started",
+		s.log(ctx).Info("gRPC request started",
 			"method", info.FullMethod,
+			"caller", caller,
 			"request", fmt.Sprintf("%T", req))
 
 		resp, err := handler(ctx, req)
 
 		duration := time.Since(start)
 		if err != nil {
-			s.logger.Error("gRPC request failed",
+			s.log(ctx).Error("gRPC request failed",
 				"method", info.FullMethod,
+				"caller", caller,
 				"duration", duration,
 				"error", err)
 		} else {
-			s.logger.Info("gRPC request completed",
+			s.log(ctx).Info("gRPC request completed",
 				"method", info.FullMethod,
+				"caller", caller,
 				"duration", duration)
 		}
 
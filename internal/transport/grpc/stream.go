@@ -0,0 +1,66 @@
+package grpc
+
+import (
+	"github.com/cawa87/garantex-test/internal/service/exchange"
+	"github.com/cawa87/garantex-test/internal/service/feed"
+	"go.opentelemetry.io/otel/attribute"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	pb "github.com/cawa87/garantex-test/gen/go/rate_service.v1"
+)
+
+// rateFeedBuffer is the number of rates buffered per StreamRates subscriber; a subscriber falling further
+// behind misses rates
+const rateFeedBuffer = 64
+
+// WithRateFeed serves StreamRates from the broadcaster; without it the method is unavailable
+func WithRateFeed(b *feed.Broadcaster) Option {
+	return func(s *Server) {
+		s.feed = b
+	}
+}
+
+func (s *Server) StreamRates(req *pb.StreamRatesRequest, stream pb.RateService_StreamRatesServer) error {
+	ctx, span := s.startSpan(stream.Context(), "StreamRates")
+	defer span.End()
+
+	if s.feed == nil {
+		return status.Error(codes.FailedPrecondition, "rate streaming is disabled")
+	}
+
+	market := req.Market
+	if market == "" {
+		market = exchange.DefaultMarket
+	}
+	span.SetAttributes(attribute.String("market", market))
+
+	rates, unsubscribe := s.feed.Subscribe(rateFeedBuffer)
+	defer unsubscribe()
+
+	var sent int64
+	for {
+		select {
+		case <-ctx.Done():
+			span.SetAttributes(attribute.Int64("rates", sent))
+			return status.FromContextError(ctx.Err()).Err()
+		case rate := <-rates:
+			if rate.Market != market {
+				continue
+			}
+			err := stream.Send(&pb.RateUpdate{
+				Id:        rate.ID,
+				Market:    rate.Market,
+				Source:    rate.Source,
+				AskPrice:  toDecimal(rate.Ask),
+				BidPrice:  toDecimal(rate.Bid),
+				Timestamp: timestamppb.New(rate.Timestamp),
+			})
+			if err != nil {
+				return err
+			}
+			sent++
+		}
+	}
+}
@@ -0,0 +1,2 @@
+-- Drop order_book_snapshots table
+DROP TABLE IF EXISTS order_book_snapshots;
@@ -0,0 +1,18 @@
+-- Create order_book_snapshots table; levels are stored as JSONB arrays of [price, volume] string pairs.
+-- Snapshots have their own retention, so pruning rates only unlinks them
+CREATE TABLE IF NOT EXISTS order_book_snapshots (
+    id BIGSERIAL PRIMARY KEY,
+    rate_id BIGINT REFERENCES rates(id) ON DELETE SET NULL,
+    market TEXT NOT NULL,
+    timestamp TIMESTAMP WITH TIME ZONE NOT NULL,
+    depth INTEGER NOT NULL,
+    asks JSONB NOT NULL,
+    bids JSONB NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+-- Create index for latest and as-of lookups per market
+CREATE INDEX IF NOT EXISTS idx_order_book_snapshots_market_timestamp ON order_book_snapshots(market, timestamp DESC);
+
+-- Create index for rate lookups
+CREATE INDEX IF NOT EXISTS idx_order_book_snapshots_rate_id ON order_book_snapshots(rate_id);
@@ -0,0 +1,3 @@
+-- Drop rate fetch timing columns
+ALTER TABLE IF EXISTS rates DROP COLUMN IF EXISTS latency_us;
+ALTER TABLE IF EXISTS rates DROP COLUMN IF EXISTS received_at;
@@ -0,0 +1,10 @@
+-- Add the local receive time and fetch round trip to rates; timestamp now holds the exchange timestamp.
+-- Rows recorded before this migration were stamped locally, so received_at is backfilled from timestamp
+-- and their latency is unknown (0)
+ALTER TABLE rates ADD COLUMN IF NOT EXISTS received_at TIMESTAMP WITH TIME ZONE;
+ALTER TABLE rates ADD COLUMN IF NOT EXISTS latency_us BIGINT NOT NULL DEFAULT 0;
+
+UPDATE rates SET received_at = timestamp WHERE received_at IS NULL;
+
+ALTER TABLE rates ALTER COLUMN received_at SET NOT NULL;
+ALTER TABLE rates ALTER COLUMN received_at SET DEFAULT NOW();
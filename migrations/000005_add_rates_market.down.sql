@@ -0,0 +1,3 @@
+-- Drop rates market column
+DROP INDEX IF EXISTS idx_rates_market_timestamp;
+ALTER TABLE IF EXISTS rates DROP COLUMN IF EXISTS market;
@@ -0,0 +1,5 @@
+-- Add market to rates; existing rows were all fetched for btcusdt
+ALTER TABLE rates ADD COLUMN IF NOT EXISTS market TEXT NOT NULL DEFAULT 'btcusdt';
+
+-- Create index for as-of lookups per market
+CREATE INDEX IF NOT EXISTS idx_rates_market_timestamp ON rates(market, timestamp DESC);
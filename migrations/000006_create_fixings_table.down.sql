@@ -0,0 +1,2 @@
+-- Drop fixings table
+DROP TABLE IF EXISTS fixings;
@@ -0,0 +1,20 @@
+-- Create fixings table; one reference rate per fixing window and calendar date
+CREATE TABLE IF NOT EXISTS fixings (
+    id BIGSERIAL PRIMARY KEY,
+    name TEXT NOT NULL,
+    market TEXT NOT NULL,
+    fixing_date DATE NOT NULL,
+    method TEXT NOT NULL,
+    methodology TEXT NOT NULL,
+    window_start TIMESTAMP WITH TIME ZONE NOT NULL,
+    window_end TIMESTAMP WITH TIME ZONE NOT NULL,
+    ask DECIMAL(20, 8) NOT NULL,
+    bid DECIMAL(20, 8) NOT NULL,
+    mid DECIMAL(20, 8) NOT NULL,
+    sample_count INTEGER NOT NULL,
+    computed_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    UNIQUE (name, fixing_date)
+);
+
+-- Create index on fixing_date for listing
+CREATE INDEX IF NOT EXISTS idx_fixings_fixing_date ON fixings(fixing_date DESC);
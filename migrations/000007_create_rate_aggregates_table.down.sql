@@ -0,0 +1,2 @@
+-- Drop rate_aggregates table
+DROP TABLE IF EXISTS rate_aggregates;
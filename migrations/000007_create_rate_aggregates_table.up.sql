@@ -0,0 +1,20 @@
+-- Create rate_aggregates table; OHLC buckets that replace raw rates once they expire
+CREATE TABLE IF NOT EXISTS rate_aggregates (
+    market TEXT NOT NULL,
+    resolution TEXT NOT NULL,
+    bucket TIMESTAMP WITH TIME ZONE NOT NULL,
+    ask_open DECIMAL(20, 8) NOT NULL,
+    ask_high DECIMAL(20, 8) NOT NULL,
+    ask_low DECIMAL(20, 8) NOT NULL,
+    ask_close DECIMAL(20, 8) NOT NULL,
+    bid_open DECIMAL(20, 8) NOT NULL,
+    bid_high DECIMAL(20, 8) NOT NULL,
+    bid_low DECIMAL(20, 8) NOT NULL,
+    bid_close DECIMAL(20, 8) NOT NULL,
+    sample_count BIGINT NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (market, resolution, bucket)
+);
+
+-- Create index for pruning by resolution
+CREATE INDEX IF NOT EXISTS idx_rate_aggregates_resolution_bucket ON rate_aggregates(resolution, bucket);
@@ -0,0 +1,41 @@
+-- Convert rates back into a single table; rows in detached partitions are not restored
+DO $$
+BEGIN
+    IF NOT EXISTS (SELECT 1 FROM pg_class WHERE relname = 'rates' AND relkind = 'p') THEN
+        RETURN;
+    END IF;
+
+    DROP INDEX IF EXISTS idx_rates_timestamp;
+    DROP INDEX IF EXISTS idx_rates_created_at;
+    DROP INDEX IF EXISTS idx_rates_market_timestamp;
+    ALTER TABLE rates RENAME TO rates_partitioned;
+    ALTER INDEX rates_pkey RENAME TO rates_partitioned_pkey;
+    ALTER SEQUENCE rates_id_seq OWNED BY NONE;
+
+    CREATE TABLE rates (
+        id BIGINT PRIMARY KEY DEFAULT nextval('rates_id_seq'),
+        market TEXT NOT NULL DEFAULT 'btcusdt',
+        ask DECIMAL(20, 8) NOT NULL,
+        bid DECIMAL(20, 8) NOT NULL,
+        timestamp TIMESTAMP WITH TIME ZONE NOT NULL,
+        received_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+        latency_us BIGINT NOT NULL DEFAULT 0,
+        created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+    );
+
+    ALTER SEQUENCE rates_id_seq OWNED BY rates.id;
+
+    INSERT INTO rates (id, market, ask, bid, timestamp, received_at, latency_us, created_at)
+    SELECT id, market, ask, bid, timestamp, received_at, latency_us, created_at FROM rates_partitioned;
+
+    DROP TABLE rates_partitioned;
+
+    CREATE INDEX idx_rates_timestamp ON rates(timestamp DESC);
+    CREATE INDEX idx_rates_created_at ON rates(created_at DESC);
+    CREATE INDEX idx_rates_market_timestamp ON rates(market, timestamp DESC);
+
+    -- Unlink snapshots of rates that were in detached partitions before restoring the reference
+    UPDATE order_book_snapshots SET rate_id = NULL WHERE rate_id IS NOT NULL AND rate_id NOT IN (SELECT id FROM rates);
+    ALTER TABLE order_book_snapshots ADD CONSTRAINT order_book_snapshots_rate_id_fkey
+        FOREIGN KEY (rate_id) REFERENCES rates(id) ON DELETE SET NULL;
+END $$;
@@ -0,0 +1,60 @@
+-- Convert rates into a table partitioned by month on timestamp. Partitions are named rates_YYYY_MM and
+-- cover UTC months; the application pre-creates upcoming ones. The primary key must include the
+-- partition key, and order_book_snapshots.rate_id becomes a plain reference so old partitions can be detached
+DO $$
+DECLARE
+    partition_month TIMESTAMP;
+    last_month TIMESTAMP;
+BEGIN
+    IF NOT EXISTS (SELECT 1 FROM pg_class WHERE relname = 'rates' AND relkind = 'r') THEN
+        RETURN;
+    END IF;
+
+    ALTER TABLE IF EXISTS order_book_snapshots DROP CONSTRAINT IF EXISTS order_book_snapshots_rate_id_fkey;
+
+    DROP INDEX IF EXISTS idx_rates_timestamp;
+    DROP INDEX IF EXISTS idx_rates_created_at;
+    DROP INDEX IF EXISTS idx_rates_market_timestamp;
+    ALTER TABLE rates RENAME TO rates_unpartitioned;
+    ALTER INDEX rates_pkey RENAME TO rates_unpartitioned_pkey;
+    ALTER SEQUENCE rates_id_seq OWNED BY NONE;
+
+    CREATE TABLE rates (
+        id BIGINT NOT NULL DEFAULT nextval('rates_id_seq'),
+        market TEXT NOT NULL DEFAULT 'btcusdt',
+        ask DECIMAL(20, 8) NOT NULL,
+        bid DECIMAL(20, 8) NOT NULL,
+        timestamp TIMESTAMP WITH TIME ZONE NOT NULL,
+        received_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+        latency_us BIGINT NOT NULL DEFAULT 0,
+        created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+        PRIMARY KEY (id, timestamp)
+    ) PARTITION BY RANGE (timestamp);
+
+    ALTER SEQUENCE rates_id_seq OWNED BY rates.id;
+
+    -- Partitions from the oldest stored month up to two months ahead
+    SELECT date_trunc('month', COALESCE(min(timestamp), NOW()) AT TIME ZONE 'UTC') INTO partition_month FROM rates_unpartitioned;
+    last_month := date_trunc('month', NOW() AT TIME ZONE 'UTC') + INTERVAL '2 months';
+    WHILE partition_month <= last_month LOOP
+        EXECUTE format('CREATE TABLE IF NOT EXISTS %I PARTITION OF rates FOR VALUES FROM (%L) TO (%L)',
+            'rates_' || to_char(partition_month, 'YYYY_MM'),
+            partition_month AT TIME ZONE 'UTC',
+            (partition_month + INTERVAL '1 month') AT TIME ZONE 'UTC');
+        partition_month := partition_month + INTERVAL '1 month';
+    END LOOP;
+
+    INSERT INTO rates (id, market, ask, bid, timestamp, received_at, latency_us, created_at)
+    SELECT id, market, ask, bid, timestamp, received_at, latency_us, created_at FROM rates_unpartitioned;
+
+    DROP TABLE rates_unpartitioned;
+END $$;
+
+-- Create index on timestamp for faster queries
+CREATE INDEX IF NOT EXISTS idx_rates_timestamp ON rates(timestamp DESC);
+
+-- Create index on created_at for faster queries
+CREATE INDEX IF NOT EXISTS idx_rates_created_at ON rates(created_at DESC);
+
+-- Create index for as-of lookups per market
+CREATE INDEX IF NOT EXISTS idx_rates_market_timestamp ON rates(market, timestamp DESC);
@@ -0,0 +1,3 @@
+-- Drop rates sample uniqueness and source column
+DROP INDEX IF EXISTS idx_rates_sample;
+ALTER TABLE IF EXISTS rates DROP COLUMN IF EXISTS source;
@@ -0,0 +1,19 @@
+-- Identify samples by (market, source, exchange timestamp) so retries, concurrent pollers and backfills
+-- cannot store a sample twice. Existing duplicates block the unique index; the migration then fails and
+-- they are removed with the dedupe-rates command before migrating again
+ALTER TABLE rates ADD COLUMN IF NOT EXISTS source TEXT NOT NULL DEFAULT 'garantex';
+
+DO $$
+BEGIN
+    IF EXISTS (
+        SELECT 1 FROM rates
+        GROUP BY market, source, timestamp
+        HAVING count(*) > 1
+        LIMIT 1
+    ) THEN
+        RAISE EXCEPTION 'rates contains duplicate samples, the unique sample index cannot be created'
+            USING HINT = 'Run "app dedupe-rates" (-dry-run counts them), then "migrate force 8" and migrate up again';
+    END IF;
+END $$;
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_rates_sample ON rates(market, source, timestamp);
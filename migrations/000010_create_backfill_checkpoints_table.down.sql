@@ -0,0 +1,2 @@
+-- Drop backfill checkpoints table
+DROP TABLE IF EXISTS backfill_checkpoints;
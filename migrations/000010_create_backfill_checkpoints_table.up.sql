@@ -0,0 +1,15 @@
+-- Create backfill checkpoints table; one row per named backfill job. Everything in [window_start, cursor)
+-- has been filled, and last_trade_id is the last exchange trade stored by a trades backfill
+CREATE TABLE IF NOT EXISTS backfill_checkpoints (
+    name TEXT PRIMARY KEY,
+    market TEXT NOT NULL,
+    mode TEXT NOT NULL,
+    period_seconds BIGINT NOT NULL DEFAULT 0,
+    window_start TIMESTAMP WITH TIME ZONE NOT NULL,
+    window_end TIMESTAMP WITH TIME ZONE NOT NULL,
+    cursor TIMESTAMP WITH TIME ZONE NOT NULL,
+    last_trade_id BIGINT NOT NULL DEFAULT 0,
+    inserted BIGINT NOT NULL DEFAULT 0,
+    completed_at TIMESTAMP WITH TIME ZONE,
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
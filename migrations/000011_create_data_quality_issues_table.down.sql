@@ -0,0 +1,2 @@
+-- Drop data quality issues table
+DROP TABLE IF EXISTS data_quality_issues;
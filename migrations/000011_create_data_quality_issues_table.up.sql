@@ -0,0 +1,16 @@
+-- Create data quality issues table; one row per stretch of a market's rate history that failed a check
+-- (gap, frozen, crossed or jump), written by the data quality checker
+CREATE TABLE IF NOT EXISTS data_quality_issues (
+    id BIGSERIAL PRIMARY KEY,
+    market TEXT NOT NULL,
+    kind TEXT NOT NULL,
+    start_time TIMESTAMP WITH TIME ZONE NOT NULL,
+    end_time TIMESTAMP WITH TIME ZONE NOT NULL,
+    samples INTEGER NOT NULL DEFAULT 0,
+    detail TEXT NOT NULL DEFAULT '',
+    detected_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    UNIQUE (market, kind, start_time)
+);
+
+-- Create index for window queries per market
+CREATE INDEX IF NOT EXISTS idx_data_quality_issues_market_end_time ON data_quality_issues(market, end_time);
@@ -0,0 +1,2 @@
+-- Drop quarantined_rates table
+DROP TABLE IF EXISTS quarantined_rates;
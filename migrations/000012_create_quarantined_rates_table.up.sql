@@ -0,0 +1,17 @@
+-- Create quarantined_rates table; ticks rejected by the price guard are kept here with the reason
+-- instead of being stored in rates
+CREATE TABLE IF NOT EXISTS quarantined_rates (
+    id BIGSERIAL PRIMARY KEY,
+    market TEXT NOT NULL,
+    source TEXT NOT NULL,
+    ask DECIMAL(20, 8) NOT NULL,
+    bid DECIMAL(20, 8) NOT NULL,
+    timestamp TIMESTAMP WITH TIME ZONE NOT NULL,
+    received_at TIMESTAMP WITH TIME ZONE,
+    reason TEXT NOT NULL,
+    detail TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+-- Create index for review queries per market
+CREATE INDEX IF NOT EXISTS idx_quarantined_rates_market_timestamp ON quarantined_rates(market, timestamp DESC);
@@ -0,0 +1,3 @@
+-- Drop rates source file tag
+DROP INDEX IF EXISTS idx_rates_source_file;
+ALTER TABLE rates DROP COLUMN IF EXISTS source_file;
@@ -0,0 +1,5 @@
+-- Tag imported rates with the file they were loaded from; NULL for rates fetched or backfilled
+ALTER TABLE rates ADD COLUMN IF NOT EXISTS source_file TEXT;
+
+-- Create index for reviewing or removing the rates of an imported file
+CREATE INDEX IF NOT EXISTS idx_rates_source_file ON rates(source_file) WHERE source_file IS NOT NULL;
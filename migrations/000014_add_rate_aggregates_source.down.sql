@@ -0,0 +1,26 @@
+-- Merge rate aggregates back into one bucket per market, keeping the open of the earliest and the close
+-- of the latest source bucket
+CREATE TEMPORARY TABLE rate_aggregates_merged AS
+SELECT market, resolution, bucket,
+    (array_agg(ask_open ORDER BY first_at))[1] AS ask_open, max(ask_high) AS ask_high, min(ask_low) AS ask_low,
+    (array_agg(ask_close ORDER BY last_at DESC))[1] AS ask_close,
+    (array_agg(bid_open ORDER BY first_at))[1] AS bid_open, max(bid_high) AS bid_high, min(bid_low) AS bid_low,
+    (array_agg(bid_close ORDER BY last_at DESC))[1] AS bid_close,
+    sum(sample_count) AS sample_count, min(created_at) AS created_at
+FROM rate_aggregates
+GROUP BY market, resolution, bucket;
+
+DELETE FROM rate_aggregates;
+ALTER TABLE rate_aggregates DROP CONSTRAINT IF EXISTS rate_aggregates_pkey;
+ALTER TABLE rate_aggregates DROP COLUMN IF EXISTS source;
+ALTER TABLE rate_aggregates DROP COLUMN IF EXISTS first_at;
+ALTER TABLE rate_aggregates DROP COLUMN IF EXISTS last_at;
+ALTER TABLE rate_aggregates ADD PRIMARY KEY (market, resolution, bucket);
+
+INSERT INTO rate_aggregates (market, resolution, bucket, ask_open, ask_high, ask_low, ask_close,
+    bid_open, bid_high, bid_low, bid_close, sample_count, created_at)
+SELECT market, resolution, bucket, ask_open, ask_high, ask_low, ask_close,
+    bid_open, bid_high, bid_low, bid_close, sample_count, created_at
+FROM rate_aggregates_merged;
+
+DROP TABLE rate_aggregates_merged;
@@ -0,0 +1,17 @@
+-- Keep rate aggregates per source and record the first and last sample time of every bucket, so late rates
+-- can be merged into a bucket instead of being dropped. Buckets written before this change blend every
+-- source under an empty source; their open and close are kept when later rates are merged in
+ALTER TABLE rate_aggregates ADD COLUMN IF NOT EXISTS source TEXT NOT NULL DEFAULT '';
+ALTER TABLE rate_aggregates ALTER COLUMN source DROP DEFAULT;
+
+ALTER TABLE rate_aggregates ADD COLUMN IF NOT EXISTS first_at TIMESTAMP WITH TIME ZONE;
+ALTER TABLE rate_aggregates ADD COLUMN IF NOT EXISTS last_at TIMESTAMP WITH TIME ZONE;
+UPDATE rate_aggregates
+SET first_at = bucket,
+    last_at = bucket + CASE resolution WHEN '1h' THEN INTERVAL '1 hour' ELSE INTERVAL '1 minute' END - INTERVAL '1 microsecond'
+WHERE first_at IS NULL;
+ALTER TABLE rate_aggregates ALTER COLUMN first_at SET NOT NULL;
+ALTER TABLE rate_aggregates ALTER COLUMN last_at SET NOT NULL;
+
+ALTER TABLE rate_aggregates DROP CONSTRAINT IF EXISTS rate_aggregates_pkey;
+ALTER TABLE rate_aggregates ADD PRIMARY KEY (market, source, resolution, bucket);